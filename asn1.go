@@ -0,0 +1,824 @@
+package dcxl
+
+/*
+asn1.go contains the ASN.1 module scanner, which extracts OBJECT IDENTIFIER
+value assignments from ASN.1 source text and resolves them into SubArc
+registrations.
+*/
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+/*
+ASN1Component describes a single component of an OBJECT IDENTIFIER value
+as written within ASN.1 source text, e.g.:
+
+  - internet(1): Name and Number
+  - 4: Number only
+  - iso: Name only (a well-known arc, or a value reference)
+  - PKIX1Explicit88.id-pkix: Ref (an external value reference)
+
+A component bearing only a Name is resolved as a value reference unless
+it names a well-known arc (e.g.: iso, itu-t or identified-organization)
+in a position where such names are permitted, and no value of that name
+is visible.
+*/
+type ASN1Component struct {
+	Name   string
+	Number string
+	Ref    string
+}
+
+/*
+ASN1Assignment describes a single OBJECT IDENTIFIER value assignment, e.g.:
+
+	id-foo OBJECT IDENTIFIER ::= { id-bar 4 }
+*/
+type ASN1Assignment struct {
+	Name       string
+	Components []ASN1Component
+	Line       int
+}
+
+/*
+ASN1Module describes a single ASN.1 module found within ASN.1 source text.
+
+The Name field contains the modulereference of the DEFINITIONS header, and
+the Identifier field contains the components of its module OID, if any.
+
+The Imports field maps each imported symbol to the name of the module from
+which it is imported.
+
+OBJECT IDENTIFIER value assignments appearing outside of any DEFINITIONS
+header (e.g.: within a snippet) are collected within an unnamed module.
+*/
+type ASN1Module struct {
+	Name        string
+	Identifier  []ASN1Component
+	Imports     map[string]string
+	Assignments []ASN1Assignment
+	Line        int
+}
+
+/*
+ASN1ReferenceError describes an OBJECT IDENTIFIER value that could not be
+resolved.
+*/
+type ASN1ReferenceError struct {
+	Module string // modulereference, or zero if unnamed
+	Value  string // valuereference being resolved
+	Line   int    // line number of the assignment, starting at one (1)
+	Ref    string // offending reference or component, if applicable
+	Err    error
+}
+
+/*
+Error returns the string representation of the receiver.
+*/
+func (r ASN1ReferenceError) Error() string {
+	s := sprintf("line %d: %s", r.Line, r.Value)
+	if len(r.Module) > 0 {
+		s = r.Module + `: ` + s
+	}
+	if len(r.Ref) > 0 {
+		s += ` (` + r.Ref + `)`
+	}
+
+	return s + `: ` + r.Err.Error()
+}
+
+/*
+Unwrap returns the underlying error of the receiver.
+*/
+func (r ASN1ReferenceError) Unwrap() error {
+	return r.Err
+}
+
+/*
+asn1Token is a single lexical item of ASN.1 source text.
+*/
+type asn1Token struct {
+	s    string
+	line int
+	str  bool // quoted string, bstring or hstring
+}
+
+/*
+ScanASN1 returns the ASN.1 modules found within the input source text
+alongside an error. Only those constructs relevant to OBJECT IDENTIFIER
+values are retained, namely:
+
+  - module DEFINITIONS headers, including the module OID, if present
+  - IMPORTS clauses
+  - OBJECT IDENTIFIER value assignments
+
+All other constructs (types, other values, information objects, etc.)
+are skipped, as are comments of both the line ("--") and block forms.
+
+An error is returned if the input cannot be read, or if an OBJECT
+IDENTIFIER value assignment is malformed.
+*/
+func ScanASN1(rd io.Reader) (mods []*ASN1Module, err error) {
+	var toks []asn1Token
+	if toks, err = asn1Tokens(rd); err != nil {
+		return
+	}
+
+	var cur, anon *ASN1Module
+	for i := 0; i < len(toks); i++ {
+		switch t := toks[i]; {
+		case t.str:
+			continue
+
+		case t.s == `DEFINITIONS`:
+			cur = &ASN1Module{Imports: make(map[string]string)}
+			if err = asn1Header(cur, toks[:i]); err != nil {
+				return
+			}
+			mods = append(mods, cur)
+			for i < len(toks) && toks[i].s != `BEGIN` {
+				i++
+			}
+
+		case t.s == `IMPORTS` && cur != nil:
+			i = asn1Imports(cur, toks, i+1)
+
+		case t.s == `END` && cur != nil:
+			cur = nil
+
+		case isValueRef(t.s) && i+4 < len(toks) &&
+			toks[i+1].s == `OBJECT` && toks[i+2].s == `IDENTIFIER` && toks[i+3].s == `::=`:
+			a := ASN1Assignment{Name: t.s, Line: t.line}
+			if toks[i+4].s == `{` {
+				if a.Components, i, err = asn1Components(toks, i+4); err != nil {
+					return
+				}
+			} else if isValueRef(toks[i+4].s) {
+				// e.g.: id-foo OBJECT IDENTIFIER ::= id-bar
+				a.Components, i = []ASN1Component{asn1RefComponent(toks, i+4)}, i+4
+				if len(a.Components[0].Ref) > len(toks[i].s) {
+					i += 2
+				}
+			} else {
+				err = errorf("line %d: malformed OBJECT IDENTIFIER value for %s", t.line, t.s)
+				return
+			}
+
+			if cur == nil {
+				if anon == nil {
+					anon = &ASN1Module{Imports: make(map[string]string), Line: t.line}
+					mods = append(mods, anon)
+				}
+				anon.Assignments = append(anon.Assignments, a)
+			} else {
+				cur.Assignments = append(cur.Assignments, a)
+			}
+		}
+	}
+
+	return
+}
+
+/*
+asn1Header populates the Name, Identifier and Line fields of the input
+module, using the tokens preceding the DEFINITIONS keyword.
+*/
+func asn1Header(mod *ASN1Module, toks []asn1Token) (err error) {
+	j := len(toks) - 1
+	if j >= 0 && toks[j].str {
+		j-- // IRI value of the DefinitiveIdentification
+	}
+
+	if j >= 0 && toks[j].s == `}` {
+		k := j
+		for k >= 0 && toks[k].s != `{` {
+			k--
+		}
+		if k < 1 {
+			return errorf("line %d: malformed module identifier", toks[j].line)
+		}
+		if mod.Identifier, _, err = asn1Components(toks, k); err != nil {
+			return
+		}
+		j = k - 1
+	}
+
+	if j < 0 || !isTypeRef(toks[j].s) {
+		line := 1
+		if j >= 0 {
+			line = toks[j].line
+		}
+		return errorf("line %d: DEFINITIONS lacks a modulereference", line)
+	}
+	mod.Name, mod.Line = toks[j].s, toks[j].line
+
+	return
+}
+
+/*
+asn1Imports populates the Imports field of the input module using the
+IMPORTS clause beginning at index i, returning the index of its closing
+semicolon.
+*/
+func asn1Imports(mod *ASN1Module, toks []asn1Token, i int) int {
+	var syms []string
+	for ; i < len(toks) && toks[i].s != `;`; i++ {
+		switch s := toks[i].s; {
+		case s == `FROM` && i+1 < len(toks):
+			i++
+			for _, sym := range syms {
+				mod.Imports[sym] = toks[i].s
+			}
+			syms = syms[:0]
+			if i+1 < len(toks) && toks[i+1].s == `{` {
+				// Skip the AssignedIdentifier.
+				for i < len(toks) && toks[i].s != `}` {
+					i++
+				}
+			}
+		case s == `WITH` && i+1 < len(toks):
+			i++ // e.g.: WITH SUCCESSORS
+		case s == `,` || s == `{` || s == `}`:
+			// Separators and parameterized references, e.g.: Foo{}
+		default:
+			syms = append(syms, s)
+		}
+	}
+
+	return i
+}
+
+/*
+asn1Components returns the components of the brace-enclosed OBJECT
+IDENTIFIER value beginning at index i, alongside the index of the
+closing brace.
+*/
+func asn1Components(toks []asn1Token, i int) (comps []ASN1Component, end int, err error) {
+	start := toks[i].line
+	for end = i + 1; end < len(toks); end++ {
+		t := toks[end]
+		switch {
+		case t.s == `}`:
+			return
+		case isNumber(t.s):
+			comps = append(comps, ASN1Component{Number: t.s})
+		case isValueRef(t.s) && end+3 < len(toks) && toks[end+1].s == `(` && toks[end+3].s == `)`:
+			comps = append(comps, ASN1Component{Name: t.s, Number: toks[end+2].s})
+			end += 3
+		case isValueRef(t.s) || isTypeRef(t.s):
+			c := asn1RefComponent(toks, end)
+			if len(c.Ref) > len(t.s) {
+				end += 2
+			}
+			comps = append(comps, c)
+		default:
+			err = errorf("line %d: unexpected '%s' within OBJECT IDENTIFIER value", t.line, t.s)
+			return
+		}
+	}
+
+	err = errorf("line %d: unterminated OBJECT IDENTIFIER value", start)
+	return
+}
+
+/*
+asn1RefComponent returns the Name-only component, or external reference
+component (Module.value), found at index i.
+*/
+func asn1RefComponent(toks []asn1Token, i int) ASN1Component {
+	if isTypeRef(toks[i].s) && i+2 < len(toks) && toks[i+1].s == `.` {
+		return ASN1Component{Ref: toks[i].s + `.` + toks[i+2].s}
+	}
+
+	return ASN1Component{Name: toks[i].s}
+}
+
+/*
+asn1Tokens returns the lexical items of the input ASN.1 source text,
+less comments.
+*/
+func asn1Tokens(rd io.Reader) (toks []asn1Token, err error) {
+	sc := bufio.NewScanner(rd)
+	sc.Buffer(nil, 1<<20)
+
+	var line, block int // block: nesting depth of /* */ comments
+	var quote byte      // pending multi-line string delimiter, if any
+	for sc.Scan() {
+		line++
+		s := sc.Text()
+		for i := 0; i < len(s); {
+			c := s[i]
+			switch {
+			case quote != 0:
+				if j := indexOf(s[i:], string(quote)); j >= 0 {
+					i, quote = i+j+1, 0
+				} else {
+					i = len(s)
+				}
+			case block > 0:
+				if hasPrefix(s[i:], `*/`) {
+					block--
+					i += 2
+				} else if hasPrefix(s[i:], `/*`) {
+					block++
+					i += 2
+				} else {
+					i++
+				}
+			case hasPrefix(s[i:], `/*`):
+				block++
+				i += 2
+			case hasPrefix(s[i:], `--`):
+				// Ends at the next "--", or the end of line.
+				if j := indexOf(s[i+2:], `--`); j >= 0 {
+					i += j + 4
+				} else {
+					i = len(s)
+				}
+			case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+				i++
+			case c == '"' || c == '\'':
+				toks = append(toks, asn1Token{s: string(c), line: line, str: true})
+				quote = c
+				i++
+			case hasPrefix(s[i:], `::=`):
+				toks = append(toks, asn1Token{s: `::=`, line: line})
+				i += 3
+			case isAlnum(c):
+				j := i + 1
+				for j < len(s) && (isAlnum(s[j]) ||
+					(s[j] == '-' && j+1 < len(s) && isAlnum(s[j+1]))) {
+					j++
+				}
+				toks = append(toks, asn1Token{s: s[i:j], line: line})
+				i = j
+			default:
+				toks = append(toks, asn1Token{s: string(c), line: line})
+				i++
+			}
+		}
+	}
+	err = sc.Err()
+
+	return
+}
+
+func isAlnum(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+/*
+isValueRef returns a boolean value indicative of whether the input is a
+valid valuereference (or identifier), i.e.: begins with a lowercase letter.
+*/
+func isValueRef(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
+
+/*
+isTypeRef returns a boolean value indicative of whether the input is a
+valid typereference or modulereference, i.e.: begins with an uppercase
+letter.
+*/
+func isTypeRef(s string) bool {
+	return len(s) > 0 && 'A' <= s[0] && s[0] <= 'Z'
+}
+
+/*
+asn1Roots contains the well-known root arc names of X.660 and X.680.
+*/
+var asn1Roots = map[string]string{
+	`itu-t`:           `0`,
+	`ccitt`:           `0`,
+	`itu-r`:           `0`,
+	`iso`:             `1`,
+	`joint-iso-itu-t`: `2`,
+	`joint-iso-ccitt`: `2`,
+}
+
+/*
+asn1Seconds contains the well-known second-level arc names beneath the
+itu-t (0) and iso (1) roots.
+*/
+var asn1Seconds = map[string]map[string]string{
+	`0`: {
+		`recommendation`:          `0`,
+		`question`:                `1`,
+		`administration`:          `2`,
+		`network-operator`:        `3`,
+		`identified-organization`: `4`,
+	},
+	`1`: {
+		`standard`:                `0`,
+		`registration-authority`:  `1`,
+		`member-body`:             `2`,
+		`identified-organization`: `3`,
+	},
+}
+
+/*
+iriRoots contains the well-known root arc Unicode labels of X.660, in
+their canonical form.
+*/
+var iriRoots = []string{`ITU-T`, `ISO`, `Joint-ISO-ITU-T`}
+
+/*
+iriSeconds contains the well-known second-level arc Unicode labels
+beneath the ITU-T (0) and ISO (1) roots, indexed by numberForm.
+*/
+var iriSeconds = map[string]map[string]string{
+	`0`: {
+		`0`: `Recommendation`,
+		`2`: `Administration`,
+		`3`: `Network-Operator`,
+		`4`: `Identified-Organization`,
+	},
+	`1`: {
+		`0`: `Standard`,
+		`1`: `Registration-Authority`,
+		`2`: `Member-Body`,
+		`3`: `Identified-Organization`,
+	},
+}
+
+/*
+iriLongArcs contains the well-known long arcs beneath Joint-ISO-ITU-T (2),
+each of which may appear as the first label of an IRI, indexed by
+numberForm.
+*/
+var iriLongArcs = map[string]string{
+	`1`:   `ASN.1`,
+	`16`:  `Country`,
+	`23`:  `International-Organizations`,
+	`25`:  `UUID`,
+	`27`:  `Tag-Based`,
+	`999`: `Example`,
+}
+
+/*
+parseASN1Notation returns the resolved components of the input ASN.1
+Notation value (e.g.: {iso(1) identified-organization(3) 6}), alongside
+an error. Names lacking a number must be well-known arcs.
+*/
+func parseASN1Notation(s string) (arcs []ASN1Component, err error) {
+	var toks []asn1Token
+	if toks, err = asn1Tokens(strings.NewReader(s)); err != nil {
+		return
+	} else if len(toks) == 0 || toks[0].s != `{` {
+		err = errorw(IllegalASN1NotationErr, "%s", s)
+		return
+	}
+
+	a := &ASN1Assignment{Line: 1}
+	var end int
+	if a.Components, end, err = asn1Components(toks, 0); err != nil {
+		err = errorw(IllegalASN1NotationErr, "%v", err)
+		return
+	} else if end != len(toks)-1 {
+		err = errorw(IllegalASN1NotationErr, `trailing text after '}'`)
+		return
+	}
+
+	var imp ASN1Importer
+	imp.reset(nil)
+	var ok bool
+	if arcs, ok = imp.resolve(&ASN1Module{}, a); !ok {
+		err = imp.errs[0].Err
+		if len(imp.errs[0].Ref) > 0 {
+			err = errorw(err, "%s", imp.errs[0].Ref)
+		}
+	}
+
+	return
+}
+
+/*
+ASN1Importer produces SubArc registrations from the OBJECT IDENTIFIER
+value assignments of ASN1Module instances, resolving value references
+within and across modules.
+
+If the DUAConfig field is set, it is used to assign the DN and supArc
+values of each SubArc per the directory model in effect, and is assigned
+to each SubArc.
+
+If the Intermediate field is true, a SubArc is also produced for each
+intermediate arc lacking an assignment of its own (e.g.: dod(6) within
+{iso(1) identified-organization(3) dod(6) internet(1)}), such that the
+output may be written to a directory in which superiors must exist.
+*/
+type ASN1Importer struct {
+	DUAConfig    *DUAConfig
+	Intermediate bool
+
+	mods  map[string]*ASN1Module
+	done  map[*ASN1Assignment][]ASN1Component
+	busy  map[*ASN1Assignment]bool
+	errs  []ASN1ReferenceError
+	fails map[*ASN1Assignment]bool
+}
+
+/*
+Import returns SubArc instances, as Registrations, for each resolvable
+OBJECT IDENTIFIER value assignment (and module identifier) found within
+the input modules, alongside any unresolved references and an error.
+
+Each SubArc bears the n, dotNotation, asn1Notation, identifier and
+nameAndNumberForm values. The identifier is the NameForm of the final
+component, or the valuereference where the final component lacks one.
+Names are supplied for nameless components within asn1Notation where
+another assignment describes the same arc.
+
+Where several assignments describe the same OID, the first produces the
+SubArc and the names of the others are added as additionalIdentifier
+values. Root arcs (0, 1 and 2) are not SubArcs, and are not produced.
+
+The modules should be those of an entire suite, as references to symbols
+imported from modules absent from the input cannot be resolved. Each
+such reference is reported as an ASN1ReferenceError, as are circular
+references. An error is returned only if the DUAConfig field is set,
+but cannot be used to assign DNs.
+*/
+func (r *ASN1Importer) Import(mods []*ASN1Module) (regs Registrations, unresolved []ASN1ReferenceError, err error) {
+	r.reset(mods)
+
+	var oids [][]ASN1Component
+	var names []string
+	for _, mod := range mods {
+		if len(mod.Identifier) > 0 {
+			// The modulereference is not a valuereference, and so
+			// does not name the final arc of the module OID.
+			hdr := &ASN1Assignment{Components: mod.Identifier, Line: mod.Line}
+			if arcs, ok := r.resolve(mod, hdr); ok {
+				oids, names = append(oids, arcs), append(names, ``)
+			}
+		}
+		for i := 0; i < len(mod.Assignments); i++ {
+			if arcs, ok := r.resolve(mod, &mod.Assignments[i]); ok {
+				oids, names = append(oids, arcs), append(names, mod.Assignments[i].Name)
+			}
+		}
+	}
+	unresolved = r.errs
+
+	regs, err = r.registrations(oids, names)
+
+	return
+}
+
+/*
+reset prepares the receiver to resolve the assignments of the input
+modules.
+*/
+func (r *ASN1Importer) reset(mods []*ASN1Module) {
+	r.mods = make(map[string]*ASN1Module, len(mods))
+	r.done = make(map[*ASN1Assignment][]ASN1Component)
+	r.busy = make(map[*ASN1Assignment]bool)
+	r.fails = make(map[*ASN1Assignment]bool)
+	r.errs = nil
+	for _, mod := range mods {
+		if len(mod.Name) > 0 {
+			r.mods[mod.Name] = mod
+		}
+	}
+}
+
+/*
+registrations returns a SubArc for each unique OID among the input
+resolved components, alongside an error.
+*/
+func (r *ASN1Importer) registrations(oids [][]ASN1Component, names []string) (regs Registrations, err error) {
+	known := make(map[string]*SubArc)
+	arcNames := make(map[string]string)
+	var order []string
+	var arcsOf = make(map[string][]ASN1Component)
+
+	add := func(arcs []ASN1Component, name string) {
+		dot := asn1Dot(arcs)
+		if sub, found := known[dot]; found {
+			switch {
+			case len(name) == 0 || name == sub.R_Id:
+			case len(sub.R_Id) == 0:
+				// Created as an unnamed intermediate arc.
+				sub.R_Id, arcNames[dot] = name, name
+			case !strInSlice(name, sub.R_AddlId):
+				sub.R_AddlId = append(sub.R_AddlId, name)
+			}
+			return
+		}
+
+		sub := &SubArc{R_N: arcs[len(arcs)-1].Number, R_DotNot: dot}
+		sub.R_Id = arcs[len(arcs)-1].Name
+		if len(sub.R_Id) == 0 {
+			sub.R_Id = name
+		}
+		if len(name) > 0 && name != sub.R_Id {
+			sub.R_AddlId = []string{name}
+		}
+		if len(sub.R_Id) > 0 {
+			arcNames[dot] = sub.R_Id
+		}
+
+		known[dot], arcsOf[dot] = sub, arcs
+		order = append(order, dot)
+	}
+
+	for i, arcs := range oids {
+		if len(arcs) < 2 {
+			continue // root arc
+		}
+		for j := 2; r.Intermediate && j < len(arcs); j++ {
+			if _, found := known[asn1Dot(arcs[:j])]; !found {
+				add(arcs[:j], ``)
+			}
+		}
+		add(arcs, names[i])
+	}
+
+	for _, dot := range order {
+		sub := known[dot]
+		arcs := arcsOf[dot]
+		comps := make([]string, len(arcs))
+		for i := 0; i < len(arcs); i++ {
+			name := arcs[i].Name
+			if len(name) == 0 {
+				name = arcNames[asn1Dot(arcs[:i+1])]
+			}
+			if len(name) == 0 {
+				comps[i] = arcs[i].Number
+			} else {
+				comps[i] = name + `(` + arcs[i].Number + `)`
+			}
+		}
+		sub.R_ASN1Not = `{` + join(comps, ` `) + `}`
+		if len(sub.R_Id) > 0 {
+			sub.R_NaNF = sub.R_Id + `(` + sub.R_N + `)`
+		}
+
+		if d := r.DUAConfig; d != nil {
+			sub.R_DUAConfig = d
+			if sub.R_DN, err = d.RegistrationDN(dot); err != nil {
+				return
+			}
+			if sub.R_SupArc, err = d.RegistrationDN(asn1Dot(arcs[:len(arcs)-1])); err != nil {
+				return
+			}
+		}
+
+		regs = append(regs, sub)
+	}
+
+	return
+}
+
+/*
+resolve returns the fully resolved components of the input assignment,
+each bearing a Number and, where known, a Name. Failures are recorded
+within the receiver.
+*/
+func (r *ASN1Importer) resolve(mod *ASN1Module, a *ASN1Assignment) (arcs []ASN1Component, ok bool) {
+	if arcs, ok = r.done[a]; ok {
+		return
+	} else if r.fails[a] {
+		return
+	}
+
+	fail := func(ref string, err error) {
+		if r.fails[a] {
+			return // already reported, e.g.: as circular
+		}
+		r.fails[a] = true
+		r.errs = append(r.errs, ASN1ReferenceError{
+			Module: mod.Name, Value: a.Name, Line: a.Line, Ref: ref, Err: err,
+		})
+	}
+
+	if r.busy[a] {
+		fail(a.Name, CircularReferenceErr)
+		return
+	}
+	r.busy[a] = true
+	defer delete(r.busy, a)
+
+	for i, c := range a.Components {
+		switch {
+		case len(c.Number) > 0:
+			if !isNumber(c.Number) {
+				fail(c.Number, errorw(UnresolvedReferenceErr, `INTEGER value references are not supported`))
+				return
+			}
+			arcs = append(arcs, ASN1Component{Name: c.Name, Number: c.Number})
+			continue
+		case len(c.Name) > 0 && i == 0:
+			if _, _, found := r.lookup(mod, c.Name); !found {
+				if n, wk := asn1Roots[c.Name]; wk {
+					arcs = append(arcs, ASN1Component{Name: c.Name, Number: n})
+					continue
+				}
+			}
+		case len(c.Name) > 0 && i == 1 && len(arcs) == 1:
+			if n, wk := asn1Seconds[arcs[0].Number][c.Name]; wk {
+				arcs = append(arcs, ASN1Component{Name: c.Name, Number: n})
+				continue
+			}
+			fallthrough
+		case len(c.Name) > 0:
+			fail(c.Name, errorw(UnresolvedReferenceErr, `not a well-known arc name`))
+			return
+		}
+
+		// Value reference, permitted only as the first component.
+		ref := c.Ref
+		if len(ref) == 0 {
+			ref = c.Name
+		}
+		if i > 0 {
+			fail(ref, errorw(UnresolvedReferenceErr, `value reference must be the first component`))
+			return
+		}
+
+		rmod, ra, found := r.lookup(mod, ref)
+		if !found {
+			fail(ref, errorw(UnresolvedReferenceErr, "%s", r.missing(mod, ref)))
+			return
+		}
+
+		sup, resolved := r.resolve(rmod, ra)
+		if !resolved {
+			fail(ref, errorw(UnresolvedReferenceErr, `referenced value is unresolved`))
+			return
+		}
+		arcs = append(arcs, sup...)
+	}
+
+	if len(arcs) == 0 {
+		fail(``, errorw(UnresolvedReferenceErr, `empty OBJECT IDENTIFIER value`))
+		return
+	}
+
+	arcs = arcs[:len(arcs):len(arcs)]
+	if last := &arcs[len(arcs)-1]; len(last.Name) == 0 && len(a.Components) > 1 && isValueRef(a.Name) {
+		// The valuereference names the final arc, absent a NameForm.
+		arcs = append(arcs[:len(arcs)-1:len(arcs)-1], ASN1Component{Name: a.Name, Number: last.Number})
+	}
+
+	r.done[a], ok = arcs, true
+
+	return
+}
+
+/*
+lookup returns the module and assignment referenced by the input name,
+or Module.value reference, as visible from the input module.
+*/
+func (r *ASN1Importer) lookup(mod *ASN1Module, ref string) (*ASN1Module, *ASN1Assignment, bool) {
+	name := ref
+	if idx := idxRune(ref, '.'); idx > 0 {
+		mod, name = r.mods[ref[:idx]], ref[idx+1:]
+	} else if from, imported := mod.Imports[ref]; imported {
+		mod = r.mods[from]
+	}
+
+	if mod != nil {
+		for i := 0; i < len(mod.Assignments); i++ {
+			if mod.Assignments[i].Name == name {
+				return mod, &mod.Assignments[i], true
+			}
+		}
+	}
+
+	return nil, nil, false
+}
+
+/*
+missing returns a description of why the input reference could not be
+found, as visible from the input module.
+*/
+func (r *ASN1Importer) missing(mod *ASN1Module, ref string) string {
+	from, imported := mod.Imports[ref]
+	if idx := idxRune(ref, '.'); idx > 0 {
+		from, imported = ref[:idx], true
+	}
+
+	if !imported {
+		return `no such value`
+	} else if _, found := r.mods[from]; !found {
+		return sprintf("module %s was not scanned", from)
+	}
+
+	return sprintf("no such value in module %s", from)
+}
+
+/*
+asn1Dot returns the dotNotation value of the input resolved components.
+*/
+func asn1Dot(arcs []ASN1Component) string {
+	nfs := make([]string, len(arcs))
+	for i := 0; i < len(arcs); i++ {
+		nfs[i] = arcs[i].Number
+	}
+
+	return join(nfs, `.`)
+}
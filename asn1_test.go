@@ -0,0 +1,49 @@
+package dcxl
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+TestASN1Importer_intermediate verifies that an arc first produced as an
+unnamed intermediate arc is named by a later assignment, and that a module
+OID is not named after its modulereference.
+*/
+func TestASN1Importer_intermediate(t *testing.T) {
+	const src = `
+ModA { iso(1) 3 6 1 4 1 99 0 1 } DEFINITIONS ::= BEGIN
+END
+ModB DEFINITIONS ::= BEGIN
+id-b OBJECT IDENTIFIER ::= { 1 3 6 1 4 1 99 }
+END`
+
+	mods, err := ScanASN1(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imp := ASN1Importer{Intermediate: true}
+	regs, unresolved, err := imp.Import(mods)
+	if err != nil || len(unresolved) > 0 {
+		t.Fatalf("unexpected errors: %v %v", err, unresolved)
+	}
+
+	for _, tc := range []struct {
+		dot, id, nanf, asn1 string
+	}{
+		{`1.3.6.1.4.1.99`, `id-b`, `id-b(99)`, `{iso(1) 3 6 1 4 1 id-b(99)}`},
+		{`1.3.6.1.4.1.99.0.1`, ``, ``, `{iso(1) 3 6 1 4 1 id-b(99) 0 1}`},
+	} {
+		reg := regs.Lookup(OIDKey, tc.dot)
+		if reg == nil {
+			t.Errorf("%s: not found", tc.dot)
+			continue
+		}
+		sub := reg.(*SubArc)
+		if sub.R_Id != tc.id || sub.R_NaNF != tc.nanf || sub.R_ASN1Not != tc.asn1 || len(sub.R_AddlId) > 0 {
+			t.Errorf("%s: unexpected identifier '%s', nameAndNumberForm '%s', asn1Notation '%s' or additionalIdentifier %v",
+				tc.dot, sub.R_Id, sub.R_NaNF, sub.R_ASN1Not, sub.R_AddlId)
+		}
+	}
+}
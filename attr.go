@@ -0,0 +1,210 @@
+package dcxl
+
+/*
+attr.go contains the dynamic attribute accessors of the entry types,
+which read and write attribute types by name rather than by method.
+*/
+
+/*
+attrEntry is satisfied by pointer instances of the entry types, each of
+which bears the generated attribute methods.
+*/
+type attrEntry interface {
+	attributeTypes() []string
+	multiValued(string) bool
+	getAttr(string) []string
+	setAttr(string, []string)
+}
+
+/*
+resolveAttr returns the name, as used by the input entry, of the input
+attribute type name, alongside a boolean value indicative of whether the
+name is applicable. The name is matched case-insensitively, and may be
+an alternative name.
+*/
+func resolveAttr(e attrEntry, at string) (string, bool) {
+	at = canonicalAttr(trimS(at))
+	for _, name := range e.attributeTypes() {
+		if eq(name, at) {
+			return name, true
+		}
+	}
+
+	return ``, false
+}
+
+/*
+getAttr returns the value(s) of the named attribute type of the input
+entry, or nil if unset or not applicable.
+*/
+func getAttr(e attrEntry, at string) []string {
+	if name, ok := resolveAttr(e, at); ok {
+		return e.getAttr(name)
+	}
+
+	return nil
+}
+
+/*
+setAttr replaces the value(s) of the named attribute type of the input
+entry, returning an error if the name is not applicable, or if several
+values are provided for a single-valued attribute type.
+*/
+func setAttr(e attrEntry, at string, vals []string) error {
+	name, ok := resolveAttr(e, at)
+	if !ok {
+		return errorf("Attribute type '%s' not applicable to %T", at, e)
+	} else if len(vals) > 1 && !e.multiValued(name) {
+		return errorf("Attribute type '%s' is single-valued; %d values provided", name, len(vals))
+	}
+
+	e.setAttr(name, vals)
+
+	return nil
+}
+
+/*
+attributes returns the names of the populated attribute types of the
+input entry, in declaration order.
+*/
+func attributes(e attrEntry) (names []string) {
+	for _, name := range e.attributeTypes() {
+		if len(e.getAttr(name)) > 0 {
+			names = append(names, name)
+		}
+	}
+
+	return
+}
+
+/*
+Get returns the value(s) of the named attribute type, or nil if unset or
+not applicable. The name is matched case-insensitively, and may be an
+alternative name (e.g.: numberForm for n, or nameForm for identifier).
+*/
+func (r RootArc) Get(at string) []string { return getAttr(&r, at) }
+
+/*
+Set replaces the value(s) of the named attribute type, which is matched
+as described for Get. Providing no values clears the attribute type. An
+error is returned if the name is not applicable to the receiver, or if
+several values are provided for a single-valued attribute type.
+*/
+func (r *RootArc) Set(at string, vals ...string) error {
+	if r == nil {
+		return NilRegistrationErr
+	}
+
+	return setAttr(r, at, vals)
+}
+
+/*
+Attributes returns the names of the populated attribute types of the
+receiver, in declaration order.
+*/
+func (r RootArc) Attributes() []string { return attributes(&r) }
+
+/*
+Get returns the value(s) of the named attribute type, or nil if unset or
+not applicable. The name is matched case-insensitively, and may be an
+alternative name (e.g.: numberForm for n, or nameForm for identifier).
+*/
+func (r SubArc) Get(at string) []string { return getAttr(&r, at) }
+
+/*
+Set replaces the value(s) of the named attribute type, which is matched
+as described for Get. Providing no values clears the attribute type. An
+error is returned if the name is not applicable to the receiver, or if
+several values are provided for a single-valued attribute type.
+*/
+func (r *SubArc) Set(at string, vals ...string) error {
+	if r == nil {
+		return NilRegistrationErr
+	}
+
+	return setAttr(r, at, vals)
+}
+
+/*
+Attributes returns the names of the populated attribute types of the
+receiver, in declaration order.
+*/
+func (r SubArc) Attributes() []string { return attributes(&r) }
+
+/*
+Get returns the value(s) of the named attribute type, or nil if unset or
+not applicable. The name is matched case-insensitively.
+*/
+func (r FirstAuthority) Get(at string) []string { return getAttr(&r, at) }
+
+/*
+Set replaces the value(s) of the named attribute type, which is matched
+as described for Get. Providing no values clears the attribute type. An
+error is returned if the name is not applicable to the receiver, or if
+several values are provided for a single-valued attribute type.
+*/
+func (r *FirstAuthority) Set(at string, vals ...string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	return setAttr(r, at, vals)
+}
+
+/*
+Attributes returns the names of the populated attribute types of the
+receiver, in declaration order.
+*/
+func (r FirstAuthority) Attributes() []string { return attributes(&r) }
+
+/*
+Get returns the value(s) of the named attribute type, or nil if unset or
+not applicable. The name is matched case-insensitively.
+*/
+func (r CurrentAuthority) Get(at string) []string { return getAttr(&r, at) }
+
+/*
+Set replaces the value(s) of the named attribute type, which is matched
+as described for Get. Providing no values clears the attribute type. An
+error is returned if the name is not applicable to the receiver, or if
+several values are provided for a single-valued attribute type.
+*/
+func (r *CurrentAuthority) Set(at string, vals ...string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	return setAttr(r, at, vals)
+}
+
+/*
+Attributes returns the names of the populated attribute types of the
+receiver, in declaration order.
+*/
+func (r CurrentAuthority) Attributes() []string { return attributes(&r) }
+
+/*
+Get returns the value(s) of the named attribute type, or nil if unset or
+not applicable. The name is matched case-insensitively.
+*/
+func (r Sponsor) Get(at string) []string { return getAttr(&r, at) }
+
+/*
+Set replaces the value(s) of the named attribute type, which is matched
+as described for Get. Providing no values clears the attribute type. An
+error is returned if the name is not applicable to the receiver, or if
+several values are provided for a single-valued attribute type.
+*/
+func (r *Sponsor) Set(at string, vals ...string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	return setAttr(r, at, vals)
+}
+
+/*
+Attributes returns the names of the populated attribute types of the
+receiver, in declaration order.
+*/
+func (r Sponsor) Attributes() []string { return attributes(&r) }
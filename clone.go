@@ -0,0 +1,809 @@
+package dcxl
+
+/*
+clone.go contains the deep copy, equality and merge methods of the entry
+types, as well as those of the Registrations and Registrants types.
+*/
+
+/*
+MergeStrategy describes the resolution of a conflict encountered during
+a merge, in which a single-valued attribute type (or DN) bears differing
+values within the receiver and the merged instance. Multi-valued attribute
+types never conflict; their values are combined, less duplicates.
+*/
+type MergeStrategy uint8
+
+const (
+	KeepExisting   MergeStrategy = iota // retain the value of the receiver
+	Overwrite                           // adopt the value of the merged instance
+	FailOnConflict                      // abort the merge with an error
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r MergeStrategy) String() string {
+	switch r {
+	case KeepExisting:
+		return `keep`
+	case Overwrite:
+		return `overwrite`
+	case FailOnConflict:
+		return `fail`
+	}
+
+	return `unknown`
+}
+
+/*
+valueEqual returns a boolean value indicative of whether the input values
+of the input attribute type are equal per its matching rule.
+*/
+func valueEqual(at, a, b string) bool {
+	return a == b || compareValues(matchingRuleOf(at), a, b) == 0
+}
+
+/*
+dnEqual returns a boolean value indicative of whether the input DNs are
+equal per distinguishedNameMatch.
+*/
+func dnEqual(a, b string) bool {
+	return a == b || compareValues(dnMatch, a, b) == 0
+}
+
+/*
+valuesEqual returns a boolean value indicative of whether the input sets
+of values of the input attribute type are equal per its matching rule,
+without regard for order.
+*/
+func valuesEqual(at string, a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	used := make([]bool, len(b))
+	for i := 0; i < len(a); i++ {
+		var found bool
+		for j := 0; j < len(b) && !found; j++ {
+			if !used[j] && valueEqual(at, a[i], b[j]) {
+				used[j], found = true, true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+cloneAttrs replaces each multi-valued attribute type of dst, which must be
+a shallow copy of src, with a copy of its values.
+*/
+func cloneAttrs(dst, src attrEntry) {
+	for _, at := range src.attributeTypes() {
+		if src.multiValued(at) {
+			dst.setAttr(at, src.getAttr(at))
+		}
+	}
+}
+
+/*
+equalAttrs returns a boolean value indicative of whether the input entries,
+which must be of the same type, bear equal DNs and attribute values.
+*/
+func equalAttrs(a, b attrEntry, adn, bdn string) bool {
+	if !dnEqual(adn, bdn) {
+		return false
+	}
+
+	for _, at := range a.attributeTypes() {
+		if !valuesEqual(at, a.getAttr(at), b.getAttr(at)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+mergeValue returns the result of merging the input single value (theirs)
+of the input attribute type into ours, alongside an error, per the input
+MergeStrategy.
+*/
+func mergeValue(at, ours, theirs string, s MergeStrategy) (string, error) {
+	switch {
+	case len(theirs) == 0 || valueEqual(at, ours, theirs):
+		return ours, nil
+	case len(ours) == 0 || s == Overwrite:
+		return theirs, nil
+	case s == FailOnConflict:
+		return ours, errorf("Merge conflict for '%s': '%s' differs from '%s'", at, theirs, ours)
+	}
+
+	return ours, nil
+}
+
+/*
+mergeAttrEntries merges the DN and attribute values of src into those of
+dst, which must be of the same type, per the input MergeStrategy.
+*/
+func mergeAttrEntries(dst, src attrEntry, dn *string, srcDN string, s MergeStrategy) (err error) {
+	if s > FailOnConflict {
+		return errorf("Unknown %T '%d'", s, s)
+	}
+
+	merged := *dn
+	if !dnEqual(*dn, srcDN) {
+		if merged, err = mergeValue(`dn`, *dn, srcDN, s); err != nil {
+			return
+		}
+	}
+
+	// Values are staged so that a conflict leaves dst untouched.
+	staged := make(map[string][]string)
+	for _, at := range dst.attributeTypes() {
+		ours, theirs := dst.getAttr(at), src.getAttr(at)
+		if len(theirs) == 0 {
+			continue
+		}
+
+		if dst.multiValued(at) {
+			n := len(ours)
+			for i := 0; i < len(theirs); i++ {
+				var dup bool
+				for j := 0; j < len(ours) && !dup; j++ {
+					dup = valueEqual(at, ours[j], theirs[i])
+				}
+				if !dup {
+					ours = append(ours, theirs[i])
+				}
+			}
+			if len(ours) > n {
+				staged[at] = ours
+			}
+			continue
+		}
+
+		var mine string
+		if len(ours) > 0 {
+			mine = ours[0]
+		}
+		var v string
+		if v, err = mergeValue(at, mine, theirs[0], s); err != nil {
+			return
+		} else if v != mine {
+			staged[at] = []string{v}
+		}
+	}
+
+	*dn = merged
+	for at, v := range staged {
+		dst.setAttr(at, v)
+	}
+
+	return
+}
+
+/*
+Clone returns a deep copy of the receiver, or nil if the receiver is nil.
+The Settings map, and the values therein, are copied.
+*/
+func (r *DUAConfig) Clone() *DUAConfig {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	if r.Settings != nil {
+		c.Settings = make(map[string][]string, len(r.Settings))
+		for k, v := range r.Settings {
+			c.Settings[k] = append([]string(nil), v...)
+		}
+	}
+
+	return &c
+}
+
+func (r *RootArc) clone() *RootArc {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	c.R_FAuthy = r.R_FAuthy.clone()
+	c.R_CAuthy = r.R_CAuthy.clone()
+	c.R_DUAConfig = r.R_DUAConfig.Clone()
+
+	return &c
+}
+
+/*
+Clone returns a deep copy of the receiver, including any COMBINED
+registrants and *DUAConfig instance, none of which are shared with the
+receiver. Nil is returned if the receiver is nil.
+*/
+func (r *RootArc) Clone() Registration {
+	if r == nil {
+		return nil
+	}
+
+	return r.clone()
+}
+
+/*
+Equal returns a boolean value indicative of whether the input Registration
+is a *RootArc bearing the same DN, attribute values and COMBINED registrants
+as the receiver. Values are compared per the matching rules of their
+attribute types, and without regard for order where multi-valued. The
+*DUAConfig instances are not compared.
+*/
+func (r *RootArc) Equal(other Registration) bool {
+	o, ok := other.(*RootArc)
+	switch {
+	case !ok:
+		return false
+	case r == nil || o == nil:
+		return r == o
+	}
+
+	return equalAttrs(r, o, r.R_DN, o.R_DN) &&
+		r.R_FAuthy.Equal(o.R_FAuthy) &&
+		r.R_CAuthy.Equal(o.R_CAuthy)
+}
+
+/*
+Merge merges the DN, attribute values and COMBINED registrants of the
+input Registration, which must be a *RootArc, into the receiver per the
+input MergeStrategy. The *DUAConfig instance of the input is adopted only
+if the receiver has none. An error is returned, and the receiver is left
+unmodified, if a conflict is encountered using the FailOnConflict
+strategy.
+*/
+func (r *RootArc) Merge(other Registration, s MergeStrategy) (err error) {
+	o, ok := other.(*RootArc)
+	switch {
+	case r == nil:
+		return NilRegistrationErr
+	case !ok:
+		return errorf("Cannot merge %T into %T", other, r)
+	case o == nil:
+		return
+	}
+
+	c := r.clone()
+	if err = mergeAttrEntries(c, o, &c.R_DN, o.R_DN, s); err == nil {
+		if c.R_FAuthy, err = mergeFirstAuthority(c.R_FAuthy, o.R_FAuthy, s); err == nil {
+			c.R_CAuthy, err = mergeCurrentAuthority(c.R_CAuthy, o.R_CAuthy, s)
+		}
+	}
+	if err == nil {
+		if c.R_DUAConfig = r.R_DUAConfig; c.R_DUAConfig == nil {
+			c.R_DUAConfig = o.R_DUAConfig.Clone()
+		}
+		*r = *c
+	}
+
+	return
+}
+
+func (r *SubArc) clone() *SubArc {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	c.R_FAuthy = r.R_FAuthy.clone()
+	c.R_CAuthy = r.R_CAuthy.clone()
+	c.R_SAuthy = r.R_SAuthy.clone()
+	c.R_DUAConfig = r.R_DUAConfig.Clone()
+
+	return &c
+}
+
+/*
+Clone returns a deep copy of the receiver, including any COMBINED
+registrants and *DUAConfig instance, none of which are shared with the
+receiver. Nil is returned if the receiver is nil.
+*/
+func (r *SubArc) Clone() Registration {
+	if r == nil {
+		return nil
+	}
+
+	return r.clone()
+}
+
+/*
+Equal returns a boolean value indicative of whether the input Registration
+is a *SubArc bearing the same DN, attribute values and COMBINED registrants
+as the receiver. Values are compared per the matching rules of their
+attribute types, and without regard for order where multi-valued. The
+*DUAConfig instances are not compared.
+*/
+func (r *SubArc) Equal(other Registration) bool {
+	o, ok := other.(*SubArc)
+	switch {
+	case !ok:
+		return false
+	case r == nil || o == nil:
+		return r == o
+	}
+
+	return equalAttrs(r, o, r.R_DN, o.R_DN) &&
+		r.R_FAuthy.Equal(o.R_FAuthy) &&
+		r.R_CAuthy.Equal(o.R_CAuthy) &&
+		r.R_SAuthy.Equal(o.R_SAuthy)
+}
+
+/*
+Merge merges the DN, attribute values and COMBINED registrants of the
+input Registration, which must be a *SubArc, into the receiver per the
+input MergeStrategy. The *DUAConfig instance of the input is adopted only
+if the receiver has none. An error is returned, and the receiver is left
+unmodified, if a conflict is encountered using the FailOnConflict
+strategy.
+*/
+func (r *SubArc) Merge(other Registration, s MergeStrategy) (err error) {
+	o, ok := other.(*SubArc)
+	switch {
+	case r == nil:
+		return NilRegistrationErr
+	case !ok:
+		return errorf("Cannot merge %T into %T", other, r)
+	case o == nil:
+		return
+	}
+
+	c := r.clone()
+	if err = mergeAttrEntries(c, o, &c.R_DN, o.R_DN, s); err == nil {
+		if c.R_FAuthy, err = mergeFirstAuthority(c.R_FAuthy, o.R_FAuthy, s); err == nil {
+			if c.R_CAuthy, err = mergeCurrentAuthority(c.R_CAuthy, o.R_CAuthy, s); err == nil {
+				c.R_SAuthy, err = mergeSponsor(c.R_SAuthy, o.R_SAuthy, s)
+			}
+		}
+	}
+	if err == nil {
+		if c.R_DUAConfig = r.R_DUAConfig; c.R_DUAConfig == nil {
+			c.R_DUAConfig = o.R_DUAConfig.Clone()
+		}
+		*r = *c
+	}
+
+	return
+}
+
+func (r *FirstAuthority) clone() *FirstAuthority {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	c.R_DUAConfig = r.R_DUAConfig.Clone()
+
+	return &c
+}
+
+/*
+Clone returns a deep copy of the receiver, including its *DUAConfig
+instance, or nil if the receiver is nil.
+*/
+func (r *FirstAuthority) Clone() Registrant {
+	if r == nil {
+		return nil
+	}
+
+	return r.clone()
+}
+
+/*
+Equal returns a boolean value indicative of whether the input Registrant
+is a *FirstAuthority bearing the same DN and attribute values as the
+receiver. Values are compared per the matching rules of their attribute
+types. The *DUAConfig instances are not compared.
+*/
+func (r *FirstAuthority) Equal(other Registrant) bool {
+	o, ok := other.(*FirstAuthority)
+	switch {
+	case !ok:
+		return false
+	case r == nil || o == nil:
+		return r == o
+	}
+
+	return equalAttrs(r, o, r.R_DN, o.R_DN)
+}
+
+/*
+Merge merges the DN and attribute values of the input Registrant, which
+must be a *FirstAuthority, into the receiver per the input MergeStrategy.
+The *DUAConfig instance of the input is adopted only if the receiver has
+none. An error is returned, and the receiver is left unmodified, if a
+conflict is encountered using the FailOnConflict strategy.
+*/
+func (r *FirstAuthority) Merge(other Registrant, s MergeStrategy) (err error) {
+	o, ok := other.(*FirstAuthority)
+	switch {
+	case r == nil:
+		return NilRegistrantErr
+	case !ok:
+		return errorf("Cannot merge %T into %T", other, r)
+	case o == nil:
+		return
+	}
+
+	if err = mergeAttrEntries(r, o, &r.R_DN, o.R_DN, s); err == nil && r.R_DUAConfig == nil {
+		r.R_DUAConfig = o.R_DUAConfig.Clone()
+	}
+
+	return
+}
+
+func (r *CurrentAuthority) clone() *CurrentAuthority {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	c.R_DUAConfig = r.R_DUAConfig.Clone()
+
+	return &c
+}
+
+/*
+Clone returns a deep copy of the receiver, including its *DUAConfig
+instance, or nil if the receiver is nil.
+*/
+func (r *CurrentAuthority) Clone() Registrant {
+	if r == nil {
+		return nil
+	}
+
+	return r.clone()
+}
+
+/*
+Equal returns a boolean value indicative of whether the input Registrant
+is a *CurrentAuthority bearing the same DN and attribute values as the
+receiver. Values are compared per the matching rules of their attribute
+types. The *DUAConfig instances are not compared.
+*/
+func (r *CurrentAuthority) Equal(other Registrant) bool {
+	o, ok := other.(*CurrentAuthority)
+	switch {
+	case !ok:
+		return false
+	case r == nil || o == nil:
+		return r == o
+	}
+
+	return equalAttrs(r, o, r.R_DN, o.R_DN)
+}
+
+/*
+Merge merges the DN and attribute values of the input Registrant, which
+must be a *CurrentAuthority, into the receiver per the input MergeStrategy.
+The *DUAConfig instance of the input is adopted only if the receiver has
+none. An error is returned, and the receiver is left unmodified, if a
+conflict is encountered using the FailOnConflict strategy.
+*/
+func (r *CurrentAuthority) Merge(other Registrant, s MergeStrategy) (err error) {
+	o, ok := other.(*CurrentAuthority)
+	switch {
+	case r == nil:
+		return NilRegistrantErr
+	case !ok:
+		return errorf("Cannot merge %T into %T", other, r)
+	case o == nil:
+		return
+	}
+
+	if err = mergeAttrEntries(r, o, &r.R_DN, o.R_DN, s); err == nil && r.R_DUAConfig == nil {
+		r.R_DUAConfig = o.R_DUAConfig.Clone()
+	}
+
+	return
+}
+
+func (r *Sponsor) clone() *Sponsor {
+	if r == nil {
+		return nil
+	}
+
+	c := *r
+	cloneAttrs(&c, r)
+	c.R_DUAConfig = r.R_DUAConfig.Clone()
+
+	return &c
+}
+
+/*
+Clone returns a deep copy of the receiver, including its *DUAConfig
+instance, or nil if the receiver is nil.
+*/
+func (r *Sponsor) Clone() Registrant {
+	if r == nil {
+		return nil
+	}
+
+	return r.clone()
+}
+
+/*
+Equal returns a boolean value indicative of whether the input Registrant
+is a *Sponsor bearing the same DN and attribute values as the receiver.
+Values are compared per the matching rules of their attribute types. The
+*DUAConfig instances are not compared.
+*/
+func (r *Sponsor) Equal(other Registrant) bool {
+	o, ok := other.(*Sponsor)
+	switch {
+	case !ok:
+		return false
+	case r == nil || o == nil:
+		return r == o
+	}
+
+	return equalAttrs(r, o, r.R_DN, o.R_DN)
+}
+
+/*
+Merge merges the DN and attribute values of the input Registrant, which
+must be a *Sponsor, into the receiver per the input MergeStrategy. The
+*DUAConfig instance of the input is adopted only if the receiver has
+none. An error is returned, and the receiver is left unmodified, if a
+conflict is encountered using the FailOnConflict strategy.
+*/
+func (r *Sponsor) Merge(other Registrant, s MergeStrategy) (err error) {
+	o, ok := other.(*Sponsor)
+	switch {
+	case r == nil:
+		return NilRegistrantErr
+	case !ok:
+		return errorf("Cannot merge %T into %T", other, r)
+	case o == nil:
+		return
+	}
+
+	if err = mergeAttrEntries(r, o, &r.R_DN, o.R_DN, s); err == nil && r.R_DUAConfig == nil {
+		r.R_DUAConfig = o.R_DUAConfig.Clone()
+	}
+
+	return
+}
+
+/*
+mergeFirstAuthority returns the result of merging the COMBINED registrant
+theirs into ours, either of which may be nil, alongside an error.
+*/
+func mergeFirstAuthority(ours, theirs *FirstAuthority, s MergeStrategy) (*FirstAuthority, error) {
+	if ours == nil {
+		return theirs.clone(), nil
+	}
+
+	return ours, ours.Merge(theirs, s)
+}
+
+/*
+mergeCurrentAuthority returns the result of merging the COMBINED
+registrant theirs into ours, either of which may be nil, alongside an
+error.
+*/
+func mergeCurrentAuthority(ours, theirs *CurrentAuthority, s MergeStrategy) (*CurrentAuthority, error) {
+	if ours == nil {
+		return theirs.clone(), nil
+	}
+
+	return ours, ours.Merge(theirs, s)
+}
+
+/*
+mergeSponsor returns the result of merging the COMBINED registrant theirs
+into ours, either of which may be nil, alongside an error.
+*/
+func mergeSponsor(ours, theirs *Sponsor, s MergeStrategy) (*Sponsor, error) {
+	if ours == nil {
+		return theirs.clone(), nil
+	}
+
+	return ours, ours.Merge(theirs, s)
+}
+
+/*
+Clone returns a deep copy of the receiver, in which each Registration is
+cloned. Nil slices remain nil.
+*/
+func (r Registrations) Clone() (c Registrations) {
+	if r == nil {
+		return
+	}
+
+	c = make(Registrations, len(r))
+	for i := 0; i < len(r); i++ {
+		if r[i] != nil {
+			c[i] = r[i].Clone()
+		}
+	}
+
+	return
+}
+
+/*
+Equal returns a boolean value indicative of whether the receiver and the
+input instance are of equal length, and bear Equal Registrations in the
+same order.
+*/
+func (r Registrations) Equal(other Registrations) bool {
+	if len(r) != len(other) {
+		return false
+	}
+
+	for i := 0; i < len(r); i++ {
+		if r[i] == nil || other[i] == nil {
+			if r[i] != other[i] {
+				return false
+			}
+		} else if !r[i].Equal(other[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Merge returns a clone of the receiver into which the input Registrations
+have been merged per the input MergeStrategy, alongside an error. Each
+input Registration is merged into the receiver's Registration bearing the
+same dotNotation (or, failing that, DN), if any; otherwise, a clone of
+it is appended. Neither the receiver nor the input are modified.
+*/
+func (r Registrations) Merge(other Registrations, s MergeStrategy) (merged Registrations, err error) {
+	merged = r.Clone()
+	index := make(map[string]Registration)
+	for i := 0; i < len(merged); i++ {
+		if key := registrationKey(merged[i]); len(key) > 0 {
+			index[key] = merged[i]
+		}
+	}
+
+	for i := 0; i < len(other); i++ {
+		if other[i] == nil {
+			continue
+		}
+
+		key := registrationKey(other[i])
+		if reg, found := index[key]; found && len(key) > 0 {
+			if err = reg.Merge(other[i], s); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		c := other[i].Clone()
+		merged = append(merged, c)
+		if len(key) > 0 {
+			index[key] = c
+		}
+	}
+
+	return
+}
+
+/*
+registrationKey returns the key by which the input Registration is
+matched during a merge.
+*/
+func registrationKey(reg Registration) string {
+	if oid := treeOID(reg); len(oid) > 0 {
+		return `oid:` + oid
+	} else if reg != nil && len(reg.DN()) > 0 {
+		return `dn:` + normalizeDN(reg.DN())
+	}
+
+	return ``
+}
+
+/*
+Clone returns a deep copy of the receiver, in which each Registrant is
+cloned. Nil slices remain nil.
+*/
+func (r Registrants) Clone() (c Registrants) {
+	if r == nil {
+		return
+	}
+
+	c = make(Registrants, len(r))
+	for i := 0; i < len(r); i++ {
+		if !isNilRegistrant(r[i]) {
+			c[i] = r[i].Clone()
+		}
+	}
+
+	return
+}
+
+/*
+Equal returns a boolean value indicative of whether the receiver and the
+input instance are of equal length, and bear Equal Registrants in the
+same order.
+*/
+func (r Registrants) Equal(other Registrants) bool {
+	if len(r) != len(other) {
+		return false
+	}
+
+	for i := 0; i < len(r); i++ {
+		if isNilRegistrant(r[i]) || isNilRegistrant(other[i]) {
+			if isNilRegistrant(r[i]) != isNilRegistrant(other[i]) {
+				return false
+			}
+		} else if !r[i].Equal(other[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Merge returns a clone of the receiver into which the input Registrants
+have been merged per the input MergeStrategy, alongside an error. Each
+input Registrant is merged into the receiver's Registrant of the same
+type bearing the same DN (or, failing that, registrantID), if any;
+otherwise, a clone of it is appended. Neither the receiver nor the input
+are modified.
+*/
+func (r Registrants) Merge(other Registrants, s MergeStrategy) (merged Registrants, err error) {
+	merged = r.Clone()
+	index := make(map[string]Registrant)
+	for i := 0; i < len(merged); i++ {
+		if key := registrantKey(merged[i]); len(key) > 0 {
+			index[key] = merged[i]
+		}
+	}
+
+	for i := 0; i < len(other); i++ {
+		if isNilRegistrant(other[i]) {
+			continue
+		}
+
+		key := registrantKey(other[i])
+		if rant, found := index[key]; found && len(key) > 0 {
+			if err = rant.Merge(other[i], s); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		c := other[i].Clone()
+		merged = append(merged, c)
+		if len(key) > 0 {
+			index[key] = c
+		}
+	}
+
+	return
+}
+
+/*
+registrantKey returns the key by which the input Registrant is matched
+during a merge.
+*/
+func registrantKey(rant Registrant) string {
+	switch {
+	case isNilRegistrant(rant):
+	case len(rant.DN()) > 0:
+		return rant.Type() + `:dn:` + normalizeDN(rant.DN())
+	case len(rant.RegistrantID()) > 0:
+		return rant.Type() + `:id:` + rant.RegistrantID()
+	}
+
+	return ``
+}
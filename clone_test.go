@@ -0,0 +1,33 @@
+package dcxl
+
+import "testing"
+
+/*
+TestSubArc_Merge_dn verifies that DNs are compared per distinguishedNameMatch
+when merging, as they are by the Equal method.
+*/
+func TestSubArc_Merge_dn(t *testing.T) {
+	X := new(SubArc)
+	X.SetDN(`n=6,n=3,n=1,ou=Registrations,o=rA`)
+	X.SetN(`6`)
+
+	Y := X.Clone().(*SubArc)
+	Y.SetDN(`N=6, n=3, n=1, OU=registrations, o=RA`)
+	if !X.Equal(Y) {
+		t.Fatalf("expected %s and %s to be equal", X.DN(), Y.DN())
+	}
+
+	for _, s := range []MergeStrategy{KeepExisting, Overwrite, FailOnConflict} {
+		Z := X.Clone().(*SubArc)
+		if err := Z.Merge(Y, s); err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if Z.DN() != X.DN() {
+			t.Errorf("%s: expected DN '%s', got '%s'", s, X.DN(), Z.DN())
+		}
+	}
+
+	Y.SetDN(`n=7,n=3,n=1,ou=Registrations,o=rA`)
+	if err := X.Clone().Merge(Y, FailOnConflict); err == nil {
+		t.Errorf("expected a conflict for '%s'", Y.DN())
+	}
+}
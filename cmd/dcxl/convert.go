@@ -0,0 +1,196 @@
+package main
+
+/*
+convert.go contains the convert command, which converts OIDs between the
+dotNotation, DN, ASN.1 Notation and OID-IRI forms.
+*/
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+/*
+forms contains the names of the supported OID forms, in output order.
+*/
+var forms = []string{`dot`, `dn`, `asn1`, `iri`}
+
+/*
+conversion describes the result of converting a single value.
+*/
+type conversion struct {
+	Input        string `json:"input"`
+	DotNotation  string `json:"dotNotation,omitempty"`
+	DN           string `json:"dn,omitempty"`
+	ASN1Notation string `json:"asn1Notation,omitempty"`
+	IRI          string `json:"iri,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func (r conversion) form(f string) string {
+	switch f {
+	case `dot`:
+		return r.DotNotation
+	case `dn`:
+		return r.DN
+	case `asn1`:
+		return r.ASN1Notation
+	}
+
+	return r.IRI
+}
+
+func runConvert(e *env, args []string) int {
+	fs := e.flagSet(`convert`, `[value ...]`)
+	var cfg configFlags
+	var out output
+	cfg.register(fs)
+	out.register(fs)
+	from := fs.String(`from`, `auto`, `input form: auto, dot, dn, asn1 or iri`)
+	to := fs.String(`to`, `all`, `output form: all, dot, dn, asn1 or iri`)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: dcxl convert [flags] [value ...]\n\n"+
+			"Values are read from standard input, one per line, if none are given.\n"+
+			"With -to all, text output bears the dot, dn, asn1 and iri forms of each\n"+
+			"value, separated by tabs; the dn form is omitted if no DUAConfig is given.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if code, ok := parse(fs, args); !ok {
+		return code
+	}
+
+	d, err := cfg.config()
+	if err == nil {
+		err = out.check()
+	}
+	if err == nil && *from != `auto` && !contains(forms, *from) {
+		err = fmt.Errorf("unknown input form %q", *from)
+	}
+	if err == nil && *to != `all` && !contains(forms, *to) {
+		err = fmt.Errorf("unknown output form %q", *to)
+	}
+	if err == nil && d == nil && (*from == `dn` || *to == `dn`) {
+		err = fmt.Errorf("a DUAConfig is required to convert DNs")
+	}
+	if err != nil {
+		e.errorf("convert: %v", err)
+		return exitUsage
+	}
+
+	vals := fs.Args()
+	if len(vals) == 0 {
+		sc := bufio.NewScanner(e.stdin)
+		for sc.Scan() {
+			if v := strings.TrimSpace(sc.Text()); len(v) > 0 {
+				vals = append(vals, v)
+			}
+		}
+		if err = sc.Err(); err != nil {
+			e.errorf("convert: %v", err)
+			return exitUsage
+		}
+	}
+
+	code := exitOK
+	results := make([]conversion, 0, len(vals))
+	for _, v := range vals {
+		c := convert(v, *from, d)
+		if len(c.Error) == 0 && *to != `all` && len(c.form(*to)) == 0 {
+			c.Error = fmt.Sprintf("no %s form", *to)
+		}
+		if len(c.Error) > 0 {
+			code = exitInvalid
+			if !out.json() {
+				e.errorf("convert: %s: %s", v, c.Error)
+			}
+		}
+		results = append(results, c)
+	}
+
+	if out.json() {
+		if err = writeJSON(e.stdout, results); err != nil {
+			e.errorf("convert: %v", err)
+			return exitUsage
+		}
+		return code
+	}
+
+	for _, c := range results {
+		switch {
+		case len(c.Error) > 0:
+		case *to != `all`:
+			fmt.Fprintln(e.stdout, c.form(*to))
+		default:
+			fmt.Fprintf(e.stdout, "%s\t%s\t%s\t%s\n", c.DotNotation, c.DN, c.ASN1Notation, c.IRI)
+		}
+	}
+
+	return code
+}
+
+/*
+convert returns the conversion of the input value, which is in the
+specified form (or inferred, if "auto"), into all other forms.
+*/
+func convert(v, from string, d *dcxl.DUAConfig) (c conversion) {
+	c.Input = v
+	if from == `auto` {
+		from = inferForm(v)
+	}
+
+	var x any
+	var err error
+	switch from {
+	case `dot`:
+		x = v
+	case `dn`:
+		if d == nil {
+			err = fmt.Errorf("a DUAConfig is required to convert DNs")
+		} else {
+			x, err = d.RegistrationOID(v)
+		}
+	case `asn1`:
+		x, err = dcxl.ASN1NotToDotNot(v, nil)
+	case `iri`:
+		x, err = dcxl.IRIToDotNot(v, nil)
+	}
+
+	if err == nil {
+		c.DotNotation = x.(string)
+		if x, err = dcxl.DotNotToASN1Not(c.DotNotation, nil); err == nil {
+			c.ASN1Notation = x.(string)
+			x, _ = dcxl.DotNotToIRI(c.DotNotation, nil)
+			c.IRI = x.(string)
+			if d != nil {
+				c.DN, err = d.RegistrationDN(c.DotNotation)
+			}
+		}
+	}
+
+	if err != nil {
+		c = conversion{Input: v, Error: err.Error()}
+	}
+
+	return
+}
+
+/*
+inferForm returns the form of the input value: asn1 if it begins with a
+brace, iri if it begins with a solidus, dn if it bears an equals sign,
+else dot.
+*/
+func inferForm(v string) string {
+	switch {
+	case strings.HasPrefix(v, `{`):
+		return `asn1`
+	case strings.HasPrefix(v, `/`):
+		return `iri`
+	case strings.Contains(v, `=`):
+		return `dn`
+	}
+
+	return `dot`
+}
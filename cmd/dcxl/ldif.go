@@ -0,0 +1,87 @@
+package main
+
+/*
+ldif.go contains the ldif command, which generates LDIF entries from CSV,
+JSON or LDIF input.
+*/
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+func runLDIF(e *env, args []string) int {
+	fs := e.flagSet(`ldif`, `[file]`)
+	var cfg configFlags
+	cfg.register(fs)
+	format := fs.String(`f`, ``, `input format: ldif, json or csv (default: inferred from extension)`)
+	outFile := fs.String(`out`, ``, `output file (default: standard output)`)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: dcxl ldif [flags] [file]\n\n"+
+			"Entries lacking a DN are assigned one using the DUAConfig, if given.\n"+
+			"Invalid CSV rows, and entries lacking a DN, are reported upon standard\n"+
+			"error, and no output is written.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if code, ok := parse(fs, args); !ok {
+		return code
+	} else if fs.NArg() > 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	d, err := cfg.config()
+	if err != nil {
+		e.errorf("ldif: %v", err)
+		return exitUsage
+	}
+
+	rc, f, err := e.open(fs.Arg(0), *format)
+	if err != nil {
+		e.errorf("ldif: %v", err)
+		return exitUsage
+	}
+	defer rc.Close()
+
+	entries, probs, err := readEntries(rc, f, d)
+	if err != nil {
+		e.errorf("ldif: %v", err)
+		return exitUsage
+	}
+
+	for i, ent := range entries {
+		if len(ent.DN) == 0 {
+			probs = append(probs, problem{
+				Entry:   fmt.Sprintf("entry %d", i+1),
+				Attr:    `dn`,
+				Message: `no DN, and none could be derived`,
+			})
+		}
+	}
+	if len(probs) > 0 {
+		for _, p := range probs {
+			e.errorf("ldif: %s", p)
+		}
+		return exitInvalid
+	}
+
+	w := e.stdout
+	if len(*outFile) > 0 {
+		fh, err := os.Create(*outFile)
+		if err != nil {
+			e.errorf("ldif: %v", err)
+			return exitUsage
+		}
+		defer fh.Close()
+		w = fh
+	}
+
+	if err = dcxl.WriteLDIF(w, entries...); err != nil {
+		e.errorf("ldif: %v", err)
+		return exitUsage
+	}
+
+	return exitOK
+}
@@ -0,0 +1,508 @@
+/*
+Command dcxl converts, validates and inspects X.660 registrations and
+registrants per draft-coretta-x660-ldap, using package dcxl.
+
+Usage:
+
+	dcxl <command> [flags] [arguments]
+
+The commands are:
+
+	convert   convert OIDs between dotNotation, DN, ASN.1 and IRI forms
+	validate  validate LDIF, JSON or CSV entries
+	ldif      generate LDIF entries from CSV, JSON or LDIF input
+	schema    export the draft schema
+	tree      print the hierarchy of registrations
+
+Run "dcxl <command> -h" for the flags of a command.
+
+Commands that deal with DNs accept a DUAConfig, either as a JSON file
+(-config), as produced by encoding a *dcxl.DUAConfig, or by way of the
+-model, -base and -registrants flags.
+
+Input files are read from standard input if named "-" or omitted. Their
+format is inferred from the file extension (.ldif, .json or .csv) unless
+specified using the -f flag.
+
+Commands supporting the -o flag produce human-readable text by default,
+and JSON if "-o json" is specified.
+
+# Exit Status
+
+	0  success
+	1  invalid input (e.g.: validation problems, or values that could not be converted)
+	2  usage error, or an input that could not be read
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+/*
+command describes a single dcxl subcommand.
+*/
+type command struct {
+	name  string
+	short string
+	run   func(*env, []string) int
+}
+
+var commands = []command{
+	{`convert`, `convert OIDs between dotNotation, DN, ASN.1 and IRI forms`, runConvert},
+	{`validate`, `validate LDIF, JSON or CSV entries`, runValidate},
+	{`ldif`, `generate LDIF entries from CSV, JSON or LDIF input`, runLDIF},
+	{`schema`, `export the draft schema`, runSchema},
+	{`tree`, `print the hierarchy of registrations`, runTree},
+}
+
+/*
+env contains the standard streams of an invocation.
+*/
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], &env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}))
+}
+
+/*
+run executes the command described by the input arguments, returning the
+exit status.
+*/
+func run(args []string, e *env) int {
+	if len(args) == 0 || args[0] == `-h` || args[0] == `-help` || args[0] == `help` {
+		usage(e.stderr)
+		return exitUsage
+	}
+
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c.run(e, args[1:])
+		}
+	}
+
+	e.errorf("unknown command %q", args[0])
+	usage(e.stderr)
+
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintf(w, "usage: dcxl <command> [flags] [arguments]\n\ncommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-9s %s\n", c.name, c.short)
+	}
+}
+
+/*
+errorf writes a diagnostic message upon the standard error stream.
+*/
+func (r *env) errorf(format string, x ...any) {
+	fmt.Fprintf(r.stderr, "dcxl: "+format+"\n", x...)
+}
+
+/*
+flagSet returns a new *flag.FlagSet for the named command, which writes
+its diagnostics upon the standard error stream.
+*/
+func (r *env) flagSet(name, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(r.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(r.stderr, "usage: dcxl %s [flags] %s\n\nflags:\n", name, args)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+/*
+parse parses the input arguments using the input *flag.FlagSet, returning
+an exit status and false upon failure.
+*/
+func parse(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+
+	return exitOK, true
+}
+
+/*
+output describes the -o flag common to several commands.
+*/
+type output struct {
+	mode string
+}
+
+func (r *output) register(fs *flag.FlagSet) {
+	fs.StringVar(&r.mode, `o`, `text`, `output mode: text or json`)
+}
+
+func (r *output) json() bool {
+	return r.mode == `json`
+}
+
+func (r *output) check() error {
+	if r.mode != `text` && r.mode != `json` {
+		return fmt.Errorf("unknown output mode %q (want text or json)", r.mode)
+	}
+
+	return nil
+}
+
+/*
+writeJSON writes the input value upon the input io.Writer as indented
+JSON.
+*/
+func writeJSON(w io.Writer, x any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent(``, `  `)
+
+	return enc.Encode(x)
+}
+
+/*
+configFlags describes the flags used to compose a *dcxl.DUAConfig.
+*/
+type configFlags struct {
+	file        string
+	model       string
+	base        string
+	registrants string
+}
+
+func (r *configFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&r.file, `config`, ``, `JSON file containing a DUAConfig`)
+	fs.StringVar(&r.model, `model`, ``, `directory model: 2d or 3d`)
+	fs.StringVar(&r.base, `base`, ``, `registration base DN`)
+	fs.StringVar(&r.registrants, `registrants`, ``, `registrant base DN`)
+}
+
+/*
+config returns the *dcxl.DUAConfig described by the receiver, or nil if
+no relevant flags were specified, alongside an error.
+*/
+func (r *configFlags) config() (d *dcxl.DUAConfig, err error) {
+	if len(r.file) > 0 {
+		var b []byte
+		if b, err = os.ReadFile(r.file); err != nil {
+			return
+		}
+		d = dcxl.NewDUAConfig()
+		if err = json.Unmarshal(b, d); err != nil {
+			return nil, fmt.Errorf("%s: %v", r.file, err)
+		}
+	}
+
+	if len(r.model)+len(r.base)+len(r.registrants) > 0 {
+		if d == nil {
+			d = dcxl.NewDUAConfig()
+		}
+		switch strings.ToLower(r.model) {
+		case ``:
+		case `2d`, dcxl.TwoDimensional:
+			d.DirectoryModel = dcxl.TwoDimensional
+		case `3d`, dcxl.ThreeDimensional:
+			d.DirectoryModel = dcxl.ThreeDimensional
+		default:
+			return nil, fmt.Errorf("unknown directory model %q (want 2d or 3d)", r.model)
+		}
+		if len(r.base) > 0 {
+			d.Registrations = []string{r.base}
+		}
+		if len(r.registrants) > 0 {
+			d.Registrants = []string{r.registrants}
+		}
+	}
+
+	if d != nil && !d.Valid() {
+		err = fmt.Errorf("%v: a directory model and a base DN are required", dcxl.DUAConfigValidityErr)
+	}
+
+	return
+}
+
+/*
+open returns the named input file, or the standard input stream if the
+name is "-" or zero, alongside the input format. If format is zero, it is
+inferred from the file extension.
+*/
+func (r *env) open(name, format string) (rc io.ReadCloser, f string, err error) {
+	f = strings.ToLower(format)
+	if len(name) == 0 || name == `-` {
+		rc = io.NopCloser(r.stdin)
+	} else if rc, err = os.Open(name); err != nil {
+		return
+	}
+
+	if len(f) == 0 {
+		f = strings.TrimPrefix(strings.ToLower(filepath.Ext(name)), `.`)
+	}
+
+	switch f {
+	case `ldif`, `json`, `csv`:
+	case ``:
+		err = fmt.Errorf("cannot infer the format of %q; specify -f", name)
+	default:
+		err = fmt.Errorf("unknown input format %q (want ldif, json or csv)", f)
+	}
+	if err != nil {
+		rc.Close()
+		rc = nil
+	}
+
+	return
+}
+
+/*
+problem describes a single invalid entry, row or value. The Value,
+Section and Severity fields are populated from any *dcxl.ValidationError
+from which the problem is derived.
+*/
+type problem struct {
+	Entry    string `json:"entry"`
+	Attr     string `json:"attribute,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Section  string `json:"section,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	Message  string `json:"message"`
+}
+
+/*
+newProblem returns a problem describing the input error, which concerns
+the input entry and attribute type.
+*/
+func newProblem(entry, attr string, err error) problem {
+	p := problem{Entry: entry, Attr: attr, Message: err.Error()}
+
+	var verr *dcxl.ValidationError
+	if errors.As(err, &verr) {
+		if len(verr.Attr) > 0 {
+			p.Attr = verr.Attr
+		}
+		p.Value = verr.Value
+		p.Section = verr.Section
+		p.Severity = verr.Severity.String()
+		p.Message = verr.Msg
+	}
+
+	return p
+}
+
+func (r problem) String() string {
+	msg := r.Message
+	if len(r.Value) > 0 {
+		msg += `: '` + r.Value + `'`
+	}
+	if len(r.Section) > 0 {
+		msg += ` (s. ` + r.Section + `)`
+	}
+	if r.Severity == dcxl.SeverityWarning.String() {
+		msg = r.Severity + `: ` + msg
+	}
+
+	if len(r.Attr) == 0 {
+		return r.Entry + `: ` + msg
+	}
+
+	return r.Entry + `: ` + r.Attr + `: ` + msg
+}
+
+/*
+readEntries returns the entries read from the input in the specified
+format, alongside any problems (invalid CSV rows) and an error. The
+input *dcxl.DUAConfig, if non-nil, is used to assign the DNs of entries
+lacking one.
+*/
+func readEntries(rd io.Reader, format string, d *dcxl.DUAConfig) (entries []dcxl.Entry, probs []problem, err error) {
+	switch format {
+	case `ldif`:
+		entries, err = dcxl.ReadLDIF(rd)
+	case `json`:
+		entries, err = readJSONEntries(rd)
+	case `csv`:
+		codec := &dcxl.CSVCodec{DUAConfig: d}
+		var regs dcxl.Registrations
+		var rowErrs []dcxl.CSVRowError
+		if regs, rowErrs, err = codec.Read(rd); err != nil {
+			return
+		}
+		for _, re := range rowErrs {
+			probs = append(probs, newProblem(fmt.Sprintf("line %d", re.Line), re.Column, re.Err))
+		}
+		for _, reg := range regs {
+			entries = append(entries, dcxl.Entry{DN: reg.DN(), Attributes: reg.Unmarshal()})
+		}
+	}
+
+	if err != nil || d == nil {
+		return
+	}
+
+	for i := range entries {
+		if len(entries[i].DN) > 0 {
+			continue
+		}
+		if dot := first(entries[i].Attributes, `dotNotation`); len(dot) > 0 {
+			entries[i].DN, _ = d.RegistrationDN(dot)
+		} else if n := first(entries[i].Attributes, `n`); isRoot(entries[i].Attributes) && len(n) > 0 {
+			entries[i].DN, _ = d.RegistrationDN(n)
+		}
+	}
+
+	return
+}
+
+/*
+readJSONEntries returns the entries described by the input JSON, which
+must be an object or an array of objects, such as those produced by
+encoding dcxl types. The attributes of nested COMBINED registrants are
+merged into the entry bearing them.
+*/
+func readJSONEntries(rd io.Reader) (entries []dcxl.Entry, err error) {
+	var raw json.RawMessage
+	if err = json.NewDecoder(rd).Decode(&raw); err != nil {
+		return
+	}
+
+	var objs []map[string]json.RawMessage
+	if t := strings.TrimSpace(string(raw)); strings.HasPrefix(t, `{`) {
+		objs = make([]map[string]json.RawMessage, 1)
+		err = json.Unmarshal(raw, &objs[0])
+	} else {
+		err = json.Unmarshal(raw, &objs)
+	}
+	if err != nil {
+		return
+	}
+
+	for i, obj := range objs {
+		if obj == nil {
+			continue
+		}
+		e := dcxl.Entry{Attributes: make(map[string][]string)}
+		if err = jsonAttrs(obj, &e); err != nil {
+			return nil, fmt.Errorf("element %d: %v", i, err)
+		}
+		entries = append(entries, e)
+	}
+
+	return
+}
+
+/*
+jsonAttrs adds the values of the input JSON object to the input entry.
+*/
+func jsonAttrs(obj map[string]json.RawMessage, e *dcxl.Entry) error {
+	for k, v := range obj {
+		switch k {
+		case `registrantType`, `duaConfig`, `settings`:
+			continue
+		case `combinedFirstAuthority`, `combinedCurrentAuthority`, `combinedSponsor`:
+			var nested map[string]json.RawMessage
+			if err := json.Unmarshal(v, &nested); err != nil {
+				return fmt.Errorf("%s: %v", k, err)
+			}
+			delete(nested, `dn`)
+			if err := jsonAttrs(nested, e); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var vals []string
+		var s string
+		if err := json.Unmarshal(v, &s); err == nil {
+			vals = []string{s}
+		} else if err = json.Unmarshal(v, &vals); err != nil {
+			return fmt.Errorf("%s: value must be a string or an array of strings", k)
+		}
+
+		if k == `dn` {
+			if len(vals) > 0 {
+				e.DN = vals[0]
+			}
+			continue
+		}
+
+		for _, val := range vals {
+			if k != `objectClass` || !contains(e.Attributes[k], val) {
+				e.Attributes[k] = append(e.Attributes[k], val)
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+first returns the first value of the named attribute type, matched
+without regard to case, or a zero string.
+*/
+func first(m map[string][]string, at string) string {
+	for k, v := range m {
+		if strings.EqualFold(k, at) && len(v) > 0 {
+			return v[0]
+		}
+	}
+
+	return ``
+}
+
+/*
+isRoot returns a boolean value indicative of whether the input entry
+attributes describe an x660RootArc.
+*/
+func isRoot(m map[string][]string) bool {
+	return hasClass(m, dcxl.RootArc{}.ObjectClass())
+}
+
+/*
+hasClass returns a boolean value indicative of whether the input entry
+attributes bear the input objectClass.
+*/
+func hasClass(m map[string][]string, oc string) bool {
+	for k, v := range m {
+		if strings.EqualFold(k, `objectClass`) && contains(v, oc) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+contains returns a boolean value indicative of whether the input value
+is present within the input slice, without regard to case.
+*/
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if strings.EqualFold(v, val) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const testLDIF = `dn: n=1,ou=Registrations,o=rA
+objectClass: top
+objectClass: x660RootArc
+n: 1
+identifier: iso
+unicodeValue: ISO
+
+dn: n=3,n=1,ou=Registrations,o=rA
+objectClass: top
+objectClass: x660SubArc
+n: 3
+identifier: identified-organization
+dotNotation: 1.3
+
+dn: n=6,n=3,n=1,ou=Registrations,o=rA
+objectClass: top
+objectClass: x660SubArc
+n: 6
+`
+
+const testInvalidLDIF = `dn: n=9,ou=X
+objectClass: top
+objectClass: x660SubArc
+dotNotation: 1.3.x
+`
+
+var base3D = []string{`-model`, `3d`, `-base`, `ou=Registrations,o=rA`}
+
+/*
+invoke executes run using the input arguments and standard input, and
+returns the exit status alongside the standard output and error streams.
+*/
+func invoke(args []string, stdin string) (code int, stdout, stderr string) {
+	var o, e bytes.Buffer
+	code = run(args, &env{stdin: strings.NewReader(stdin), stdout: &o, stderr: &e})
+	return code, o.String(), e.String()
+}
+
+func with(args ...[]string) (all []string) {
+	for _, a := range args {
+		all = append(all, a...)
+	}
+
+	return
+}
+
+/*
+TestRun verifies the exit status and output of each command.
+*/
+func TestRun(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		args   []string
+		stdin  string
+		code   int
+		stdout string // expected substring of standard output
+		stderr string // expected substring of standard error
+	}{
+		{`no command`, nil, ``, exitUsage, ``, `usage: dcxl`},
+		{`help`, []string{`help`}, ``, exitUsage, ``, `commands:`},
+		{`unknown command`, []string{`bogus`}, ``, exitUsage, ``, `unknown command "bogus"`},
+		{`command help`, []string{`validate`, `-h`}, ``, exitOK, ``, `usage: dcxl validate`},
+		{`unknown flag`, []string{`tree`, `-bogus`}, ``, exitUsage, ``, `-bogus`},
+
+		{`convert`, []string{`convert`, `1.3.6`}, ``, exitOK, "{iso(1) identified-organization(3) 6}", ``},
+		{`convert stdin`, []string{`convert`, `-to`, `iri`}, "1.3.6\n", exitOK, "/ISO/Identified-Organization/6\n", ``},
+		{`convert dn`, with([]string{`convert`, `-to`, `dn`}, base3D, []string{`1.3`}), ``, exitOK,
+			"n=3,n=1,ou=Registrations,o=rA\n", ``},
+		{`convert bogus`, []string{`convert`, `1.3.x`}, ``, exitInvalid, ``, `1.3.x`},
+		{`convert dn without config`, []string{`convert`, `-to`, `dn`, `1.3`}, ``, exitUsage, ``, `DUAConfig is required`},
+		{`convert unknown form`, []string{`convert`, `-from`, `bogus`, `1.3`}, ``, exitUsage, ``, `unknown input form`},
+		{`convert unknown output`, []string{`convert`, `-o`, `yaml`, `1.3`}, ``, exitUsage, ``, `unknown output mode`},
+
+		{`validate`, []string{`validate`, `-f`, `ldif`}, testLDIF, exitOK, `3 entries, 0 skipped, 0 problems`, ``},
+		{`validate invalid`, []string{`validate`, `-f`, `ldif`}, testInvalidLDIF, exitInvalid, `dotNotation`, ``},
+		{`validate dn`, with([]string{`validate`, `-f`, `ldif`}, base3D), testLDIF, exitOK, `0 problems`, ``},
+		{`validate unknown format`, []string{`validate`, `-f`, `yaml`}, ``, exitUsage, ``, `unknown input format`},
+		{`validate uninferable format`, []string{`validate`}, ``, exitUsage, ``, `specify -f`},
+		{`validate unknown draft`, []string{`validate`, `-f`, `ldif`, `-draft`, `1`}, ``, exitUsage, ``, `1`},
+		{`validate unknown model`, []string{`validate`, `-f`, `ldif`, `-model`, `4d`}, ``, exitUsage, ``, `unknown directory model`},
+		{`validate missing file`, []string{`validate`, `/nonexistent.ldif`}, ``, exitUsage, ``, `nonexistent`},
+
+		{`ldif`, []string{`ldif`, `-f`, `ldif`}, testLDIF, exitOK, "dn: n=6,n=3,n=1,ou=Registrations,o=rA\n", ``},
+		{`ldif derived dn`, with([]string{`ldif`, `-f`, `json`}, base3D),
+			`[{"objectClass":["top","x660SubArc"],"n":["3"],"dotNotation":["1.3"]}]`, exitOK,
+			"dn: n=3,n=1,ou=Registrations,o=rA\n", ``},
+		{`ldif no dn`, []string{`ldif`, `-f`, `json`},
+			`[{"objectClass":["top","x660SubArc"],"n":["3"],"dotNotation":["1.3"]}]`, exitInvalid, ``, `no DN`},
+		{`ldif bad json`, []string{`ldif`, `-f`, `json`}, `{`, exitUsage, ``, `ldif:`},
+
+		{`schema`, []string{`schema`}, ``, exitOK, `dn: cn=Subschema`, ``},
+		{`schema list`, []string{`schema`, `-list`}, ``, exitOK, "openldap-schema\n", ``},
+		{`schema unknown format`, []string{`schema`, `-format`, `bogus`}, ``, exitUsage, ``, `unknown format`},
+		{`schema extra argument`, []string{`schema`, `extra`}, ``, exitUsage, ``, `usage: dcxl schema`},
+
+		{`tree`, with([]string{`tree`, `-f`, `ldif`}, base3D), testLDIF, exitOK,
+			"iso(1)\n└── identified-organization(3)\n    └── 6\n", ``},
+		{`tree ascii`, with([]string{`tree`, `-f`, `ldif`, `-ascii`}, base3D), testLDIF, exitOK,
+			"iso(1)\n`-- identified-organization(3)\n    `-- 6\n", ``},
+		{`tree depth`, with([]string{`tree`, `-f`, `ldif`, `-depth`, `2`}, base3D), testLDIF, exitOK,
+			"iso(1)\n└── identified-organization(3)\n", ``},
+		{`tree root`, with([]string{`tree`, `-f`, `ldif`, `-root`, `1.3`, `-format`, `mermaid`}, base3D), testLDIF, exitOK,
+			"flowchart TD\n\tn1_3[\"identified-organization(3)\"]\n\tn1_3_6[\"6\"]\n\tn1_3 --> n1_3_6\n", ``},
+		{`tree filter`, with([]string{`tree`, `-f`, `ldif`, `-filter`, `(n=3)`, `-format`, `dot`}, base3D), testLDIF, exitOK,
+			`"1.3" [label="identified-organization(3)"];`, ``},
+		{`tree no oid`, []string{`tree`, `-f`, `ldif`}, testLDIF, exitInvalid, `identified-organization(3)`, `no OID could be determined`},
+		{`tree bad filter`, []string{`tree`, `-f`, `ldif`, `-filter`, `(n=3`}, testLDIF, exitUsage, ``, `tree:`},
+		{`tree unknown format`, []string{`tree`, `-f`, `ldif`, `-format`, `svg`}, testLDIF, exitUsage, ``, `unknown diagram format`},
+		{`tree extra argument`, []string{`tree`, `a.ldif`, `b.ldif`}, ``, exitUsage, ``, `usage: dcxl tree`},
+	} {
+		code, stdout, stderr := invoke(tc.args, tc.stdin)
+		if code != tc.code {
+			t.Errorf("%s: expected exit status %d, got %d (stderr: %s)", tc.name, tc.code, code, stderr)
+		}
+		if !strings.Contains(stdout, tc.stdout) {
+			t.Errorf("%s: expected standard output to contain %q, got %q", tc.name, tc.stdout, stdout)
+		}
+		if !strings.Contains(stderr, tc.stderr) {
+			t.Errorf("%s: expected standard error to contain %q, got %q", tc.name, tc.stderr, stderr)
+		}
+	}
+}
+
+/*
+TestRun_json verifies the shape of the JSON output of each command
+supporting "-o json".
+*/
+func TestRun_json(t *testing.T) {
+	code, stdout, _ := invoke([]string{`convert`, `-o`, `json`, `1.3`, `1.3.x`}, ``)
+	var convs []conversion
+	if err := json.Unmarshal([]byte(stdout), &convs); err != nil {
+		t.Fatalf("convert: %v", err)
+	} else if code != exitInvalid || len(convs) != 2 {
+		t.Fatalf("convert: expected 2 results and exit status %d, got %d (%d)", exitInvalid, len(convs), code)
+	} else if convs[0].ASN1Notation != `{iso(1) identified-organization(3)}` || len(convs[0].Error) > 0 {
+		t.Errorf("convert: unexpected result %#v", convs[0])
+	} else if convs[1].Input != `1.3.x` || len(convs[1].Error) == 0 || len(convs[1].DotNotation) > 0 {
+		t.Errorf("convert: unexpected result %#v", convs[1])
+	}
+
+	for _, tc := range []struct {
+		stdin    string
+		code     int
+		valid    bool
+		problems int
+	}{
+		{testLDIF, exitOK, true, 0},
+		{testInvalidLDIF, exitInvalid, false, 3},
+	} {
+		code, stdout, _ = invoke([]string{`validate`, `-f`, `ldif`, `-o`, `json`}, tc.stdin)
+		var rep report
+		if err := json.Unmarshal([]byte(stdout), &rep); err != nil {
+			t.Fatalf("validate: %v", err)
+		} else if code != tc.code || rep.Valid != tc.valid || len(rep.Problems) != tc.problems {
+			t.Errorf("validate: expected %d/%t/%d, got %d/%t/%d: %s",
+				tc.code, tc.valid, tc.problems, code, rep.Valid, len(rep.Problems), stdout)
+		} else if rep.Problems == nil {
+			t.Errorf("validate: expected an empty problems array, got null")
+		}
+	}
+
+	code, stdout, _ = invoke(with([]string{`tree`, `-f`, `ldif`, `-o`, `json`}, base3D), testLDIF)
+	var roots []struct {
+		OID      string `json:"oid"`
+		DN       string `json:"dn"`
+		Children []struct {
+			OID      string            `json:"oid"`
+			Children []json.RawMessage `json:"children"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &roots); err != nil {
+		t.Fatalf("tree: %v", err)
+	} else if code != exitOK || len(roots) != 1 || roots[0].OID != `1` || roots[0].DN != `n=1,ou=Registrations,o=rA` {
+		t.Fatalf("tree: unexpected output (%d): %s", code, stdout)
+	} else if len(roots[0].Children) != 1 || roots[0].Children[0].OID != `1.3` || len(roots[0].Children[0].Children) != 1 {
+		t.Errorf("tree: unexpected children: %s", stdout)
+	}
+
+	if _, stdout, _ = invoke([]string{`tree`, `-f`, `ldif`, `-o`, `json`}, ``); strings.TrimSpace(stdout) != `[]` {
+		t.Errorf("tree: expected an empty array, got %q", stdout)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+/*
+schema.go contains the schema command, which exports the schema of a
+draft revision in one of several directory schema formats.
+*/
+
+import (
+	"fmt"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+/*
+schemaFormats contains the supported schema formats.
+*/
+var schemaFormats = []dcxl.SchemaFormat{
+	dcxl.OpenLDAPConfigFormat,
+	dcxl.OpenLDAPSchemaFormat,
+	dcxl.ApacheDSFormat,
+	dcxl.NetscapeDSFormat,
+	dcxl.SubschemaFormat,
+}
+
+func runSchema(e *env, args []string) int {
+	fs := e.flagSet(`schema`, ``)
+	format := fs.String(`format`, dcxl.SubschemaFormat.String(), `schema format (see -list)`)
+	draft := fs.Int(`draft`, int(dcxl.LatestDraft), `draft revision`)
+	list := fs.Bool(`list`, false, `list the supported formats`)
+	if code, ok := parse(fs, args); !ok {
+		return code
+	} else if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	if *list {
+		for _, f := range schemaFormats {
+			fmt.Fprintln(e.stdout, f)
+		}
+		return exitOK
+	}
+
+	schema := dcxl.DraftVersion(*draft).Schema()
+	if schema == nil {
+		e.errorf("schema: %v: %d", dcxl.UnknownDraftErr, *draft)
+		return exitUsage
+	}
+
+	for _, f := range schemaFormats {
+		if f.String() == *format {
+			if err := schema.Write(e.stdout, f); err != nil {
+				e.errorf("schema: %v", err)
+				return exitUsage
+			}
+			return exitOK
+		}
+	}
+
+	e.errorf("schema: unknown format %q (see -list)", *format)
+
+	return exitUsage
+}
@@ -0,0 +1,142 @@
+package main
+
+/*
+tree.go contains the tree command, which prints the hierarchy of the
+registrations read from LDIF, JSON or CSV input.
+*/
+
+import (
+	"fmt"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+func runTree(e *env, args []string) int {
+	fs := e.flagSet(`tree`, `[file]`)
+	var cfg configFlags
+	var out output
+	cfg.register(fs)
+	out.register(fs)
+	format := fs.String(`f`, ``, `input format: ldif, json or csv (default: inferred from extension)`)
+	diagram := fs.String(`format`, `text`, `diagram format: text, dot or mermaid (ignored if -o json)`)
+	root := fs.String(`root`, ``, `limit the tree to the subtree of this dotNotation`)
+	depth := fs.Int(`depth`, 0, `limit the number of levels printed (0: unlimited)`)
+	filter := fs.String(`filter`, ``, `limit the tree to the registrations matching this LDAP filter`)
+	states := fs.Bool(`states`, false, `annotate registrations with their status, leaf, frozen and range states`)
+	ascii := fs.Bool(`ascii`, false, `draw text trees using ASCII characters`)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: dcxl tree [flags] [file]\n\n"+
+			"The OID of each registration is read from its dotNotation, from its n\n"+
+			"value if it is a root, or else from its DN, if a DUAConfig is given.\n"+
+			"Registrations whose superior is absent are attached to their nearest\n"+
+			"present ancestor.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if code, ok := parse(fs, args); !ok {
+		return code
+	} else if fs.NArg() > 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	tr := dcxl.TreeRenderer{Root: *root, Depth: *depth, States: *states, ASCII: *ascii}
+	d, err := cfg.config()
+	if err == nil {
+		err = out.check()
+	}
+	if err == nil {
+		tr.Format, err = treeFormat(*diagram)
+	}
+	if err == nil && len(*filter) > 0 {
+		tr.Filter, err = dcxl.ParseFilter(*filter)
+	}
+	if err != nil {
+		e.errorf("tree: %v", err)
+		return exitUsage
+	}
+	if out.json() {
+		tr.Format = dcxl.JSONTreeFormat
+	}
+
+	rc, f, err := e.open(fs.Arg(0), *format)
+	if err != nil {
+		e.errorf("tree: %v", err)
+		return exitUsage
+	}
+	defer rc.Close()
+
+	entries, probs, err := readEntries(rc, f, d)
+	if err != nil {
+		e.errorf("tree: %v", err)
+		return exitUsage
+	}
+
+	code := exitOK
+	for _, p := range probs {
+		e.errorf("tree: %s", p)
+		code = exitInvalid
+	}
+
+	var regs dcxl.Registrations
+	for _, ent := range entries {
+		m := ent.Attributes
+		if !hasClass(m, dcxl.SubArc{}.ObjectClass()) && !isRoot(m) {
+			continue
+		}
+
+		reg, err := dcxl.MarshalRegistration(m)
+		if err != nil {
+			e.errorf("tree: %s: %v", ent.DN, err)
+			code = exitInvalid
+			continue
+		}
+		reg.SetDN(ent.DN)
+		reg.SetDUAConfig(d)
+
+		if !hasOID(m, ent.DN, d) {
+			e.errorf("tree: %s: no OID could be determined", ent.DN)
+			code = exitInvalid
+			continue
+		}
+		regs = append(regs, reg)
+	}
+
+	if err = tr.Render(e.stdout, regs); err != nil {
+		e.errorf("tree: %v", err)
+		return exitUsage
+	}
+
+	return code
+}
+
+/*
+hasOID returns a boolean value indicative of whether the OID of the input
+registration entry is known, either from its dotNotation or n value, or
+by way of its DN and the input *dcxl.DUAConfig.
+*/
+func hasOID(m map[string][]string, dn string, d *dcxl.DUAConfig) bool {
+	switch {
+	case len(first(m, `dotNotation`)) > 0:
+		return true
+	case isRoot(m) && len(first(m, `n`)) > 0:
+		return true
+	case d == nil || len(dn) == 0:
+		return false
+	}
+
+	_, err := d.RegistrationOID(dn)
+	return err == nil
+}
+
+/*
+treeFormat returns the dcxl.TreeFormat named by the input value.
+*/
+func treeFormat(name string) (dcxl.TreeFormat, error) {
+	for _, f := range []dcxl.TreeFormat{dcxl.TextTreeFormat, dcxl.DOTFormat, dcxl.MermaidFormat} {
+		if f.String() == name {
+			return f, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown diagram format %q", name)
+}
@@ -0,0 +1,182 @@
+package main
+
+/*
+validate.go contains the validate command, which validates LDIF, JSON or
+CSV entries against the draft schema and the DN rules of a DUAConfig.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+/*
+report describes the outcome of the validate command.
+*/
+type report struct {
+	Valid    bool      `json:"valid"`
+	Entries  int       `json:"entries"`
+	Skipped  int       `json:"skipped"`
+	Problems []problem `json:"problems"`
+}
+
+func runValidate(e *env, args []string) int {
+	fs := e.flagSet(`validate`, `[file]`)
+	var cfg configFlags
+	var out output
+	cfg.register(fs)
+	out.register(fs)
+	format := fs.String(`f`, ``, `input format: ldif, json or csv (default: inferred from extension)`)
+	draft := fs.Int(`draft`, int(dcxl.LatestDraft), `draft revision whose schema is used`)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "usage: dcxl validate [flags] [file]\n\n"+
+			"Each entry bearing an x660 objectClass is validated against the schema\n"+
+			"of the draft revision. If a DUAConfig is given, the DN of each registration\n"+
+			"must also agree with its dotNotation and n values. Other entries, such as\n"+
+			"those of the registration bases, are skipped.\n\nflags:\n")
+		fs.PrintDefaults()
+	}
+	if code, ok := parse(fs, args); !ok {
+		return code
+	} else if fs.NArg() > 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	d, err := cfg.config()
+	if err == nil {
+		err = out.check()
+	}
+	schema := dcxl.DraftVersion(*draft).Schema()
+	if err == nil && schema == nil {
+		err = fmt.Errorf("%v: %d", dcxl.UnknownDraftErr, *draft)
+	}
+	if err != nil {
+		e.errorf("validate: %v", err)
+		return exitUsage
+	}
+
+	rc, f, err := e.open(fs.Arg(0), *format)
+	if err != nil {
+		e.errorf("validate: %v", err)
+		return exitUsage
+	}
+	defer rc.Close()
+
+	entries, probs, err := readEntries(rc, f, d)
+	if err != nil {
+		e.errorf("validate: %v", err)
+		return exitUsage
+	}
+
+	rep := report{Problems: probs, Entries: len(entries) + len(probs)}
+	for _, ent := range entries {
+		if !hasX660Class(ent.Attributes) {
+			rep.Skipped++
+			continue
+		}
+		rep.Problems = append(rep.Problems, validate(ent, schema, d)...)
+	}
+	rep.Valid = len(rep.Problems) == 0
+	if rep.Problems == nil {
+		rep.Problems = []problem{}
+	}
+
+	if out.json() {
+		if err = writeJSON(e.stdout, rep); err != nil {
+			e.errorf("validate: %v", err)
+			return exitUsage
+		}
+	} else {
+		for _, p := range rep.Problems {
+			fmt.Fprintln(e.stdout, p)
+		}
+		fmt.Fprintf(e.stdout, "%d entries, %d skipped, %d problems\n",
+			rep.Entries, rep.Skipped, len(rep.Problems))
+	}
+
+	if !rep.Valid {
+		return exitInvalid
+	}
+
+	return exitOK
+}
+
+/*
+validate returns the problems found within the input entry.
+*/
+func validate(ent dcxl.Entry, schema *dcxl.Schema, d *dcxl.DUAConfig) (probs []problem) {
+	name := ent.DN
+	if len(name) == 0 {
+		name = `(no dn)`
+	}
+	add := func(attr, msg string) {
+		probs = append(probs, problem{Entry: name, Attr: attr, Message: msg})
+	}
+
+	var verrs dcxl.ValidationErrors
+	if errors.As(schema.Check(ent.Attributes), &verrs) {
+		for _, v := range verrs {
+			probs = append(probs, newProblem(name, ``, v))
+		}
+	}
+
+	m := ent.Attributes
+	if !hasClass(m, dcxl.SubArc{}.ObjectClass()) && !isRoot(m) {
+		return // not a registration
+	}
+
+	dot, n := first(m, `dotNotation`), first(m, `n`)
+	if isRoot(m) {
+		if n != `0` && n != `1` && n != `2` {
+			add(`n`, dcxl.IllegalRootErr.Error())
+		}
+		dot = n
+	} else if len(dot) > 0 {
+		if _, err := dcxl.DotNotToASN1Not(dot, nil); err != nil {
+			add(`dotNotation`, err.Error())
+			return
+		} else if arcs := strings.Split(dot, `.`); len(n) > 0 && arcs[len(arcs)-1] != n {
+			add(`n`, dcxl.MismatchedLeafErr.Error())
+		}
+	}
+
+	if d == nil {
+		return
+	} else if len(ent.DN) == 0 {
+		add(`dn`, dcxl.InvalidDNErr.Error())
+		return
+	}
+
+	oid, err := d.RegistrationOID(ent.DN)
+	switch {
+	case err != nil:
+		add(`dn`, err.Error())
+	case len(dot) > 0 && oid != dot:
+		add(`dn`, fmt.Sprintf("DN describes %s, but the entry describes %s", oid, dot))
+	}
+
+	return
+}
+
+/*
+hasX660Class returns a boolean value indicative of whether the input
+entry attributes bear an objectClass defined by the ID.
+*/
+func hasX660Class(m map[string][]string) bool {
+	for k, v := range m {
+		if !strings.EqualFold(k, `objectClass`) {
+			continue
+		}
+		for _, oc := range v {
+			if strings.HasPrefix(strings.ToLower(oc), `x660`) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,453 @@
+package dcxl
+
+/*
+collection.go contains the sorting, lookup, filtering, grouping and set
+operations of the Registrations and Registrants types.
+*/
+
+import "sort"
+
+/*
+CollectionKey describes the value by which Registration and Registrant
+instances are identified when looked up, de-duplicated or compared within
+a set operation.
+*/
+type CollectionKey uint8
+
+const (
+	OIDKey CollectionKey = iota // dotNotation (or n, for a *RootArc); not applicable to Registrants
+	DNKey                       // distinguished name, normalized
+	IDKey                       // identifier (nameForm) of a Registration, or registrantID of a Registrant
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r CollectionKey) String() string {
+	switch r {
+	case OIDKey:
+		return `oid`
+	case DNKey:
+		return `dn`
+	case IDKey:
+		return `id`
+	}
+
+	return `unknown`
+}
+
+/*
+normalize returns the input value as it would appear as a key of the
+receiver type.
+*/
+func (r CollectionKey) normalize(value string) string {
+	switch r {
+	case OIDKey:
+		return trimS(value)
+	case DNKey:
+		return normalizeDN(value)
+	}
+
+	return value
+}
+
+/*
+registration returns the key of the receiver type for the input
+Registration, or a zero string if the Registration is nil or bears
+no such value.
+*/
+func (r CollectionKey) registration(reg Registration) (key string) {
+	if isNilRegistration(reg) {
+		return
+	}
+
+	switch r {
+	case OIDKey:
+		key = treeOID(reg)
+	case DNKey:
+		key = r.normalize(reg.DN())
+	case IDKey:
+		key = reg.Identifier()
+	}
+
+	return
+}
+
+/*
+isNilRegistration returns a boolean value indicative of whether the input
+Registration is nil, or bears a nil pointer.
+*/
+func isNilRegistration(reg Registration) bool {
+	switch tv := reg.(type) {
+	case nil:
+		return true
+	case *RootArc:
+		return tv == nil
+	case *SubArc:
+		return tv == nil
+	}
+
+	return false
+}
+
+/*
+registrant returns the key of the receiver type for the input Registrant,
+or a zero string if the Registrant is nil or bears no such value.
+*/
+func (r CollectionKey) registrant(rant Registrant) (key string) {
+	if isNilRegistrant(rant) {
+		return
+	}
+
+	switch r {
+	case DNKey:
+		key = r.normalize(rant.DN())
+	case IDKey:
+		key = rant.RegistrantID()
+	}
+
+	return
+}
+
+/*
+Sort sorts the receiver in place by numeric OID order, such that 2.9
+precedes 2.10, and an arc precedes its subordinates. Registrations whose
+OID cannot be determined follow those whose OID can, ordered by DN. Nil
+instances are placed last. The sort is stable.
+*/
+func (r Registrations) Sort() {
+	sort.SliceStable(r, func(i, j int) bool {
+		x, y := treeOID(r[i]), treeOID(r[j])
+		switch {
+		case isNilRegistration(r[i]) || isNilRegistration(r[j]):
+			return !isNilRegistration(r[i]) && isNilRegistration(r[j])
+		case len(x) > 0 && len(y) > 0:
+			return oidLess(x, y)
+		case len(x) > 0 || len(y) > 0:
+			return len(x) > 0
+		}
+
+		return DNKey.registration(r[i]) < DNKey.registration(r[j])
+	})
+}
+
+/*
+Lookup returns the first Registration within the receiver whose value of
+the input CollectionKey matches value, or nil if none is found. DNs are
+matched after normalization, while identifiers are matched exactly.
+*/
+func (r Registrations) Lookup(key CollectionKey, value string) Registration {
+	if value = key.normalize(value); len(value) > 0 {
+		for i := 0; i < len(r); i++ {
+			if key.registration(r[i]) == value {
+				return r[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+Filter returns the Registration instances within the receiver for which
+the input predicate returns true. Nil instances are not offered to the
+predicate.
+*/
+func (r Registrations) Filter(fn func(Registration) bool) (f Registrations) {
+	f = make(Registrations, 0)
+	for i := 0; i < len(r); i++ {
+		if !isNilRegistration(r[i]) && fn(r[i]) {
+			f = append(f, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+GroupByParent returns the Registration instances within the receiver
+keyed by the OID of their superior arc, such that the subordinates of
+2.25 are keyed by 2.25. Root arcs are keyed by a zero string, and those
+whose OID cannot be determined are omitted. The order of the receiver is
+preserved within each group.
+*/
+func (r Registrations) GroupByParent() (groups map[string]Registrations) {
+	groups = make(map[string]Registrations)
+	for i := 0; i < len(r); i++ {
+		oid := treeOID(r[i])
+		if len(oid) == 0 {
+			continue
+		}
+
+		var parent string
+		if idx := lastIndex(oid, `.`); idx != -1 {
+			parent = oid[:idx]
+		}
+		groups[parent] = append(groups[parent], r[i])
+	}
+
+	return
+}
+
+/*
+Dedup returns the Registration instances within the receiver less any
+whose value of the input CollectionKey was borne by a preceding instance.
+Instances bearing no such value are retained, while nil instances are
+not.
+*/
+func (r Registrations) Dedup(key CollectionKey) Registrations {
+	return Registrations{}.Union(r, key)
+}
+
+/*
+Union returns the Registration instances within the receiver, followed
+by those within the input instance whose value of the input CollectionKey
+is borne by no preceding instance. Instances bearing no such value are
+retained, while nil instances are not. Registrations are not merged; see
+the Merge method for that purpose.
+*/
+func (r Registrations) Union(other Registrations, key CollectionKey) (u Registrations) {
+	u = make(Registrations, 0, len(r)+len(other))
+	seen := make(map[string]bool)
+	for _, regs := range []Registrations{r, other} {
+		for i := 0; i < len(regs); i++ {
+			k := key.registration(regs[i])
+			if isNilRegistration(regs[i]) || (len(k) > 0 && seen[k]) {
+				continue
+			}
+			seen[k] = len(k) > 0
+			u = append(u, regs[i])
+		}
+	}
+
+	return
+}
+
+/*
+Intersection returns the Registration instances within the receiver whose
+value of the input CollectionKey is borne by an instance within the input
+instance.
+*/
+func (r Registrations) Intersection(other Registrations, key CollectionKey) Registrations {
+	keys := other.keys(key)
+	return r.Filter(func(reg Registration) bool {
+		return keys[key.registration(reg)]
+	})
+}
+
+/*
+Difference returns the Registration instances within the receiver whose
+value of the input CollectionKey is borne by no instance within the input
+instance. Instances bearing no such value are retained.
+*/
+func (r Registrations) Difference(other Registrations, key CollectionKey) Registrations {
+	keys := other.keys(key)
+	return r.Filter(func(reg Registration) bool {
+		return !keys[key.registration(reg)]
+	})
+}
+
+/*
+keys returns the non-zero values of the input CollectionKey borne by the
+receiver's Registration instances.
+*/
+func (r Registrations) keys(key CollectionKey) (keys map[string]bool) {
+	keys = make(map[string]bool)
+	for i := 0; i < len(r); i++ {
+		if k := key.registration(r[i]); len(k) > 0 {
+			keys[k] = true
+		}
+	}
+
+	return
+}
+
+/*
+registrantOrder contains the sort order of the Registrant types.
+*/
+var registrantOrder = map[string]int{
+	`firstAuthority`:   1,
+	`currentAuthority`: 2,
+	`sponsor`:          3,
+}
+
+/*
+Sort sorts the receiver in place by type (firstAuthority, currentAuthority
+and then sponsor), then by registrantID, and finally by DN. Numeric
+registrantIDs are ordered numerically, and precede all others, which are
+ordered lexically. Nil instances are placed last. The sort is stable.
+*/
+func (r Registrants) Sort() {
+	sort.SliceStable(r, func(i, j int) bool {
+		x, y := r[i], r[j]
+		switch {
+		case isNilRegistrant(x) || isNilRegistrant(y):
+			return !isNilRegistrant(x) && isNilRegistrant(y)
+		case x.Type() != y.Type():
+			return registrantOrder[x.Type()] < registrantOrder[y.Type()]
+		case x.RegistrantID() != y.RegistrantID():
+			return idLess(x.RegistrantID(), y.RegistrantID())
+		}
+
+		return normalizeDN(x.DN()) < normalizeDN(y.DN())
+	})
+}
+
+/*
+idLess returns a boolean value indicative of whether registrantID a
+precedes b, such that numeric values precede all others, and are ordered
+numerically, while all others are ordered lexically.
+*/
+func idLess(a, b string) bool {
+	na, nb := isNumber(a), isNumber(b)
+	switch {
+	case na != nb:
+		return na
+	case na:
+		if c := compareValues(integerMatch, a, b); c != 0 {
+			return c < 0
+		}
+	}
+
+	return a < b
+}
+
+/*
+Lookup returns the first Registrant within the receiver whose value of
+the input CollectionKey matches value, or nil if none is found. DNs are
+matched after normalization, while registrantIDs are matched exactly.
+OIDKey matches nothing, as Registrants bear no OID.
+*/
+func (r Registrants) Lookup(key CollectionKey, value string) Registrant {
+	if value = key.normalize(value); len(value) > 0 {
+		for i := 0; i < len(r); i++ {
+			if key.registrant(r[i]) == value {
+				return r[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+/*
+Filter returns the Registrant instances within the receiver for which the
+input predicate returns true. Nil instances are not offered to the
+predicate.
+*/
+func (r Registrants) Filter(fn func(Registrant) bool) (f Registrants) {
+	f = make(Registrants, 0)
+	for i := 0; i < len(r); i++ {
+		if !isNilRegistrant(r[i]) && fn(r[i]) {
+			f = append(f, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+GroupByType returns the Registrant instances within the receiver keyed
+by type (e.g.: sponsor). The order of the receiver is preserved within
+each group.
+*/
+func (r Registrants) GroupByType() (groups map[string]Registrants) {
+	groups = make(map[string]Registrants)
+	for i := 0; i < len(r); i++ {
+		if !isNilRegistrant(r[i]) {
+			groups[r[i].Type()] = append(groups[r[i].Type()], r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Dedup returns the Registrant instances within the receiver less any of
+the same type whose value of the input CollectionKey was borne by a
+preceding instance. Instances bearing no such value are retained, while
+nil instances are not.
+*/
+func (r Registrants) Dedup(key CollectionKey) Registrants {
+	return Registrants{}.Union(r, key)
+}
+
+/*
+Union returns the Registrant instances within the receiver, followed by
+those within the input instance whose type and value of the input
+CollectionKey are borne by no preceding instance. Instances bearing no
+such value are retained, while nil instances are not. Registrants are not
+merged; see the Merge method for that purpose.
+*/
+func (r Registrants) Union(other Registrants, key CollectionKey) (u Registrants) {
+	u = make(Registrants, 0, len(r)+len(other))
+	seen := make(map[string]bool)
+	for _, rants := range []Registrants{r, other} {
+		for i := 0; i < len(rants); i++ {
+			if isNilRegistrant(rants[i]) {
+				continue
+			}
+
+			k := registrantSetKey(rants[i], key)
+			if len(k) > 0 && seen[k] {
+				continue
+			}
+			seen[k] = len(k) > 0
+			u = append(u, rants[i])
+		}
+	}
+
+	return
+}
+
+/*
+Intersection returns the Registrant instances within the receiver whose
+type and value of the input CollectionKey are borne by an instance within
+the input instance.
+*/
+func (r Registrants) Intersection(other Registrants, key CollectionKey) Registrants {
+	keys := other.keys(key)
+	return r.Filter(func(rant Registrant) bool {
+		return keys[registrantSetKey(rant, key)]
+	})
+}
+
+/*
+Difference returns the Registrant instances within the receiver whose
+type and value of the input CollectionKey are borne by no instance within
+the input instance. Instances bearing no such value are retained.
+*/
+func (r Registrants) Difference(other Registrants, key CollectionKey) Registrants {
+	keys := other.keys(key)
+	return r.Filter(func(rant Registrant) bool {
+		return !keys[registrantSetKey(rant, key)]
+	})
+}
+
+/*
+keys returns the non-zero set keys of the input CollectionKey borne by the
+receiver's Registrant instances.
+*/
+func (r Registrants) keys(key CollectionKey) (keys map[string]bool) {
+	keys = make(map[string]bool)
+	for i := 0; i < len(r); i++ {
+		if k := registrantSetKey(r[i], key); len(k) > 0 {
+			keys[k] = true
+		}
+	}
+
+	return
+}
+
+/*
+registrantSetKey returns the value of the input CollectionKey borne by
+the input Registrant, qualified by its type, or a zero string if no such
+value is borne.
+*/
+func registrantSetKey(rant Registrant, key CollectionKey) string {
+	if k := key.registrant(rant); len(k) > 0 {
+		return rant.Type() + `:` + k
+	}
+
+	return ``
+}
@@ -0,0 +1,38 @@
+package dcxl
+
+import (
+	"strings"
+	"testing"
+)
+
+/*
+TestRegistrants_Sort verifies that numeric registrantIDs are ordered
+numerically, ahead of any others, which are ordered lexically.
+*/
+func TestRegistrants_Sort(t *testing.T) {
+	var rants Registrants
+	for _, id := range []string{`bob`, `10`, `1a`, `9`, `alice`, `010`, `2.10`, `2.9`} {
+		s := new(Sponsor)
+		s.SetRegistrantID(id)
+		rants = append(rants, s)
+	}
+	f := new(FirstAuthority)
+	f.SetRegistrantID(`z`)
+	rants = append(rants, nil, f)
+
+	rants.Sort()
+
+	var ids []string
+	for _, rant := range rants {
+		if isNilRegistrant(rant) {
+			ids = append(ids, `<nil>`)
+		} else {
+			ids = append(ids, rant.RegistrantID())
+		}
+	}
+
+	want := `z 9 010 10 1a 2.10 2.9 alice bob <nil>`
+	if got := strings.Join(ids, ` `); got != want {
+		t.Errorf("expected '%s', got '%s'", want, got)
+	}
+}
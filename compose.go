@@ -0,0 +1,165 @@
+package dcxl
+
+/*
+compose.go contains the GetOrSetFunc composition helpers, as well as the
+registry of named GetOrSetFunc instances, allowing transforms to be
+selected by name (e.g.: from a configuration file).
+*/
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+Compose returns a GetOrSetFunc that executes the input GetOrSetFunc
+instances in order as a pipeline, such that the value returned by each
+becomes the first input argument of the next. The second input argument
+(the receiver instance) is passed to each as-is. Execution stops at the
+first error encountered, which is returned alongside a nil value.
+
+Nil instances are skipped. If none remain, the returned GetOrSetFunc
+returns its first input argument unmodified.
+
+Set<*> methods apply all GetOrSetFunc instances passed to them in this
+manner, while <*>GetFunc methods accept a single GetOrSetFunc, which may
+be the return value of this function.
+*/
+func Compose(funcs ...GetOrSetFunc) GetOrSetFunc {
+	var pipe []GetOrSetFunc
+	for i := 0; i < len(funcs); i++ {
+		if funcs[i] != nil {
+			pipe = append(pipe, funcs[i])
+		}
+	}
+
+	return func(X, R any) (v any, err error) {
+		v = X
+		for i := 0; i < len(pipe); i++ {
+			if v, err = pipe[i](v, R); err != nil {
+				return nil, err
+			}
+		}
+
+		return
+	}
+}
+
+/*
+Typed returns a GetOrSetFunc that wraps the input type-safe function. An
+error is returned by the GetOrSetFunc if its first input argument is not
+of type I, otherwise the return values of fn are returned as-is.
+
+This allows custom functions to be written without manual type assertion
+of the input value, e.g.:
+
+	upper := Typed(func(s string, _ any) (string, error) {
+		return strings.ToUpper(s), nil
+	})
+*/
+func Typed[I, O any](fn func(I, any) (O, error)) GetOrSetFunc {
+	return func(X, R any) (any, error) {
+		x, ok := X.(I)
+		if !ok {
+			var want I
+			return nil, errorf("Unsupported type %T (expecting %T)", X, want)
+		}
+
+		return fn(x, R)
+	}
+}
+
+/*
+getOrSetFuncs contains the registered GetOrSetFunc instances, keyed by
+name. The stock functions of this package are registered by default,
+each bearing its own name.
+*/
+var getOrSetFuncs = struct {
+	sync.RWMutex
+	funcs map[string]GetOrSetFunc
+}{
+	funcs: map[string]GetOrSetFunc{
+		`GeneralizedTimeToTime`: GeneralizedTimeToTime,
+		`TimeToGeneralizedTime`: TimeToGeneralizedTime,
+		`DotNotToDN2D`:          DotNotToDN2D,
+		`DNToDotNot2D`:          DNToDotNot2D,
+		`DotNotToDN3D`:          DotNotToDN3D,
+		`DNToDotNot3D`:          DNToDotNot3D,
+		`ASN1NotToDotNot`:       ASN1NotToDotNot,
+		`DotNotToASN1Not`:       DotNotToASN1Not,
+		`IRIToDotNot`:           IRIToDotNot,
+		`DotNotToIRI`:           DotNotToIRI,
+	},
+}
+
+/*
+RegisterGetOrSetFunc registers the input GetOrSetFunc under the input
+name, allowing it to be selected using the NamedGetOrSetFunc and
+ComposeNamed functions. An error is returned if the name is zero, if
+fn is nil, or if the name is already registered.
+*/
+func RegisterGetOrSetFunc(name string, fn GetOrSetFunc) error {
+	if len(name) == 0 || fn == nil {
+		return errorf("Invalid %T registration '%s'", fn, name)
+	}
+
+	getOrSetFuncs.Lock()
+	defer getOrSetFuncs.Unlock()
+
+	if _, found := getOrSetFuncs.funcs[name]; found {
+		return errorf("GetOrSetFunc '%s' is already registered", name)
+	}
+	getOrSetFuncs.funcs[name] = fn
+
+	return nil
+}
+
+/*
+NamedGetOrSetFunc returns the GetOrSetFunc registered under the input
+name alongside an error, which is non-nil if no such name is registered.
+*/
+func NamedGetOrSetFunc(name string) (fn GetOrSetFunc, err error) {
+	getOrSetFuncs.RLock()
+	defer getOrSetFuncs.RUnlock()
+
+	var found bool
+	if fn, found = getOrSetFuncs.funcs[trimS(name)]; !found {
+		err = errorf("Unknown GetOrSetFunc '%s'", name)
+	}
+
+	return
+}
+
+/*
+ComposeNamed returns the Compose pipeline of the GetOrSetFunc instances
+registered under the input names, in order, alongside an error, which
+is non-nil if any name is not registered.
+*/
+func ComposeNamed(names ...string) (GetOrSetFunc, error) {
+	funcs := make([]GetOrSetFunc, len(names))
+	for i := 0; i < len(names); i++ {
+		fn, err := NamedGetOrSetFunc(names[i])
+		if err != nil {
+			return nil, err
+		}
+		funcs[i] = fn
+	}
+
+	return Compose(funcs...), nil
+}
+
+/*
+GetOrSetFuncNames returns the names of all registered GetOrSetFunc
+instances in alphabetical order.
+*/
+func GetOrSetFuncNames() (names []string) {
+	getOrSetFuncs.RLock()
+	defer getOrSetFuncs.RUnlock()
+
+	for name := range getOrSetFuncs.funcs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return
+}
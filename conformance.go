@@ -0,0 +1,199 @@
+package dcxl
+
+/*
+conformance.go contains the schema conformance checker, which verifies
+the `ldap` struct tags of this package against a *Schema instance.
+*/
+
+import "reflect"
+
+/*
+ConformanceIssue describes a single discrepancy between the `ldap` struct
+tags of a type defined in this package and a *Schema instance.
+
+The Field value is zero for issues concerning schema definitions which
+lack a corresponding struct field.
+*/
+type ConformanceIssue struct {
+	Type    string // e.g.: `SubArc`
+	Field   string // e.g.: `R_DotNot`
+	Attr    string // e.g.: `dotNotation`
+	Problem string
+}
+
+/*
+String returns a human-readable representation of the receiver.
+*/
+func (r ConformanceIssue) String() string {
+	if len(r.Field) == 0 {
+		return sprintf("%s: %s: %s", r.Type, r.Attr, r.Problem)
+	}
+
+	return sprintf("%s.%s (%s): %s", r.Type, r.Field, r.Attr, r.Problem)
+}
+
+/*
+conformanceTypes maps each tagged type defined in this package to the
+name of the objectClass whose MUST and MAY clauses it implements.
+*/
+var conformanceTypes []struct {
+	x  any
+	oc string
+} = []struct {
+	x  any
+	oc string
+}{
+	{RootArc{}, RootArc{}.ObjectClass()},
+	{SubArc{}, SubArc{}.ObjectClass()},
+	{FirstAuthority{}, FirstAuthority{}.ObjectClass()},
+	{CurrentAuthority{}, CurrentAuthority{}.ObjectClass()},
+	{Sponsor{}, Sponsor{}.ObjectClass()},
+	{DUAConfig{}, DUAConfig{}.ObjectClass()},
+}
+
+/*
+singleByIntent contains attribute types the ID defines without the
+SINGLE-VALUE clause, but whose descriptions state they are intended
+to hold only one value per entry (e.g.: to allow Collective Attribute
+extensibility, per s. 2.1.19). Such types may be stored within string
+fields.
+*/
+var singleByIntent []string = []string{
+	`isLeafNode`,                     // s. 2.1.14
+	`isFrozen`,                       // s. 2.1.15
+	`supArc`,                         // s. 2.1.19
+	`topArc`,                         // s. 2.1.20
+	`firstArc`,                       // s. 2.1.23
+	`finalArc`,                       // s. 2.1.25
+	`currentAuthorityStartTimestamp`, // s. 2.1.29
+	`firstAuthorityStartTimestamp`,   // s. 2.1.47
+	`firstAuthorityEndTimestamp`,     // s. 2.1.48
+	`sponsorStartTimestamp`,          // s. 2.1.66
+	`sponsorEndTimestamp`,            // s. 2.1.67
+}
+
+/*
+CheckConformance returns slices of ConformanceIssue following a comparison
+of the `ldap` struct tags of the RootArc, SubArc, FirstAuthority,
+CurrentAuthority, Sponsor and DUAConfig types with the input *Schema. If
+the input *Schema is nil, the output of DraftSchema is used.
+
+For each tagged field, the following are verified:
+
+  - the attribute type is defined within the schema, or is at least
+    permitted by the associated objectClass (e.g.: 'description')
+  - single-valued attribute types are stored within string fields, and
+    multi-valued attribute types are stored within []string fields, save
+    for those the ID describes as single-valued in prose only (e.g.: s.
+    2.1.19)
+  - the attribute type is present within the MUST or MAY clauses of the
+    associated objectClass
+
+Additionally, any attribute type defined within the schema that is not
+associated with any struct field is reported.
+
+A zero length return value indicates full conformance.
+*/
+func CheckConformance(s *Schema) (issues []ConformanceIssue) {
+	if s == nil {
+		s = DraftSchema()
+	}
+
+	covered := make(map[string]bool)
+	for _, ct := range conformanceTypes {
+		t := typeOf(ct.x)
+		oc, ocFound := s.ObjectClass(ct.oc)
+		if !ocFound {
+			issues = append(issues, ConformanceIssue{
+				Type:    t.Name(),
+				Attr:    ct.oc,
+				Problem: `objectClass not defined in schema`,
+			})
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag, found := f.Tag.Lookup(`ldap`)
+			if !found || eq(tag, `dn`) {
+				continue
+			}
+			at := split(tag, `,`)[0]
+			issue := func(p string) {
+				issues = append(issues, ConformanceIssue{
+					Type:    t.Name(),
+					Field:   f.Name,
+					Attr:    at,
+					Problem: p,
+				})
+			}
+
+			allowed := strInSlice(at, oc.Must) || strInSlice(at, oc.May)
+			if ocFound && !allowed {
+				issue(sprintf("not permitted by objectClass '%s'", oc.Name()))
+			}
+
+			def, defined := s.AttributeType(at)
+			if !defined {
+				if !allowed {
+					issue(`attribute type not defined in schema`)
+				}
+				continue
+			}
+
+			for j := 0; j < len(def.Names); j++ {
+				covered[lc(def.Names[j])] = true
+			}
+
+			if def.Name() != at && !eq(canonicalAttr(at), def.Name()) {
+				issue(sprintf("tag should use the principal name '%s'", def.Name()))
+			}
+
+			switch {
+			case f.Type.Kind() == reflect.String && !def.SingleValue && !strInSlice(def.Name(), singleByIntent):
+				issue(`attribute type is multi-valued; field should be []string`)
+			case f.Type.Kind() == reflect.Slice && def.SingleValue:
+				issue(`attribute type is SINGLE-VALUE; field should be string`)
+			case f.Type.Kind() != reflect.String && f.Type.Kind() != reflect.Slice:
+				issue(sprintf("unsupported field kind %s", f.Type.Kind()))
+			}
+		}
+	}
+
+	for i := 0; i < len(s.AttributeTypes); i++ {
+		if a := s.AttributeTypes[i]; !covered[lc(a.Name())] {
+			issues = append(issues, ConformanceIssue{
+				Attr:    a.Name(),
+				Type:    `Schema`,
+				Problem: `attribute type has no struct field`,
+			})
+		}
+	}
+
+	return
+}
+
+/*
+ConformanceTB describes the subset of testing.TB used by AssertConformance,
+allowing this package to avoid a dependency upon the testing package.
+*/
+type ConformanceTB interface {
+	Helper()
+	Errorf(string, ...any)
+}
+
+/*
+AssertConformance is a test helper that reports each ConformanceIssue
+returned by CheckConformance as a test error, e.g.:
+
+	func TestConformance(t *testing.T) {
+		dcxl.AssertConformance(t, nil)
+	}
+*/
+func AssertConformance(t ConformanceTB, s *Schema) {
+	t.Helper()
+
+	issues := CheckConformance(s)
+	for i := 0; i < len(issues); i++ {
+		t.Errorf("%s", issues[i])
+	}
+}
@@ -0,0 +1,7 @@
+package dcxl
+
+import "testing"
+
+func TestConformance(t *testing.T) {
+	AssertConformance(t, nil)
+}
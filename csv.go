@@ -0,0 +1,322 @@
+package dcxl
+
+/*
+csv.go contains the tabular (CSV) codec, which exports Registrations as
+spreadsheet-friendly rows and imports SubArc registrations from same.
+*/
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+/*
+DefaultMultiValueDelimiter is the delimiter used by the CSVCodec type to
+join and split the values of multi-valued attribute types within a single
+cell, unless configured otherwise.
+*/
+const DefaultMultiValueDelimiter = `|`
+
+/*
+CSVCodec exports Registrations as CSV rows, and imports SubArc instances
+from CSV rows. The zero value is ready for use.
+
+The Comma field specifies the field delimiter. If zero, a comma is used.
+
+The MultiValueDelimiter field specifies the delimiter used to join and
+split the values of multi-valued attribute types within a single cell.
+If zero, DefaultMultiValueDelimiter is used.
+
+The Columns field specifies the attribute types exported, in order. If
+nil, all attribute types of the SubArc type are exported. The first
+column of an export is always the DN.
+
+The Header field maps the header cells of an import to attribute types,
+e.g.: "OID" to "dotNotation". Header cells absent from this map must
+name an attribute type (or "dn") outright. A header cell mapped to a
+zero string is ignored.
+
+The DUAConfig field, if non-nil, is used to fill in the DN of imported
+rows lacking one, using the directory model in effect (see the
+RegistrationDN method extended by *DUAConfig), and is assigned to each
+imported SubArc.
+*/
+type CSVCodec struct {
+	Comma               rune
+	MultiValueDelimiter string
+	Columns             []string
+	Header              map[string]string
+	DUAConfig           *DUAConfig
+}
+
+/*
+CSVRowError describes an invalid row encountered during an import.
+*/
+type CSVRowError struct {
+	Line   int    // line number within the input, starting at one (1)
+	Column string // attribute type, if applicable
+	Err    error
+}
+
+/*
+Error returns the string representation of the receiver.
+*/
+func (r CSVRowError) Error() string {
+	if len(r.Column) == 0 {
+		return sprintf("line %d: %v", r.Line, r.Err)
+	}
+
+	return sprintf("line %d: %s: %v", r.Line, r.Column, r.Err)
+}
+
+/*
+Unwrap returns the underlying error of the receiver.
+*/
+func (r CSVRowError) Unwrap() error {
+	return r.Err
+}
+
+func (r *CSVCodec) delimiter() string {
+	if len(r.MultiValueDelimiter) == 0 {
+		return DefaultMultiValueDelimiter
+	}
+
+	return r.MultiValueDelimiter
+}
+
+func (r *CSVCodec) columns() []string {
+	if r.Columns == nil {
+		return SubArc{}.attributeTypes()
+	}
+
+	return r.Columns
+}
+
+/*
+Write writes the input Registrations to the input io.Writer as CSV rows,
+preceded by a header row. The values of multi-valued attribute types are
+joined using the configured delimiter. An error is returned if writing
+fails, or if a value of a multi-valued attribute type contains the
+delimiter, as it could not be imported faithfully.
+*/
+func (r *CSVCodec) Write(w io.Writer, regs Registrations) error {
+	cw := csv.NewWriter(w)
+	if r.Comma != 0 {
+		cw.Comma = r.Comma
+	}
+
+	cols := r.columns()
+	delim := r.delimiter()
+	if err := cw.Write(append([]string{`dn`}, cols...)); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(regs); i++ {
+		if regs[i] == nil {
+			continue
+		}
+
+		m := regs[i].Unmarshal()
+		row := make([]string, 1, len(cols)+1)
+		row[0] = regs[i].DN()
+		for _, col := range cols {
+			vals := mapValues(m, col)
+			for j := 0; j < len(vals) && (SubArc{}).multiValued(col); j++ {
+				if contains(vals[j], delim) {
+					return errorf("%s: value of %s contains the multi-value delimiter '%s'",
+						regs[i].DN(), col, delim)
+				}
+			}
+			row = append(row, join(vals, delim))
+		}
+
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+/*
+Read returns SubArc instances, as Registrations, following an attempt to
+import CSV rows from the input io.Reader. The first row must be a header
+row (see the Header field). The values of multi-valued attribute types
+are split using the configured delimiter, and surrounding whitespace is
+removed from each value.
+
+Each row is validated; invalid rows are omitted from the return value and
+reported as CSVRowError instances. A row is invalid if:
+
+  - its dotNotation value is malformed, or its final arc differs from
+    its n value
+  - its DN is absent and cannot be derived using the DUAConfig field
+  - it does not conform to the schema returned by DraftSchema (e.g.:
+    multiple values for a SINGLE-VALUE attribute type)
+
+If a row lacks an n value, but bears a dotNotation value, the final arc
+of the latter is used. An error is returned if the input is not valid
+CSV, or if the header row names an unknown attribute type.
+*/
+func (r *CSVCodec) Read(rd io.Reader) (regs Registrations, rowErrs []CSVRowError, err error) {
+	cr := csv.NewReader(rd)
+	if r.Comma != 0 {
+		cr.Comma = r.Comma
+	}
+	cr.FieldsPerRecord = -1 // reported as row errors instead
+
+	var header []string
+	if header, err = cr.Read(); err != nil {
+		err = errorf("reading CSV header: %v", err)
+		return
+	}
+
+	var cols []string
+	if cols, err = r.mapHeader(header); err != nil {
+		return
+	}
+
+	schema := DraftSchema()
+	for {
+		var rec []string
+		if rec, err = cr.Read(); err == io.EOF {
+			err = nil
+			break
+		} else if err != nil {
+			return
+		}
+
+		line, _ := cr.FieldPos(0)
+		sub, errs := r.readRow(line, cols, rec, schema)
+		if len(errs) > 0 {
+			rowErrs = append(rowErrs, errs...)
+			continue
+		}
+		regs = append(regs, sub)
+	}
+
+	return
+}
+
+/*
+mapHeader returns the attribute types described by the input header row.
+Ignored columns are returned as zero strings.
+*/
+func (r *CSVCodec) mapHeader(header []string) (cols []string, err error) {
+	known := append([]string{`dn`}, SubArc{}.attributeTypes()...)
+
+	cols = make([]string, len(header))
+	for i, h := range header {
+		h = trimS(h)
+		if at, found := r.headerMapping(h); found {
+			h = at
+			if len(at) == 0 {
+				continue // ignored column
+			}
+		}
+
+		h = canonicalAttr(h)
+		for j := 0; j < len(known); j++ {
+			if eq(h, known[j]) {
+				cols[i] = known[j]
+				break
+			}
+		}
+
+		if len(cols[i]) == 0 {
+			err = errorw(NoSuchAttributeErr, "CSV column %d ('%s') does not name an attribute type of %T",
+				i+1, header[i], SubArc{})
+			return
+		}
+	}
+
+	return
+}
+
+func (r *CSVCodec) headerMapping(h string) (string, bool) {
+	for k, v := range r.Header {
+		if eq(trimS(k), h) {
+			return v, true
+		}
+	}
+
+	return ``, false
+}
+
+/*
+readRow returns a *SubArc following an attempt to populate it using the
+input record, alongside any row errors.
+*/
+func (r *CSVCodec) readRow(line int, cols, rec []string, schema *Schema) (sub *SubArc, errs []CSVRowError) {
+	rowErr := func(col string, err error) {
+		errs = append(errs, CSVRowError{Line: line, Column: col, Err: err})
+	}
+
+	if len(rec) != len(cols) {
+		rowErr(``, errorf("expected %d fields, found %d", len(cols), len(rec)))
+		return
+	}
+
+	sub = new(SubArc)
+	delim := r.delimiter()
+	m := make(map[string][]string, len(cols))
+	for i, col := range cols {
+		val := trimS(rec[i])
+		if len(col) == 0 || len(val) == 0 {
+			continue
+		}
+
+		if !sub.multiValued(col) {
+			m[col] = append(m[col], val)
+			continue
+		}
+
+		for _, v := range split(val, delim) {
+			if v = trimS(v); len(v) > 0 {
+				m[col] = append(m[col], v)
+			}
+		}
+	}
+	sub.marshal(m)
+
+	if dot := sub.DotNotation(); len(dot) > 0 {
+		arcs, err := splitDotNot(dot)
+		if err != nil {
+			rowErr(`dotNotation`, err)
+			return
+		} else if len(sub.N()) == 0 {
+			sub.R_N = arcs[len(arcs)-1]
+			m[`n`] = []string{sub.R_N}
+		} else if sub.N() != arcs[len(arcs)-1] {
+			rowErr(`n`, MismatchedLeafErr)
+			return
+		}
+	}
+
+	if len(sub.DN()) == 0 {
+		if r.DUAConfig == nil || len(sub.DotNotation()) == 0 {
+			rowErr(`dn`, errorw(InvalidDNErr, `no DN, and none could be derived`))
+			return
+		}
+
+		dn, err := r.DUAConfig.RegistrationDN(sub.DotNotation())
+		if err != nil {
+			rowErr(`dn`, err)
+			return
+		}
+		sub.R_DN = dn
+	}
+	sub.R_DUAConfig = r.DUAConfig
+
+	delete(m, `dn`)
+	m[`objectClass`] = []string{`top`, sub.ObjectClass()}
+	for _, v := range schema.validate(m) {
+		verr := *v
+		verr.Attr = `` // named by the column
+		rowErr(v.Attr, &verr)
+	}
+
+	return
+}
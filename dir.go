@@ -0,0 +1,947 @@
+package dcxl
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+dir.go contains the Directory interface, which abstracts the DSA (or any
+other store of entries) from which registrations and registrants are read
+and to which they are written, as well as an in-memory implementation and
+a few higher-level helpers built atop the search request planner.
+*/
+
+/*
+Entry describes a single directory entry by way of its string DN and its
+attribute types and values.
+*/
+type Entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+/*
+ModifyOp describes the operation performed by a Modification, per section
+4.6 of RFC 4511.
+*/
+type ModifyOp uint8
+
+const (
+	ModifyAdd     ModifyOp = iota // add (0)
+	ModifyDelete                  // delete (1)
+	ModifyReplace                 // replace (2)
+)
+
+/*
+String returns the RFC 4511 name of the receiver.
+*/
+func (r ModifyOp) String() string {
+	switch r {
+	case ModifyAdd:
+		return `add`
+	case ModifyDelete:
+		return `delete`
+	case ModifyReplace:
+		return `replace`
+	}
+
+	return `unknown`
+}
+
+/*
+Modification describes a single change to be applied to an attribute
+type (Attr) of an entry by way of the Directory.Modify method.
+
+A delete operation bearing no Values removes the attribute type entirely,
+while a replace operation bearing no Values removes the attribute type if
+present.
+*/
+type Modification struct {
+	Op     ModifyOp
+	Attr   string
+	Values []string
+}
+
+/*
+Directory describes a pluggable backend from which entries may be read,
+and to which entries may be written. Implementations may wrap an actual
+LDAP client connection, or may be entirely self-contained, such as the
+MemoryDirectory type.
+
+Implementations should return errors that wrap NoSuchObjectErr,
+EntryExistsErr, NotAllowedOnNonLeafErr, NoSuchAttributeErr and
+SizeLimitExceededErr where appropriate, such that errors.Is may be used
+to identify them. In the case of SizeLimitExceededErr, the entries found
+prior to reaching the limit should also be returned.
+*/
+type Directory interface {
+	// Search returns the entries that satisfy the input SearchRequest.
+	Search(SearchRequest) ([]Entry, error)
+
+	// Get returns the attribute types and values of the entry bearing
+	// the input string DN.
+	Get(string) (map[string][]string, error)
+
+	// Add writes a new entry bearing the input string DN and attribute
+	// types and values.
+	Add(string, map[string][]string) error
+
+	// Modify applies the input Modification instances, in order, to the
+	// entry bearing the input string DN. No changes are applied if any
+	// single Modification fails.
+	Modify(string, ...Modification) error
+
+	// Delete removes the leaf entry bearing the input string DN.
+	Delete(string) error
+
+	// RootDSE returns the attribute types and values of the Root DSE,
+	// which may include those of the x660DUAConfig class (s. 3.5.1).
+	RootDSE() (map[string][]string, error)
+}
+
+/*
+MemoryDirectory is a self-contained, concurrency-safe implementation of
+Directory that stores all entries in memory. This is useful for testing,
+for small deployments and for staging entries prior to submission to an
+actual DSA.
+
+Instances of this type should be initialized using the NewMemoryDirectory
+function.
+*/
+type MemoryDirectory struct {
+	mu      sync.RWMutex
+	dse     map[string][]string
+	ncs     [][]string          // normalized naming context RDNs
+	keys    []string            // normalized DNs, in order of addition
+	entries map[string]memEntry // keyed by normalized DN
+}
+
+type memEntry struct {
+	dn    string
+	rdns  []string // normalized
+	attrs map[string][]string
+}
+
+/*
+NewMemoryDirectory returns a freshly initialized instance of *MemoryDirectory.
+
+If the input *DUAConfig instance is non-nil, its values are published by way
+of the Root DSE (see s. 3.5.1 of the ID), and its registration and registrant
+bases are treated as naming contexts: such bases need not exist as entries,
+but all other entries must reside beneath an existing entry or a naming
+context.
+
+If the input *DUAConfig instance is nil, no hierarchy is enforced.
+*/
+func NewMemoryDirectory(d *DUAConfig) *MemoryDirectory {
+	r := &MemoryDirectory{
+		dse:     map[string][]string{`objectClass`: {`top`}},
+		entries: make(map[string]memEntry),
+	}
+
+	if d == nil {
+		return r
+	}
+
+	for k, v := range d.Unmarshal() {
+		r.dse[k] = append([]string{}, v...)
+	}
+
+	var ncs []string
+	for _, base := range append(append([]string{}, d.Registrations...), d.Registrants...) {
+		if !strInSlice(base, ncs) {
+			ncs = append(ncs, base)
+			r.ncs = append(r.ncs, splitDN(normalizeDN(base)))
+		}
+	}
+
+	if len(ncs) > 0 {
+		r.dse[`namingContexts`] = ncs
+	}
+
+	return r
+}
+
+/*
+RootDSE returns a copy of the receiver's Root DSE attribute types and
+values alongside a nil error.
+*/
+func (r *MemoryDirectory) RootDSE() (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return copyAttrs(r.dse), nil
+}
+
+/*
+Get returns a copy of the attribute types and values of the entry bearing
+the input string DN, alongside an error.
+*/
+func (r *MemoryDirectory) Get(dn string) (map[string][]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, found := r.entries[normalizeDN(dn)]
+	if !found {
+		return nil, errorw(NoSuchObjectErr, "'%s'", dn)
+	}
+
+	return copyAttrs(e.attrs), nil
+}
+
+/*
+Search returns the entries that satisfy the input SearchRequest alongside
+an error. Entries are returned in the order in which they were added.
+
+A nil Filter is treated as (objectClass=*). A nil (or '*') Attributes value
+returns all attribute types, while the special value '1.1' returns none.
+
+A zero-length base DN is treated as the parent of all entries, and any
+naming context (or superior thereof) is a valid base DN. The Root
+DSE itself is only available by way of the RootDSE method.
+*/
+func (r *MemoryDirectory) Search(req SearchRequest) (res []Entry, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	base := splitDN(normalizeDN(req.BaseDN))
+	if len(base) > 0 {
+		if _, found := r.entries[join(base, `,`)]; !found && !r.isNamingContextOrSuperior(base) {
+			err = errorw(NoSuchObjectErr, "'%s'", req.BaseDN)
+			return
+		}
+	}
+
+	f := req.Filter
+	if f == nil {
+		f = Present(`objectClass`)
+	}
+
+	for i := 0; i < len(r.keys); i++ {
+		e := r.entries[r.keys[i]]
+		if !inScope(e.rdns, base, req.Scope) {
+			continue
+		} else if ok, _ := MatchFilter(f, e.attrs); !ok {
+			continue
+		}
+
+		if req.SizeLimit > 0 && len(res) == req.SizeLimit {
+			err = SizeLimitExceededErr
+			return
+		}
+
+		res = append(res, Entry{
+			DN:         e.dn,
+			Attributes: selectAttrs(e.attrs, req.Attributes),
+		})
+	}
+
+	return
+}
+
+/*
+Add writes a new entry bearing the input string DN and attribute types
+and values, returning an error if the entry already exists, or if its
+parent does not exist. The values of the RDN are added to the entry if
+not already present.
+*/
+func (r *MemoryDirectory) Add(dn string, attrs map[string][]string) (err error) {
+	var at, val string
+	if at, val, err = leafRDN(dn); err != nil {
+		return
+	} else if len(mapValues(attrs, `objectClass`)) == 0 {
+		err = errorf("No objectClass values provided for '%s'", dn)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := normalizeDN(dn)
+	if _, found := r.entries[key]; found {
+		err = errorw(EntryExistsErr, "'%s'", dn)
+		return
+	}
+
+	rdns := splitDN(key)
+	if len(r.ncs) > 0 && !r.isNamingContext(rdns) {
+		parent := rdns[1:]
+		if _, found := r.entries[join(parent, `,`)]; !found && !r.isNamingContext(parent) {
+			err = errorw(NoSuchObjectErr, "parent of '%s'", dn)
+			return
+		}
+	}
+
+	e := memEntry{dn: dn, rdns: rdns, attrs: copyAttrs(attrs)}
+	if k := attrKey(e.attrs, at); len(k) == 0 {
+		e.attrs[at] = []string{val}
+	} else if !strInSlice(val, e.attrs[k]) {
+		e.attrs[k] = append(e.attrs[k], val)
+	}
+
+	r.entries[key] = e
+	r.keys = append(r.keys, key)
+
+	return
+}
+
+/*
+Modify applies the input Modification instances, in order, to the entry
+bearing the input string DN. No changes are applied if any Modification
+fails.
+*/
+func (r *MemoryDirectory) Modify(dn string, mods ...Modification) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := normalizeDN(dn)
+	e, found := r.entries[key]
+	if !found {
+		err = errorw(NoSuchObjectErr, "'%s'", dn)
+		return
+	}
+
+	attrs := copyAttrs(e.attrs)
+	for i := 0; i < len(mods); i++ {
+		if err = applyModification(attrs, mods[i]); err != nil {
+			return
+		}
+	}
+
+	e.attrs = attrs
+	r.entries[key] = e
+
+	return
+}
+
+/*
+Delete removes the entry bearing the input string DN, returning an error
+if it does not exist or if it has subordinate entries.
+*/
+func (r *MemoryDirectory) Delete(dn string) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := normalizeDN(dn)
+	e, found := r.entries[key]
+	if !found {
+		err = errorw(NoSuchObjectErr, "'%s'", dn)
+		return
+	}
+
+	var idx int = -1
+	for i := 0; i < len(r.keys); i++ {
+		if r.keys[i] == key {
+			idx = i
+		} else if inScope(r.entries[r.keys[i]].rdns, e.rdns, SingleLevel) {
+			err = errorw(NotAllowedOnNonLeafErr, "'%s'", dn)
+			return
+		}
+	}
+
+	delete(r.entries, key)
+	r.keys = append(r.keys[:idx], r.keys[idx+1:]...)
+
+	return
+}
+
+/*
+Len returns the integer number of entries present within the receiver.
+*/
+func (r *MemoryDirectory) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.keys)
+}
+
+/*
+AddRegistrations writes each of the input Registration instances to the
+receiver, returning the first error encountered. Superior registrations
+are written before their subordinates, regardless of input order. Nil
+instances are skipped.
+*/
+func (r *MemoryDirectory) AddRegistrations(regs Registrations) error {
+	var entries []Entry
+	for i := 0; i < len(regs); i++ {
+		if !isNilRegistration(regs[i]) {
+			entries = append(entries, Entry{DN: regs[i].DN(), Attributes: regs[i].Unmarshal()})
+		}
+	}
+
+	return r.addSorted(entries)
+}
+
+/*
+AddRegistrants writes each of the input Registrant instances to the
+receiver, returning the first error encountered. Nil instances are
+skipped.
+*/
+func (r *MemoryDirectory) AddRegistrants(rants Registrants) error {
+	var entries []Entry
+	for i := 0; i < len(rants); i++ {
+		if !isNilRegistrant(rants[i]) {
+			entries = append(entries, Entry{DN: rants[i].DN(), Attributes: rants[i].Unmarshal()})
+		}
+	}
+
+	return r.addSorted(entries)
+}
+
+func (r *MemoryDirectory) addSorted(entries []Entry) (err error) {
+	// Order by DN depth, such that superiors
+	// are always present prior to subordinates.
+	sort.SliceStable(entries, func(i, j int) bool {
+		return len(splitDN(entries[i].DN)) < len(splitDN(entries[j].DN))
+	})
+
+	for i := 0; i < len(entries) && err == nil; i++ {
+		err = r.Add(entries[i].DN, entries[i].Attributes)
+	}
+
+	return
+}
+
+/*
+isNamingContext returns a boolean value indicative of whether the input
+normalized RDNs describe one of the receiver's naming contexts.
+*/
+func (r *MemoryDirectory) isNamingContext(rdns []string) bool {
+	for i := 0; i < len(r.ncs); i++ {
+		if len(r.ncs[i]) == len(rdns) && inScope(rdns, r.ncs[i], BaseObject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+isNamingContextOrSuperior returns a boolean value indicative of whether the
+input normalized RDNs describe one of the receiver's naming contexts, or
+any superior thereof (e.g.: a search base of 'dc=example,dc=com').
+*/
+func (r *MemoryDirectory) isNamingContextOrSuperior(rdns []string) bool {
+	for i := 0; i < len(r.ncs); i++ {
+		if inScope(r.ncs[i], rdns, WholeSubtree) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+inScope returns a boolean value indicative of whether the entry described
+by the normalized RDNs (rdns) falls within the scope (s) of a search based
+upon the normalized RDNs of base.
+*/
+func inScope(rdns, base []string, s Scope) bool {
+	depth := len(rdns) - len(base)
+	if depth < 0 {
+		return false
+	}
+
+	for i := 0; i < len(base); i++ {
+		if rdns[depth+i] != base[i] {
+			return false
+		}
+	}
+
+	switch s {
+	case BaseObject:
+		return depth == 0
+	case SingleLevel:
+		return depth == 1
+	}
+
+	return true
+}
+
+/*
+applyModification applies the input Modification to the input attribute
+map in place. Values are matched per the matching rule of the attribute
+type, and the addition of an existing value is an error, per s. 4.6 of
+RFC 4511.
+*/
+func applyModification(attrs map[string][]string, mod Modification) (err error) {
+	k := attrKey(attrs, mod.Attr)
+
+	switch mod.Op {
+	case ModifyAdd:
+		if len(k) == 0 {
+			k = mod.Attr
+		}
+		for i := 0; i < len(mod.Values); i++ {
+			if valueIn(k, mod.Values[i], attrs[k]) {
+				err = errorw(AttributeOrValueExistsErr, "'%s' value '%s'", mod.Attr, mod.Values[i])
+				return
+			}
+			attrs[k] = append(attrs[k], mod.Values[i])
+		}
+	case ModifyDelete:
+		if len(k) == 0 {
+			err = errorw(NoSuchAttributeErr, "'%s'", mod.Attr)
+			return
+		} else if len(mod.Values) == 0 {
+			delete(attrs, k)
+			return
+		}
+
+		for i := 0; i < len(mod.Values); i++ {
+			var kept []string
+			for j := 0; j < len(attrs[k]); j++ {
+				if !valueEqual(k, attrs[k][j], mod.Values[i]) {
+					kept = append(kept, attrs[k][j])
+				}
+			}
+			if len(kept) == len(attrs[k]) {
+				err = errorw(NoSuchAttributeErr, "'%s' value '%s'", mod.Attr, mod.Values[i])
+				return
+			}
+			attrs[k] = kept
+		}
+
+		if len(attrs[k]) == 0 {
+			delete(attrs, k)
+		}
+	case ModifyReplace:
+		if len(k) > 0 {
+			delete(attrs, k)
+		}
+		if len(mod.Values) > 0 {
+			attrs[mod.Attr] = append([]string{}, mod.Values...)
+		}
+	default:
+		err = errorf("Unknown %T '%d'", mod.Op, mod.Op)
+	}
+
+	return
+}
+
+/*
+valueIn returns a boolean value indicative of whether the input value of
+the input attribute type is present within vals, per its matching rule.
+*/
+func valueIn(at, val string, vals []string) bool {
+	for i := 0; i < len(vals); i++ {
+		if valueEqual(at, vals[i], val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+attrKey returns the actual key used within the input map for the named
+attribute type (at), honoring case-insensitivity and alternative names,
+or a zero string if not present.
+*/
+func attrKey(attrs map[string][]string, at string) string {
+	at = canonicalAttr(at)
+	for k := range attrs {
+		if eq(canonicalAttr(k), at) {
+			return k
+		}
+	}
+
+	return ``
+}
+
+/*
+selectAttrs returns a copy of the input attribute map, limited to the
+requested attribute types (req), per section 4.5.1.8 of RFC 4511.
+*/
+func selectAttrs(attrs map[string][]string, req []string) map[string][]string {
+	if len(req) == 0 || strInSlice(`*`, req) {
+		return copyAttrs(attrs)
+	}
+
+	sel := make(map[string][]string)
+	for i := 0; i < len(req); i++ {
+		if k := attrKey(attrs, req[i]); len(k) > 0 {
+			sel[k] = append([]string{}, attrs[k]...)
+		}
+	}
+
+	return sel
+}
+
+/*
+copyAttrs returns a deep copy of the input attribute map.
+*/
+func copyAttrs(attrs map[string][]string) map[string][]string {
+	c := make(map[string][]string, len(attrs))
+	for k, v := range attrs {
+		c[k] = append([]string{}, v...)
+	}
+
+	return c
+}
+
+/*
+FetchRegistration returns the Registration bearing the input dotNotation
+value (oid) alongside an error following a search of the input Directory.
+
+If the input *DUAConfig instance is nil, one is derived from the Root DSE
+of the Directory (s. 3.5.1 of the ID). The registration is first sought at
+its inferred DN, and then by way of a broad search should that fail. See
+the Plan method extended by *DUAConfig for details.
+
+The *DUAConfig instance is assigned to the return Registration.
+*/
+func FetchRegistration(dir Directory, d *DUAConfig, oid string) (r Registration, err error) {
+	if d, err = fetchDUAConfig(dir, d); err != nil {
+		return
+	}
+
+	var entries []Entry
+	if entries, err = fetchLookup(dir, d, Lookup{Intent: LookupByOID, Value: oid}); err != nil {
+		return
+	}
+
+	for i := 0; i < len(entries) && r == nil; i++ {
+		if r, err = entryRegistration(entries[i], d); err != nil {
+			return
+		}
+	}
+
+	if r == nil {
+		err = errorw(NoSuchObjectErr, "registration '%s'", oid)
+	}
+
+	return
+}
+
+/*
+FetchChildren returns the immediate subordinate registrations of the
+registration bearing the input dotNotation value (oid) alongside an
+error following a search of the input Directory.
+
+If the input *DUAConfig instance is nil, one is derived from the Root DSE
+of the Directory. Entries describing any registration other than an
+immediate child of oid are discarded, regardless of the directory model
+in use.
+*/
+func FetchChildren(dir Directory, d *DUAConfig, oid string) (regs Registrations, err error) {
+	if d, err = fetchDUAConfig(dir, d); err != nil {
+		return
+	}
+
+	var arcs []string
+	if arcs, err = splitDotNot(oid); err != nil {
+		return
+	}
+
+	var entries []Entry
+	if entries, err = fetchLookup(dir, d, Lookup{Intent: LookupChildren, Value: oid}); err != nil {
+		return
+	}
+
+	for i := 0; i < len(entries); i++ {
+		var r Registration
+		if r, err = entryRegistration(entries[i], d); err != nil {
+			return
+		}
+
+		// Discard anything not bearing exactly one (1)
+		// extra arc, lest a supArc value be misassigned.
+		if dot := r.DotNotation(); len(dot) > 0 {
+			if !hasPrefix(dot, oid+`.`) || len(split(dot, `.`)) != len(arcs)+1 {
+				continue
+			}
+		}
+		regs = append(regs, r)
+	}
+
+	return
+}
+
+/*
+FetchRegistrants returns the registrants associated with the input
+Registration alongside an error following a search of the input Directory.
+The Registration must bear a valid *DUAConfig instance, which is assigned
+to each return Registrant.
+
+See the RegistrantsOf function for details.
+*/
+func FetchRegistrants(dir Directory, r Registration) (rants Registrants, err error) {
+	var f Filter
+	if f, err = RegistrantsOf(r); err != nil {
+		return
+	}
+
+	d := r.DUAConfig()
+	var reqs []SearchRequest
+	if len(d.Registrations) > 0 && eq(d.Registrations[0], d.Registrants[0]) {
+		// combined entries: the registrant
+		// information is the registration.
+		reqs = append(reqs, SearchRequest{BaseDN: r.DN(), Scope: BaseObject, Filter: f})
+	} else {
+		for i := 0; i < len(d.Registrants); i++ {
+			reqs = append(reqs, SearchRequest{BaseDN: d.Registrants[i], Scope: WholeSubtree, Filter: f})
+		}
+	}
+
+	var entries []Entry
+	if entries, err = fetchAll(dir, reqs); err != nil {
+		return
+	}
+
+	for i := 0; i < len(entries); i++ {
+		var rs Registrants
+		if rs, err = MarshalRegistrants(entries[i].Attributes); err != nil {
+			return
+		}
+		for j := 0; j < len(rs); j++ {
+			rs[j].SetDN(entries[i].DN)
+			rs[j].SetDUAConfig(d)
+		}
+		rants = append(rants, rs...)
+	}
+
+	return
+}
+
+/*
+fetchDUAConfig returns the input *DUAConfig instance if non-nil, else an
+instance derived from the Root DSE of the input Directory.
+*/
+func fetchDUAConfig(dir Directory, d *DUAConfig) (*DUAConfig, error) {
+	if d != nil {
+		return d, nil
+	} else if dir == nil {
+		return nil, errorf("%T is nil", dir)
+	}
+
+	dse, err := dir.RootDSE()
+	if err != nil {
+		return nil, err
+	}
+
+	d = new(DUAConfig)
+	if err = d.Marshal(dse); err == nil && !d.Valid() {
+		err = errorw(DUAConfigValidityErr, `Root DSE does not publish x660DUAConfig values`)
+	}
+
+	return d, err
+}
+
+/*
+fetchLookup plans and submits the input Lookup, falling back to a broad
+Lookup should the initial plan produce no entries.
+*/
+func fetchLookup(dir Directory, d *DUAConfig, l Lookup) (entries []Entry, err error) {
+	var reqs []SearchRequest
+	if reqs, err = d.Plan(l); err != nil {
+		return
+	} else if entries, err = fetchAll(dir, reqs); err != nil || len(entries) > 0 || l.Broad {
+		return
+	}
+
+	l.Broad = true
+	if reqs, err = d.Plan(l); err == nil {
+		entries, err = fetchAll(dir, reqs)
+	}
+
+	return
+}
+
+/*
+fetchAll submits each of the input SearchRequest instances and returns the
+combined (deduplicated) entries. Missing bases and exceeded size limits are
+not regarded as errors.
+*/
+func fetchAll(dir Directory, reqs []SearchRequest) (entries []Entry, err error) {
+	seen := make(map[string]bool)
+	for i := 0; i < len(reqs); i++ {
+		res, serr := dir.Search(reqs[i])
+		if serr != nil && !isErr(serr, NoSuchObjectErr) && !isErr(serr, SizeLimitExceededErr) {
+			err = serr
+			return
+		}
+
+		for j := 0; j < len(res); j++ {
+			if key := normalizeDN(res[j].DN); !seen[key] {
+				seen[key] = true
+				entries = append(entries, res[j])
+			}
+		}
+	}
+
+	return
+}
+
+/*
+entryRegistration marshals the input Entry into a Registration, to which
+the input *DUAConfig instance is assigned.
+*/
+func entryRegistration(e Entry, d *DUAConfig) (r Registration, err error) {
+	if r, err = MarshalRegistration(e.Attributes); err == nil {
+		r.SetDN(e.DN)
+		r.SetDUAConfig(d)
+	}
+
+	return
+}
+
+/*
+ChangeType describes the nature of an EntryChange, per the changetype
+values of RFC 2849.
+*/
+type ChangeType uint8
+
+const (
+	ChangeAdd    ChangeType = iota // add (0)
+	ChangeModify                   // modify (1)
+	ChangeDelete                   // delete (2)
+)
+
+/*
+String returns the RFC 2849 changetype name of the receiver.
+*/
+func (r ChangeType) String() string {
+	switch r {
+	case ChangeAdd:
+		return `add`
+	case ChangeModify:
+		return `modify`
+	case ChangeDelete:
+		return `delete`
+	}
+
+	return `unknown`
+}
+
+/*
+EntryChange describes a single change to be made to a Directory. The
+Attributes field applies only to ChangeAdd, and the Modifications field
+applies only to ChangeModify.
+*/
+type EntryChange struct {
+	Type          ChangeType
+	DN            string
+	Attributes    map[string][]string
+	Modifications []Modification
+}
+
+/*
+DiffEntries returns the EntryChange instances required to transform the
+first set of entries (prev) into the second (cur). DNs are compared in
+normalized form, and attribute types in case-insensitive fashion. Value
+order is not significant.
+
+Additions are returned first, in the order of cur, followed by
+modifications and then deletions, the latter in reverse order of prev
+such that subordinate entries precede their superiors if prev is sorted
+superiors-first.
+*/
+func DiffEntries(prev, cur []Entry) (changes []EntryChange) {
+	old := make(map[string]Entry, len(prev))
+	for i := 0; i < len(prev); i++ {
+		old[normalizeDN(prev[i].DN)] = prev[i]
+	}
+
+	seen := make(map[string]bool, len(cur))
+	var mods []EntryChange
+	for i := 0; i < len(cur); i++ {
+		ndn := normalizeDN(cur[i].DN)
+		seen[ndn] = true
+
+		o, found := old[ndn]
+		if !found {
+			changes = append(changes, EntryChange{
+				Type:       ChangeAdd,
+				DN:         cur[i].DN,
+				Attributes: copyAttrs(cur[i].Attributes),
+			})
+		} else if m := diffAttrs(o.Attributes, cur[i].Attributes); len(m) > 0 {
+			mods = append(mods, EntryChange{Type: ChangeModify, DN: cur[i].DN, Modifications: m})
+		}
+	}
+	changes = append(changes, mods...)
+
+	for i := len(prev) - 1; i >= 0; i-- {
+		if !seen[normalizeDN(prev[i].DN)] {
+			changes = append(changes, EntryChange{Type: ChangeDelete, DN: prev[i].DN})
+		}
+	}
+
+	return
+}
+
+/*
+diffAttrs returns the ModifyReplace instances required to transform the
+first attribute map into the second, sorted by attribute type.
+*/
+func diffAttrs(a, b map[string][]string) (mods []Modification) {
+	var keys []string
+	for k := range b {
+		keys = append(keys, k)
+	}
+	for k := range a {
+		if len(attrKey(b, k)) == 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		av, bv := a[attrKey(a, k)], b[attrKey(b, k)]
+		if !sameValues(av, bv) {
+			mods = append(mods, Modification{Op: ModifyReplace, Attr: k, Values: append([]string(nil), bv...)})
+		}
+	}
+
+	return
+}
+
+/*
+sameValues returns a boolean value indicative of whether the input slices
+contain the same values, without regard for order.
+*/
+func sameValues(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for i := 0; i < len(a); i++ {
+		counts[a[i]]++
+	}
+	for i := 0; i < len(b); i++ {
+		if counts[b[i]]--; counts[b[i]] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+ApplyChanges applies the input EntryChange instances to the input
+Directory in order, stopping at the first error encountered.
+*/
+func ApplyChanges(dir Directory, changes []EntryChange) (err error) {
+	for i := 0; i < len(changes) && err == nil; i++ {
+		c := changes[i]
+		switch c.Type {
+		case ChangeAdd:
+			err = dir.Add(c.DN, c.Attributes)
+		case ChangeModify:
+			err = dir.Modify(c.DN, c.Modifications...)
+		case ChangeDelete:
+			err = dir.Delete(c.DN)
+		default:
+			err = errorf("Unknown %T '%d'", c.Type, c.Type)
+		}
+
+		if err != nil {
+			err = errorw(err, "%s %s", c.Type, c.DN)
+		}
+	}
+
+	return
+}
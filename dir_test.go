@@ -0,0 +1,92 @@
+package dcxl
+
+import (
+	"errors"
+	"testing"
+)
+
+/*
+TestFetchRegistrants_noRegistrationBase verifies that registrants can be
+fetched using a *DUAConfig that describes registrant bases only.
+*/
+func TestFetchRegistrants_noRegistrationBase(t *testing.T) {
+	dua := &DUAConfig{
+		Registrants:    []string{`ou=Registrants,o=rA`},
+		DirectoryModel: TwoDimensional,
+	}
+
+	dir := NewMemoryDirectory(dua)
+	fa := &FirstAuthority{R_DN: `registrantID=X,ou=Registrants,o=rA`, R_Id: `X`, R_CN: `Jesse Coretta`}
+	if err := dir.AddRegistrants(Registrants{fa}); err != nil {
+		t.Fatal(err)
+	}
+
+	sub := &SubArc{
+		R_DN:        `dotNotation=1.3.6,ou=Registrations,o=rA`,
+		R_DotNot:    `1.3.6`,
+		R_FAuthyDN:  []string{fa.R_DN},
+		R_DUAConfig: dua,
+	}
+
+	rants, err := FetchRegistrants(dir, sub)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rants) != 1 || rants[0].RegistrantID() != `X` {
+		t.Errorf("unexpected registrants: %#v", rants)
+	}
+}
+
+/*
+TestApplyModification verifies that values are matched per the matching
+rule of their attribute type, and that adding an existing value fails.
+*/
+func TestApplyModification(t *testing.T) {
+	attrs := map[string][]string{`identifier`: {`Example`}}
+
+	err := applyModification(attrs, Modification{Op: ModifyAdd, Attr: `identifier`, Values: []string{`EXAMPLE`}})
+	if !errors.Is(err, AttributeOrValueExistsErr) {
+		t.Errorf("expected %v, got %v", AttributeOrValueExistsErr, err)
+	} else if len(attrs[`identifier`]) != 1 {
+		t.Errorf("failed add modified values: %v", attrs[`identifier`])
+	}
+
+	err = applyModification(attrs, Modification{Op: ModifyDelete, Attr: `identifier`, Values: []string{`example`}})
+	if err != nil {
+		t.Fatal(err)
+	} else if _, found := attrs[`identifier`]; found {
+		t.Errorf("expected identifier removal, got %v", attrs[`identifier`])
+	}
+
+	attrs[`n`] = []string{`01`}
+	if err = applyModification(attrs, Modification{Op: ModifyAdd, Attr: `n`, Values: []string{`1`}}); !errors.Is(err, AttributeOrValueExistsErr) {
+		t.Errorf("expected %v, got %v", AttributeOrValueExistsErr, err)
+	}
+}
+
+/*
+TestMemoryDirectory_AddRegistrations_nil verifies that nil elements are
+skipped, and that superiors are written before their subordinates.
+*/
+func TestMemoryDirectory_AddRegistrations_nil(t *testing.T) {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		Registrants:    []string{`ou=Registrants,o=rA`},
+		DirectoryModel: ThreeDimensional,
+	}
+
+	dir := NewMemoryDirectory(dua)
+	var nilSub *SubArc
+	var nilFA *FirstAuthority
+	if err := dir.AddRegistrations(Registrations{
+		&SubArc{R_DN: `n=3,n=1,ou=Registrations,o=rA`, R_N: `3`},
+		nil,
+		nilSub,
+		&RootArc{R_DN: `n=1,ou=Registrations,o=rA`, R_N: `1`},
+	}); err != nil {
+		t.Fatal(err)
+	} else if err = dir.AddRegistrants(Registrants{nil, nilFA}); err != nil {
+		t.Fatal(err)
+	} else if n := dir.Len(); n != 2 {
+		t.Errorf("expected 2 entries, got %d", n)
+	}
+}
@@ -45,10 +45,54 @@ This package offers the following:
 
 • Extensible design; user-authored "Get" and "Set" closure functions are supported (but not required) at virtually every point, allowing limitless control over the contents and presentation of relevant objects/values
 
+• Composition of "Get" and "Set" closure functions into pipelines, type-safe wrapping of custom functions, and a registry of named functions, allowing transforms to be selected from configuration files
+
+• Field enumeration of all attribute types, with generic typed accessors and stock converters (time.Time, *big.Int, OID, *url.URL and bool) complementing the any-returning GetFunc methods
+
+• Dynamic attribute access by name (Get, Set and Attributes) upon all Registration and Registrant types, honoring alternative names and single-valued semantics
+
+• Deep copying, matching-rule aware equality and merging (with a configurable conflict strategy) of all Registration and Registrant types, as well as of Registrations and Registrants slices
+
+• Numeric OID sorting, lookup by OID, DN or identifier, predicate filtering, grouping by parent arc, de-duplication and set operations (union, intersection and difference) upon Registrations and Registrants slices
+
+• Structured schema validation errors (see Schema.Check) bearing the offending attribute type, value, section of the ID and severity, which wrap the predefined error instances (for use with errors.Is) and aggregate into a single error
+
 • Seamless compatibility with *ldap.NewEntry using the map-populating Unmarshal method, which is extended through any Registration or Registrant type instance
 
 • Portable configuration type (per s. 2.2.4 of the ID), allowing critical information and configuration settings to be kept within individual Registration/Registrant instances for efficient and reliable operation
 
+• RFC 4515 search filter composition and parsing, with properly escaped assertion values and model-aware constructors for the lookups described in s. 3.7.2 of the ID, as well as in-memory evaluation of filters against Registration and Registrant instances
+
+• Pluggable Directory backend interface, alongside a self-contained in-memory implementation and helpers for fetching registrations, children and registrants in terms of the directory model in use
+
+• Minimal LDAPv3 server subpackage (dsa), suitable for integration testing of DUAs -- including auto-configuration by way of the Root DSE -- without the need for a full-featured DSA
+
+• Embedded schema definitions for all attributeTypes and objectClasses defined in the ID, with writers for OpenLDAP (cn=config and slapd.conf), ApacheDS, Netscape/389-DS and RFC 4512 subschema formats
+
+• RFC 4512 subschema parsing, allowing Registration and Registrant instances to be validated against the schema published by a live DSA
+
+• Draft revision detection and migration, allowing entries to be converted between revisions of the ID with a report of any lossy fields
+
+• JSON encoding and decoding of all Registration and Registrant types using the attribute type names defined in the ID, including polymorphic decoding of Registrations and Registrants instances
+
+• Streaming RDF export (Turtle and N-Triples) of Registration and Registrant instances, with urn:oid IRIs for registrations and resource links for DN references
+
+• CSV export of Registrations and validated CSV import of SubArc registrations, suitable for spreadsheet-based allocation workflows
+
+• Import of the IANA Private Enterprise Numbers registry as SubArc registrations and CurrentAuthority registrants, with incremental change sets relative to a previous import
+
+• ASN.1 module scanning, producing SubArc registrations from OBJECT IDENTIFIER value assignments, with value references resolved within and across modules and unresolved references reported
+
+• Conversion between Registrations (and Registrants) and the XML interchange format of the OID Repository (oid-info.com), preserving the OID hierarchy
+
+• LDIF (RFC 2849) reading and writing of Entry instances, and GetOrSetFuncs for converting between the dotNotation, ASN.1 Notation and OID-IRI forms of an OID
+
+• Command-line tool (cmd/dcxl) for converting OIDs, validating entries, generating LDIF, exporting the schema and printing the registration hierarchy, with JSON output and exit codes suitable for scripting
+
+• Rendering of registration hierarchies as Graphviz DOT digraphs, Mermaid flowcharts, indented text trees and nested JSON objects, with optional highlighting of status, leaf-node, frozen and range allocation states, and subtree, depth and filter limits
+
+• Static HTML OID browser generation, with breadcrumbs, subordinate and sibling navigation, registrant contact blocks filtered through a privacy profile, a JSON search index and overridable html/template templates
+
 # Enhanced Operation
 
 For those dealing with ASN.1 Notation, Dot Notation, NumberForm and NameAndNumberForm values, OR for situations requiring uint128 NumberForm support, consider my objectid package (https://pkg.go.dev/github.com/JesseCoretta/go-objectid) for inclusion in any custom Set/Get functions in use. It can offer a potent advantage in scenarios relevant to this very package, and extends several very useful methods to greatly reduce the tedium (and error-prone nature) of this subject matter.
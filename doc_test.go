@@ -1,7 +1,11 @@
 package dcxl
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 )
 
@@ -276,3 +280,721 @@ func ExampletimeToGenTime() {
 	}
 	// Output: Times are valid and equal: true
 }
+
+func ExampleByIdentifier() {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: ThreeDimensional,
+	}
+
+	f, err := ByIdentifier(dua, `enter*`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s\n", f)
+	// Output: (&(|(objectClass=x660RootArc)(objectClass=x660SubArc))(|(identifier=enter*)(additionalIdentifier=enter*)))
+}
+
+func ExampleChildrenOf() {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: TwoDimensional,
+	}
+
+	f, err := ChildrenOf(dua, `1.3.6.1.4.1`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s\n", f)
+	// Output: (&(objectClass=x660SubArc)(supArc=dotNotation=1.3.6.1.4.1,ou=Registrations,o=rA))
+}
+
+func ExampleEqualityFilter_String() {
+	f := Equal(`description`, `Widgets (*and* gadgets)`)
+
+	fmt.Printf("%s\n", f)
+	// Output: (description=Widgets \28\2aand\2a gadgets\29)
+}
+
+func ExampleParseFilter() {
+	f, err := ParseFilter(`(&(objectClass=x660SubArc)(|(identifier=enter*)(n>=56521))(!(isFrozen=TRUE)))`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%T: %s\n", f, f)
+	// Output: dcxl.AndFilter: (&(objectClass=x660SubArc)(|(identifier=enter*)(n>=56521))(!(isFrozen=TRUE)))
+}
+
+func ExampleMatchFilter() {
+	var X *SubArc = new(SubArc)
+	X.SetN(`1`)
+	X.SetIdentifier(`enterprise`)
+	X.SetAdditionalIdentifier(`enterprises`)
+	X.SetDotNotation(`1.3.6.1.4.1`)
+
+	f, _ := ParseFilter(`(&(objectClass=x660SubArc)(identifier=ENTER*)(numberForm=1))`)
+	ok, err := MatchFilter(f, X)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("Matched: %t\n", ok)
+	// Output: Matched: true
+}
+
+func ExampleRegistrations_Match() {
+	var R *RootArc = new(RootArc)
+	R.SetN(`1`)
+	R.SetIdentifier(`iso`)
+
+	var X *SubArc = new(SubArc)
+	X.SetN(`3`)
+	X.SetIdentifier(`identified-organization`)
+	X.SetAdditionalIdentifier(`org`)
+
+	regs := Registrations{R, X}
+	f, _ := ParseFilter(`(|(identifier~=ISO)(additionalIdentifier=org))`)
+
+	fmt.Printf("%d matches\n", len(regs.Match(f)))
+	// Output: 2 matches
+}
+
+func ExampleDUAConfig_Plan() {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: ThreeDimensional,
+	}
+
+	reqs, err := dua.Plan(Lookup{Intent: LookupAncestors, Value: `1.3.6.1`})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i := 0; i < len(reqs); i++ {
+		fmt.Printf("%s %s %s\n", reqs[i].Scope, reqs[i].BaseDN, reqs[i].Filter)
+	}
+	// Output:
+	// baseObject n=1,ou=Registrations,o=rA (&(objectClass=x660RootArc)(n=1))
+	// baseObject n=3,n=1,ou=Registrations,o=rA (&(objectClass=x660SubArc)(n=3))
+	// baseObject n=6,n=3,n=1,ou=Registrations,o=rA (&(objectClass=x660SubArc)(n=6))
+}
+
+func ExampleDUAConfig_RegistrationOID() {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: ThreeDimensional,
+	}
+
+	oid, err := dua.RegistrationOID(`n=56521,n=1,n=4,n=1,n=6,n=3,n=1,ou=Registrations,o=rA`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(oid)
+	// Output: 1.3.6.1.4.1.56521
+}
+
+func ExampleFetchChildren() {
+	dua := &DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: TwoDimensional,
+	}
+
+	dir := NewMemoryDirectory(dua)
+	err := dir.AddRegistrations(Registrations{
+		&RootArc{R_DN: `n=1,ou=Registrations,o=rA`, R_N: `1`, R_Id: `iso`},
+		&SubArc{R_DN: `dotNotation=1.3,ou=Registrations,o=rA`, R_N: `3`, R_DotNot: `1.3`, R_Id: `identified-organization`,
+			R_SupArc: `n=1,ou=Registrations,o=rA`},
+		&SubArc{R_DN: `dotNotation=1.3.6,ou=Registrations,o=rA`, R_N: `6`, R_DotNot: `1.3.6`, R_Id: `dod`,
+			R_SupArc: `dotNotation=1.3,ou=Registrations,o=rA`},
+		&SubArc{R_DN: `dotNotation=1.3.6.1,ou=Registrations,o=rA`, R_N: `1`, R_DotNot: `1.3.6.1`, R_Id: `internet`,
+			R_SupArc: `dotNotation=1.3.6,ou=Registrations,o=rA`},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// A nil *DUAConfig means the Root DSE is consulted.
+	kids, err := FetchChildren(dir, nil, `1.3`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i := 0; i < len(kids); i++ {
+		fmt.Printf("%s (%s)\n", kids[i].DotNotation(), kids[i].Identifier())
+	}
+	// Output: 1.3.6 (dod)
+}
+
+func ExampleDraftSchema() {
+	schema := DraftSchema()
+	fmt.Printf("%d attributeTypes, %d objectClasses\n",
+		len(schema.AttributeTypes), len(schema.ObjectClasses))
+
+	at, _ := schema.AttributeType(`numberForm`)
+	fmt.Println(at)
+	// Output:
+	// 88 attributeTypes, 4 objectClasses
+	// ( 1.3.6.1.4.1.56521.101.2.1.1 NAME ( 'n' 'numberForm' ) DESC 'A single unsigned integer value assigned to a registration to represent its primary integer identifier' EQUALITY integerMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )
+}
+
+func ExampleSchema_Write() {
+	oc, _ := DraftSchema().ObjectClass(`x660DUAConfig`)
+	schema := &Schema{ObjectClasses: []ObjectClass{oc}}
+
+	if err := schema.Write(os.Stdout, OpenLDAPSchemaFormat); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// # x660 schema, per draft-coretta-x660-ldap-08
+	//
+	// objectclass ( 1.3.6.1.4.1.56521.101.2.2.4
+	// 	NAME 'x660DUAConfig'
+	// 	DESC 'Entry class to facilitate advertisement of optimal X.660 DUA configuration values'
+	// 	SUP top
+	// 	AUXILIARY
+	// 	MAY ( rADirectoryModel $ rARegistrantBase $ rAServiceMail $ rAServiceURI $ rARegistrationBase ) )
+}
+
+func ExampleParseAttributeType() {
+	at, err := ParseAttributeType(`( 2.5.4.3 NAME ( 'cn' 'commonName' ) SUP name )`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s: %s\n", at.Name(), at.Sup)
+	// Output: cn: name
+}
+
+func ExampleSchema_Validate() {
+	var sub SubArc
+	sub.SetDN(`n=6,n=3,n=1,ou=Registrations,o=rA`)
+	sub.SetDotNotation(`1.3.6`)
+
+	violations := DraftSchema().Validate(&sub)
+	for i := 0; i < len(violations); i++ {
+		fmt.Println(violations[i])
+	}
+	// Output: n: required attribute type missing
+}
+
+func ExampleDetectDraft() {
+	var sub SubArc
+	sub.SetN(`6`)
+	sub.SetDotNotation(`1.3.6`)
+
+	v, err := DetectDraft(sub.Unmarshal())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(v)
+	// Output: draft-coretta-x660-ldap-08
+}
+
+func ExampleSubArc_MarshalJSON() {
+	var sub SubArc
+	sub.SetDN(`n=6,n=3,n=1,ou=Registrations,o=rA`)
+	sub.SetN(`6`)
+	sub.SetIdentifier(`dod`)
+	sub.SetDotNotation(`1.3.6`)
+
+	b, err := json.Marshal(sub)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(string(b))
+	// Output: {"dn":"n=6,n=3,n=1,ou=Registrations,o=rA","dotNotation":"1.3.6","identifier":"dod","n":"6","objectClass":["top","x660SubArc"]}
+}
+
+func ExampleRDFWriter() {
+	var sub SubArc
+	sub.SetDN(`n=6,n=3,n=1,ou=Registrations,o=rA`)
+	sub.SetN(`6`)
+	sub.SetDotNotation(`1.3.6`)
+	sub.SetSupArc(`n=3,n=1,ou=Registrations,o=rA`)
+
+	w := NewRDFWriter(os.Stdout, NTriplesFormat)
+	w.Vocabulary = `urn:x660:`
+	w.DUAConfig = &DUAConfig{
+		DirectoryModel: ThreeDimensional,
+		Registrations:  []string{`ou=Registrations,o=rA`},
+	}
+
+	if err := w.WriteRegistration(&sub); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// <urn:oid:1.3.6> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <urn:x660:x660SubArc> .
+	// <urn:oid:1.3.6> <urn:x660:dotNotation> "1.3.6" .
+	// <urn:oid:1.3.6> <urn:x660:n> "6" .
+	// <urn:oid:1.3.6> <urn:x660:supArc> <urn:oid:1.3> .
+}
+
+func ExampleCSVCodec_Read() {
+	codec := &CSVCodec{
+		Header: map[string]string{`OID`: `dotNotation`, `Name`: `identifier`},
+		DUAConfig: &DUAConfig{
+			DirectoryModel: ThreeDimensional,
+			Registrations:  []string{`ou=Registrations,o=rA`},
+		},
+	}
+
+	input := "OID,Name\n1.3.6,dod\n1.3..1,bogus\n"
+	regs, rowErrs, err := codec.Read(strings.NewReader(input))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i := 0; i < len(regs); i++ {
+		fmt.Printf("%s (%s)\n", regs[i].DN(), regs[i].N())
+	}
+	fmt.Printf("%d invalid row(s)\n", len(rowErrs))
+	// Output:
+	// n=6,n=3,n=1,ou=Registrations,o=rA (6)
+	// 1 invalid row(s)
+}
+
+func ExamplePENImporter_Changes() {
+	const before = `PRIVATE ENTERPRISE NUMBERS
+
+Decimal
+| Organization
+| | Contact
+| | | Email
+| | | |
+0
+  Reserved
+    Internet Assigned Numbers Authority
+      iana&iana.org
+2
+  IBM (https://w3.ibm.com/standards )
+    Glenn Daly
+      gdaly&us.ibm.com
+`
+	const after = `0
+  Reserved
+    Internet Assigned Numbers Authority
+      iana&iana.org
+2
+  IBM
+    Glenn Daly
+      gdaly&us.ibm.com
+3
+  CMU
+    ---none---
+      ---none---
+`
+
+	prev, _ := ParsePENs(strings.NewReader(before))
+	cur, _ := ParsePENs(strings.NewReader(after))
+
+	imp := &PENImporter{DUAConfig: &DUAConfig{
+		DirectoryModel: TwoDimensional,
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		Registrants:    []string{`ou=Registrants,o=rA`},
+	}}
+
+	changes, err := imp.Changes(prev, cur)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	for i := 0; i < len(changes); i++ {
+		fmt.Printf("%s %s\n", changes[i].Type, changes[i].DN)
+	}
+	// Output:
+	// add registrantID=pen-3,ou=Registrants,o=rA
+	// add dotNotation=1.3.6.1.4.1.3,ou=Registrations,o=rA
+	// modify registrantID=pen-2,ou=Registrants,o=rA
+	// modify dotNotation=1.3.6.1.4.1.2,ou=Registrations,o=rA
+}
+
+func ExampleScanASN1() {
+	const src = `
+Example DEFINITIONS ::= BEGIN
+id-pkix OBJECT IDENTIFIER ::= { iso(1) identified-organization(3)
+	dod(6) internet(1) security(5) mechanisms(5) pkix(7) }
+id-qt OBJECT IDENTIFIER ::= { id-pkix 2 } -- policy qualifiers
+id-qt-cps OBJECT IDENTIFIER ::= { id-qt 1 }
+id-bogus OBJECT IDENTIFIER ::= { id-unknown 1 }
+END`
+
+	mods, err := ScanASN1(strings.NewReader(src))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var imp ASN1Importer
+	regs, unresolved, _ := imp.Import(mods)
+	for i := 0; i < len(regs); i++ {
+		fmt.Println(regs[i].DotNotation(), regs[i].ASN1Notation())
+	}
+	for i := 0; i < len(unresolved); i++ {
+		fmt.Println(unresolved[i])
+	}
+	// Output:
+	// 1.3.6.1.5.5.7 {iso(1) identified-organization(3) dod(6) internet(1) security(5) mechanisms(5) pkix(7)}
+	// 1.3.6.1.5.5.7.2 {iso(1) identified-organization(3) dod(6) internet(1) security(5) mechanisms(5) pkix(7) id-qt(2)}
+	// 1.3.6.1.5.5.7.2.1 {iso(1) identified-organization(3) dod(6) internet(1) security(5) mechanisms(5) pkix(7) id-qt(2) id-qt-cps(1)}
+	// Example: line 7: id-bogus (id-unknown): Unresolved ASN.1 value reference: no such value
+}
+
+func ExampleOIDInfoCodec_Read() {
+	const doc = `<?xml version="1.0" encoding="UTF-8"?>
+<oid-database>
+	<oid>
+		<dot-notation>1.3.6.1.4.1.56521</dot-notation>
+		<asn1-notation>{iso(1) identified-organization(3) dod(6) internet(1) private(4) enterprise(1) 56521}</asn1-notation>
+		<description>Jesse Coretta</description>
+	</oid>
+	<oid>
+		<dot-notation>1.3.6.1.4.1.56521.101</dot-notation>
+		<current-registrant>
+			<first-name>Jesse</first-name>
+			<last-name>Coretta</last-name>
+		</current-registrant>
+	</oid>
+</oid-database>`
+
+	codec := &OIDInfoCodec{DUAConfig: &DUAConfig{
+		DirectoryModel: TwoDimensional,
+		Registrations:  []string{`ou=Registrations,o=rA`},
+	}}
+
+	regs, _, err := codec.Read(strings.NewReader(doc))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	sub := regs[1].(*SubArc)
+	fmt.Println(sub.DN())
+	fmt.Println(sub.SupArc())
+	fmt.Println(sub.CombinedCurrentAuthority().CN())
+	// Output:
+	// dotNotation=1.3.6.1.4.1.56521.101,ou=Registrations,o=rA
+	// dotNotation=1.3.6.1.4.1.56521,ou=Registrations,o=rA
+	// Jesse Coretta
+}
+
+func ExampleReadLDIF() {
+	const doc = `version: 1
+
+dn: n=1,ou=Registrations,o=rA
+objectClass: top
+objectClass: x660RootArc
+n: 1
+description:: SW50ZXJuYXRpb25hbCBPcmdhbml6YXRpb24gZm9yIFN0YW5kYXJkaXphdGlvbg==
+`
+
+	entries, err := ReadLDIF(strings.NewReader(doc))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(entries[0].DN)
+	fmt.Println(entries[0].Attributes[`description`][0])
+	// Output:
+	// n=1,ou=Registrations,o=rA
+	// International Organization for Standardization
+}
+
+func ExampleDotNotToASN1Not() {
+	asn, _ := DotNotToASN1Not(`1.3.6.1.4.1.56521`, nil)
+	iri, _ := DotNotToIRI(`2.25.7`, nil)
+	dot, _ := IRIToDotNot(`/ISO/Identified-Organization/6`, nil)
+	fmt.Println(asn)
+	fmt.Println(iri)
+	fmt.Println(dot)
+	// Output:
+	// {iso(1) identified-organization(3) 6 1 4 1 56521}
+	// /UUID/7
+	// 1.3.6
+}
+
+func ExampleTreeRenderer_Render() {
+	var regs Registrations
+	for _, x := range [][]string{
+		{`1.3.6.1.4`, `4`, `private`},
+		{`1.3.6.1.4.1`, `1`, `enterprise`},
+		{`1.3.6.1.4.1.56521`, `56521`, ``},
+		{`1.3.6.1.4.1.99999`, `99999`, ``},
+	} {
+		sub := &SubArc{}
+		sub.SetDotNotation(x[0])
+		sub.SetN(x[1])
+		sub.SetIdentifier(x[2])
+		regs = append(regs, sub)
+	}
+	regs[3].SetStatus(`reserved`)
+
+	tr := TreeRenderer{Format: TextTreeFormat, States: true}
+	if err := tr.Render(os.Stdout, regs); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// private(4)
+	// └── enterprise(1)
+	//     ├── 56521
+	//     └── 99999 [reserved]
+}
+
+func ExampleTreeRenderer_Render_json() {
+	root := &RootArc{}
+	root.SetN(`2`)
+	root.SetIdentifier(`joint-iso-itu-t`)
+
+	sub := &SubArc{}
+	sub.SetDotNotation(`2.25`)
+	sub.SetN(`25`)
+	sub.SetIdentifier(`uuid`)
+
+	tr := TreeRenderer{Format: JSONTreeFormat}
+	if err := tr.Render(os.Stdout, Registrations{root, sub}); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// [
+	//   {
+	//     "oid": "2",
+	//     "label": "joint-iso-itu-t(2)",
+	//     "identifier": "joint-iso-itu-t",
+	//     "children": [
+	//       {
+	//         "oid": "2.25",
+	//         "label": "uuid(25)",
+	//         "identifier": "uuid"
+	//       }
+	//     ]
+	//   }
+	// ]
+}
+
+func ExampleSiteGenerator_Generate() {
+	dir, err := os.MkdirTemp(``, `dcxl-site`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	var regs Registrations
+	for _, x := range [][]string{
+		{`1.3.6.1.4.1`, `1`, `enterprise`},
+		{`1.3.6.1.4.1.56521`, `56521`, ``},
+		{`1.3.6.1.4.1.56522`, `56522`, ``},
+	} {
+		sub := &SubArc{}
+		sub.SetDotNotation(x[0])
+		sub.SetN(x[1])
+		sub.SetIdentifier(x[2])
+		regs = append(regs, sub)
+	}
+	regs[2].SetStatus(`private`)
+
+	if err = (SiteGenerator{}).Generate(dir, regs, nil); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	files, _ := os.ReadDir(dir)
+	for _, f := range files {
+		fmt.Println(f.Name())
+	}
+	// Output:
+	// 1.3.6.1.4.1.56521.html
+	// 1.3.6.1.4.1.html
+	// index.html
+	// search.json
+}
+
+func ExampleCompose() {
+	var X *SubArc = new(SubArc)
+	X.SetDUAConfig(&DUAConfig{
+		Registrations:  []string{`ou=Registrations,o=rA`},
+		DirectoryModel: ThreeDimensional,
+	})
+
+	// Each GetOrSetFunc is applied in turn: the OID-IRI
+	// becomes a dotNotation, which then becomes a DN.
+	if err := X.SetDN(`/ISO/Identified-Organization/6`, IRIToDotNot, DotNotToDN3D); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Printf("%s\n", X.DN())
+	// Output: n=6,n=3,n=1,ou=Registrations,o=rA
+}
+
+func ExampleComposeNamed() {
+	// Names such as these might be read from a configuration file.
+	fn, err := ComposeNamed(`ASN1NotToDotNot`, `DotNotToIRI`)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	iri, err := fn(`{iso(1) identified-organization(3) dod(6)}`, nil)
+	fmt.Println(iri, err)
+
+	_, err = ComposeNamed(`NoSuchFunc`)
+	fmt.Println(err)
+	// Output:
+	// /ISO/Identified-Organization/6 <nil>
+	// Unknown GetOrSetFunc 'NoSuchFunc'
+}
+
+func ExampleTyped() {
+	upper := Typed(func(s string, _ any) (string, error) {
+		return strings.ToUpper(s), nil
+	})
+
+	var X *SubArc = new(SubArc)
+	if err := X.SetIdentifier(`dod`, upper); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(X.Identifier())
+
+	fmt.Println(X.SetIdentifier(6, upper))
+	// Output:
+	// DOD
+	// Unsupported type int (expecting string)
+}
+
+func ExampleGet() {
+	var X *SubArc = new(SubArc)
+	X.SetDotNotation(`2.25.987895962269883002155146617097157934`)
+	X.SetCreateTime(`20230102150405Z`)
+	X.SetLeafNode(true)
+
+	oid, err := Get(X, FieldDotNotation, AsOID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(len(oid), oid[2].BitLen())
+
+	created, _ := Get(X, FieldRegistrationCreated, AsTime)
+	fmt.Println(created.Year())
+
+	leaf, _ := Get(X, FieldIsLeafNode, AsBool)
+	fmt.Println(leaf)
+
+	_, err = Get(X, FieldDotNotation, AsTime)
+	fmt.Println(err)
+	// Output:
+	// 3 120
+	// 2023
+	// true
+	// Invalid generalizedTime value '2.25.987895962269883002155146617097157934'
+}
+
+func ExampleParseField() {
+	f, err := ParseField(`numberForm`)
+	fmt.Println(f, f == FieldN, err)
+	// Output: n true <nil>
+}
+
+func ExampleSubArc_Set() {
+	var X *SubArc = new(SubArc)
+	if err := X.Set(`numberForm`, `56521`); err != nil {
+		fmt.Println(err)
+		return
+	}
+	X.Set(`REGISTRATIONSTATUS`, `reserved`)
+	X.Set(`unicodeValue`, `Example`, `Beispiel`)
+
+	fmt.Println(X.N(), X.Get(`registrationStatus`), X.Get(`unicodeValue`))
+	fmt.Println(X.Attributes())
+	fmt.Println(X.Set(`n`, `1`, `2`))
+	fmt.Println(X.Set(`currentAuthorityCommonName`, `Jesse`))
+	// Output:
+	// 56521 [reserved] [Example Beispiel]
+	// [n registrationStatus unicodeValue]
+	// Attribute type 'n' is single-valued; 2 values provided
+	// Attribute type 'currentAuthorityCommonName' not applicable to *dcxl.SubArc
+}
+
+func ExampleSubArc_Merge() {
+	var X *SubArc = new(SubArc)
+	X.Set(`dotNotation`, `1.3.6.1.4.1.56521`)
+	X.Set(`registrationStatus`, `reserved`)
+	X.Set(`unicodeValue`, `Example`)
+
+	Y := X.Clone().(*SubArc)
+	Y.Set(`registrationStatus`, `allocated`)
+	Y.Set(`unicodeValue`, `Beispiel`, `EXAMPLE`)
+
+	fmt.Println(X.Merge(Y, FailOnConflict))
+	if err := X.Merge(Y, Overwrite); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(X.Get(`registrationStatus`), X.Get(`unicodeValue`), X.Equal(Y))
+	// Output:
+	// Merge conflict for 'registrationStatus': 'allocated' differs from 'reserved'
+	// [allocated] [Example Beispiel EXAMPLE] false
+}
+
+func ExampleRegistrations_Sort() {
+	var regs Registrations
+	for _, dot := range []string{`2.10`, `2.9`, `2.9.1`, `2.10`} {
+		X := new(SubArc)
+		X.SetDotNotation(dot)
+		regs = append(regs, X)
+	}
+
+	regs = regs.Dedup(OIDKey)
+	regs.Sort()
+	for _, reg := range regs {
+		fmt.Println(reg.(*SubArc).DotNotation())
+	}
+	fmt.Println(len(regs.GroupByParent()[`2`]), regs.Lookup(OIDKey, `2.9.1`) != nil)
+	// Output:
+	// 2.9
+	// 2.9.1
+	// 2.10
+	// 2 true
+}
+
+func ExampleSchema_Check() {
+	err := DraftSchema().Check(map[string][]string{
+		`objectClass`: {`top`, `x660SubArc`},
+		`n`:           {`5`, `6`},
+	})
+
+	var verrs ValidationErrors
+	if errors.As(err, &verrs) {
+		for _, verr := range verrs {
+			fmt.Println(verr.Attr, verr.Section, verr.Severity)
+		}
+	}
+	fmt.Println(errors.Is(err, RegistrationValidityErr))
+	fmt.Println(err)
+	// Output:
+	// n 2.1.1 error
+	// true
+	// n: SINGLE-VALUE attribute type bears 2 values: '5, 6' (s. 2.1.1)
+}
@@ -0,0 +1,232 @@
+package dsa
+
+/*
+ber.go contains the minimal subset of the ITU-T Rec. X.690 Basic Encoding
+Rules (BER) needed to transport LDAPv3 messages, per section 5.1 of RFC
+4511. Only definite-length, single-octet identifiers are supported, which
+is all LDAP requires.
+*/
+
+import (
+	"bufio"
+	"io"
+)
+
+const (
+	tagBoolean     byte = 0x01
+	tagInteger     byte = 0x02
+	tagOctetString byte = 0x04
+	tagNull        byte = 0x05
+	tagEnumerated  byte = 0x0a
+	tagSequence    byte = 0x30
+	tagSet         byte = 0x31
+
+	classApplication byte = 0x40
+	classContext     byte = 0x80
+	constructed      byte = 0x20
+)
+
+/*
+maxElementSize limits the size of any single inbound element, such that
+a malicious or confused client cannot exhaust memory.
+*/
+const maxElementSize = 16 << 20
+
+/*
+element is a single decoded BER TLV. The id field contains the complete
+identifier octet (class, form and tag number), while the data field holds
+the contents octets.
+*/
+type element struct {
+	id   byte
+	data []byte
+}
+
+/*
+readElement reads one complete BER element from the input reader.
+*/
+func readElement(r *bufio.Reader) (e element, err error) {
+	if e.id, err = r.ReadByte(); err != nil {
+		return
+	} else if e.id&0x1f == 0x1f {
+		err = errorf("BER: multi-octet identifiers are not supported")
+		return
+	}
+
+	var l int
+	if l, err = readLength(r); err != nil {
+		return
+	}
+
+	e.data = make([]byte, l)
+	_, err = io.ReadFull(r, e.data)
+
+	return
+}
+
+func readLength(r *bufio.Reader) (l int, err error) {
+	var b byte
+	if b, err = r.ReadByte(); err != nil {
+		return
+	} else if b&0x80 == 0 {
+		l = int(b)
+		return
+	}
+
+	n := int(b & 0x7f)
+	if n == 0 || n > 4 {
+		err = errorf("BER: unsupported length encoding (0x%02x)", b)
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		if b, err = r.ReadByte(); err != nil {
+			return
+		}
+		l = l<<8 | int(b)
+	}
+
+	if l > maxElementSize {
+		err = errorf("BER: element too large (%d octets)", l)
+	}
+
+	return
+}
+
+/*
+children returns the elements contained within the receiver's contents,
+which must be of the constructed form.
+*/
+func (r element) children() (kids []element, err error) {
+	if r.id&constructed == 0 {
+		err = errorf("BER: element 0x%02x is not constructed", r.id)
+		return
+	}
+
+	b := r.data
+	for len(b) > 0 {
+		if len(b) < 2 {
+			err = errorf("BER: truncated element")
+			return
+		}
+
+		var e element
+		e.id = b[0]
+		l, off := int(b[1]), 2
+		if b[1]&0x80 != 0 {
+			n := int(b[1] & 0x7f)
+			if n == 0 || n > 4 || len(b) < 2+n {
+				err = errorf("BER: bad length encoding")
+				return
+			}
+			l = 0
+			for i := 0; i < n; i++ {
+				l = l<<8 | int(b[2+i])
+			}
+			off += n
+		}
+
+		if l < 0 || len(b) < off+l {
+			err = errorf("BER: truncated element")
+			return
+		}
+
+		e.data = b[off : off+l]
+		kids = append(kids, e)
+		b = b[off+l:]
+	}
+
+	return
+}
+
+/*
+str returns the receiver's contents as a string.
+*/
+func (r element) str() string {
+	return string(r.data)
+}
+
+/*
+integer returns the receiver's contents as a (signed) integer.
+*/
+func (r element) integer() (v int64, err error) {
+	if len(r.data) == 0 || len(r.data) > 8 {
+		err = errorf("BER: bad integer length %d", len(r.data))
+		return
+	}
+
+	if r.data[0]&0x80 != 0 {
+		v = -1
+	}
+	for i := 0; i < len(r.data); i++ {
+		v = v<<8 | int64(r.data[i])
+	}
+
+	return
+}
+
+/*
+boolean returns the receiver's contents as a boolean.
+*/
+func (r element) boolean() bool {
+	return len(r.data) > 0 && r.data[0] != 0
+}
+
+/*
+encode returns the complete BER encoding of an element bearing the input
+identifier octet and contents.
+*/
+func encode(id byte, data []byte) []byte {
+	b := []byte{id}
+	switch l := len(data); {
+	case l < 0x80:
+		b = append(b, byte(l))
+	case l <= 0xff:
+		b = append(b, 0x81, byte(l))
+	case l <= 0xffff:
+		b = append(b, 0x82, byte(l>>8), byte(l))
+	default:
+		b = append(b, 0x84, byte(l>>24), byte(l>>16), byte(l>>8), byte(l))
+	}
+
+	return append(b, data...)
+}
+
+/*
+encodeSeq returns the BER encoding of a constructed element bearing the
+input identifier octet, containing each of the input encoded elements.
+*/
+func encodeSeq(id byte, parts ...[]byte) []byte {
+	var data []byte
+	for i := 0; i < len(parts); i++ {
+		data = append(data, parts[i]...)
+	}
+
+	return encode(id, data)
+}
+
+/*
+encodeString returns the BER encoding of a primitive element bearing the
+input identifier octet and string contents.
+*/
+func encodeString(id byte, s string) []byte {
+	return encode(id, []byte(s))
+}
+
+/*
+encodeInt returns the BER encoding of an INTEGER (or ENUMERATED) element
+bearing the input identifier octet and value, using the minimum number
+of contents octets.
+*/
+func encodeInt(id byte, v int64) []byte {
+	var data []byte
+	for {
+		data = append([]byte{byte(v)}, data...)
+		if (v < 0x80 && v >= -0x80) || len(data) == 8 {
+			break
+		}
+		v >>= 8
+	}
+
+	return encode(id, data)
+}
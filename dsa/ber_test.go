@@ -0,0 +1,84 @@
+package dsa
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+)
+
+/*
+TestReadElement verifies that well-formed elements are decoded, and that
+malformed or oversized elements are refused.
+*/
+func TestReadElement(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		in   []byte
+		ok   bool
+	}{
+		{`short form`, []byte{tagOctetString, 0x02, 'h', 'i'}, true},
+		{`long form`, encodeString(tagOctetString, string(make([]byte, 300))), true},
+		{`multi-octet identifier`, []byte{0x1f, 0x01, 0x00}, false},
+		{`indefinite length`, []byte{tagSequence, 0x80, 0x00, 0x00}, false},
+		{`length of length too large`, []byte{tagSequence, 0x85, 0x00, 0x00, 0x00, 0x00, 0x01}, false},
+		{`oversized`, []byte{tagSequence, 0x84, 0x7f, 0xff, 0xff, 0xff}, false},
+		{`truncated contents`, []byte{tagOctetString, 0x05, 'h', 'i'}, false},
+		{`truncated length`, []byte{tagOctetString, 0x82, 0x01}, false},
+		{`empty`, nil, false},
+	} {
+		e, err := readElement(bufio.NewReader(bytes.NewReader(tc.in)))
+		if ok := err == nil; ok != tc.ok {
+			t.Errorf("%s: expected ok=%t, got err=%v", tc.name, tc.ok, err)
+		} else if ok && !bytes.Equal(encode(e.id, e.data), tc.in) {
+			t.Errorf("%s: round trip mismatch", tc.name)
+		}
+	}
+}
+
+/*
+TestChildren verifies the decoding of constructed elements, including the
+refusal of truncated or primitive elements.
+*/
+func TestChildren(t *testing.T) {
+	seq := encodeSeq(tagSequence, encodeInt(tagInteger, 300), encodeString(tagOctetString, `x`))
+	e, err := readElement(bufio.NewReader(bytes.NewReader(seq)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kids, err := e.children()
+	if err != nil || len(kids) != 2 {
+		t.Fatalf("expected 2 children, got %d (%v)", len(kids), err)
+	} else if v, err := kids[0].integer(); err != nil || v != 300 {
+		t.Errorf("expected 300, got %d (%v)", v, err)
+	} else if kids[1].str() != `x` {
+		t.Errorf("expected 'x', got '%s'", kids[1].str())
+	}
+
+	for _, bad := range []element{
+		{id: tagOctetString, data: []byte{0x01}},                // primitive
+		{id: tagSequence, data: []byte{tagInteger}},             // truncated header
+		{id: tagSequence, data: []byte{tagInteger, 0x05, 0x01}}, // truncated contents
+		{id: tagSequence, data: []byte{tagInteger, 0x85, 0x01}}, // bad length
+	} {
+		if _, err = bad.children(); err == nil {
+			t.Errorf("expected error for % x", bad.data)
+		}
+	}
+}
+
+/*
+TestEncodeInt verifies the minimal encoding of INTEGER values.
+*/
+func TestEncodeInt(t *testing.T) {
+	for _, v := range []int64{0, 1, 127, 128, -1, -128, -129, 65535, 1 << 40} {
+		e := element{id: tagInteger, data: encodeInt(tagInteger, v)[2:]}
+		if got, err := e.integer(); err != nil || got != v {
+			t.Errorf("expected %d, got %d (%v)", v, got, err)
+		}
+	}
+
+	if got := encodeInt(tagInteger, 128); !bytes.Equal(got, []byte{tagInteger, 0x02, 0x00, 0x80}) {
+		t.Errorf("unexpected encoding of 128: % x", got)
+	}
+}
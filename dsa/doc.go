@@ -0,0 +1,42 @@
+/*
+Package dsa implements a minimal LDAPv3 (RFC 4511) server, or Directory
+System Agent, that serves dcxl data from any dcxl.Directory implementation.
+
+It is intended as a lightweight stand-in for a full-featured DSA, such as
+OpenLDAP, for use within integration tests and demonstrations. It is not
+intended for production use.
+
+# Features
+
+• Anonymous and simple bind operations
+
+• Search operations with all three (3) scopes and all RFC 4515 filter types except extensible matches
+
+• Add, Modify, Delete and Compare operations
+
+• A Root DSE publishing the x660DUAConfig attribute types of the backing directory, allowing DUAs to exercise auto-configuration (s. 3.5.1 of the ID) against a local endpoint
+
+# Limitations
+
+Neither TLS (including StartTLS), SASL, controls, ModifyDN nor abandonment
+of in-flight operations are supported. Schema is not enforced.
+
+# Example
+
+	dua := &dcxl.DUAConfig{
+		DirectoryModel: dcxl.ThreeDimensional,
+		Registrations:  []string{`ou=Registrations,o=rA`},
+	}
+
+	srv, err := dsa.NewMemoryServer(dua, registrations, nil)
+	if err != nil {
+		// handle error
+	}
+
+	l, _ := net.Listen(`tcp`, `127.0.0.1:0`)
+	go srv.Serve(l)
+	defer srv.Close()
+
+	// point your DUA at l.Addr() ...
+*/
+package dsa
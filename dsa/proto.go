@@ -0,0 +1,660 @@
+package dsa
+
+/*
+proto.go contains the LDAPv3 protocol operation handlers, per section 4
+of RFC 4511.
+*/
+
+import (
+	"errors"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+/*
+Protocol operation tag numbers, per section 4.2 through 4.12 of RFC 4511.
+*/
+const (
+	opBindRequest      byte = 0
+	opBindResponse     byte = 1
+	opUnbindRequest    byte = 2
+	opSearchRequest    byte = 3
+	opSearchResultItem byte = 4
+	opSearchResultDone byte = 5
+	opModifyRequest    byte = 6
+	opModifyResponse   byte = 7
+	opAddRequest       byte = 8
+	opAddResponse      byte = 9
+	opDelRequest       byte = 10
+	opDelResponse      byte = 11
+	opModDNRequest     byte = 12
+	opModDNResponse    byte = 13
+	opCompareRequest   byte = 14
+	opCompareResponse  byte = 15
+	opAbandonRequest   byte = 16
+	opExtendedRequest  byte = 23
+	opExtendedResponse byte = 24
+)
+
+/*
+Result codes, per Appendix A of RFC 4511.
+*/
+const (
+	resultSuccess                 int64 = 0
+	resultOperationsError         int64 = 1
+	resultProtocolError           int64 = 2
+	resultSizeLimitExceeded       int64 = 4
+	resultCompareFalse            int64 = 5
+	resultCompareTrue             int64 = 6
+	resultAuthMethodNotSupported  int64 = 7
+	resultNoSuchAttribute         int64 = 16
+	resultAttributeOrValueExists  int64 = 20
+	resultNoSuchObject            int64 = 32
+	resultInvalidDNSyntax         int64 = 34
+	resultInvalidCredentials      int64 = 49
+	resultInsufficientAccessRight int64 = 50
+	resultUnwillingToPerform      int64 = 53
+	resultNotAllowedOnNonLeaf     int64 = 66
+	resultEntryAlreadyExists      int64 = 68
+	resultOther                   int64 = 80
+)
+
+/*
+noticeOfDisconnectionOID is the responseName of the unsolicited
+notification described in s. 4.4.1 of RFC 4511.
+*/
+const noticeOfDisconnectionOID = `1.3.6.1.4.1.1466.20036`
+
+/*
+parseMessage returns the messageID and protocolOp of the input LDAPMessage
+element. Controls, if present, are ignored.
+*/
+func parseMessage(e element) (id int64, op element, err error) {
+	if e.id != tagSequence {
+		err = errorf("LDAPMessage: unexpected identifier 0x%02x", e.id)
+		return
+	}
+
+	var kids []element
+	if kids, err = e.children(); err != nil {
+		return
+	} else if len(kids) < 2 || kids[0].id != tagInteger {
+		err = errorf("LDAPMessage: malformed")
+		return
+	}
+
+	if id, err = kids[0].integer(); err == nil && id < 0 {
+		err = errorf("LDAPMessage: negative messageID")
+	}
+	op = kids[1]
+
+	return
+}
+
+/*
+message returns an encoded LDAPMessage bearing the input messageID and
+encoded protocolOp.
+*/
+func message(id int64, op []byte) []byte {
+	return encodeSeq(tagSequence, encodeInt(tagInteger, id), op)
+}
+
+/*
+result returns an encoded LDAPResult bearing the input application tag
+number, result code and diagnostic message.
+*/
+func result(op byte, code int64, diag string, extra ...[]byte) []byte {
+	parts := [][]byte{
+		encodeInt(tagEnumerated, code),
+		encodeString(tagOctetString, ``),
+		encodeString(tagOctetString, diag),
+	}
+
+	return encodeSeq(classApplication|constructed|op, append(parts, extra...)...)
+}
+
+func noticeOfDisconnection(code int64, diag string) []byte {
+	return message(0, result(opExtendedResponse, code, diag,
+		encodeString(classContext|10, noticeOfDisconnectionOID)))
+}
+
+/*
+resultOf returns the result code and diagnostic message appropriate for
+the input error.
+*/
+func resultOf(err error) (int64, string) {
+	switch {
+	case err == nil:
+		return resultSuccess, ``
+	case errors.Is(err, dcxl.NoSuchObjectErr):
+		return resultNoSuchObject, err.Error()
+	case errors.Is(err, dcxl.EntryExistsErr):
+		return resultEntryAlreadyExists, err.Error()
+	case errors.Is(err, dcxl.NotAllowedOnNonLeafErr):
+		return resultNotAllowedOnNonLeaf, err.Error()
+	case errors.Is(err, dcxl.NoSuchAttributeErr):
+		return resultNoSuchAttribute, err.Error()
+	case errors.Is(err, dcxl.AttributeOrValueExistsErr):
+		return resultAttributeOrValueExists, err.Error()
+	case errors.Is(err, dcxl.SizeLimitExceededErr):
+		return resultSizeLimitExceeded, err.Error()
+	case errors.Is(err, dcxl.InvalidDNErr):
+		return resultInvalidDNSyntax, err.Error()
+	}
+
+	return resultOther, err.Error()
+}
+
+/*
+handle processes the input protocolOp element, returning zero or more
+encoded response protocolOps, and a boolean value indicative of whether
+the connection should be closed. A non-nil error indicates the client
+has sent something that cannot be processed, and the connection should
+be closed following a Notice of Disconnection.
+*/
+func (r *Server) handle(s *session, op element) (resps [][]byte, quit bool, err error) {
+	tag := op.id &^ (classApplication | constructed)
+	if op.id&classApplication == 0 || op.id&classContext != 0 {
+		tag = 0xff
+	}
+
+	switch tag {
+	case opBindRequest:
+		resps = append(resps, r.bind(s, op))
+	case opUnbindRequest:
+		quit = true
+	case opSearchRequest:
+		resps = r.search(op)
+	case opModifyRequest:
+		resps = append(resps, r.write(s, opModifyResponse, op, r.modify))
+	case opAddRequest:
+		resps = append(resps, r.write(s, opAddResponse, op, r.add))
+	case opDelRequest:
+		resps = append(resps, r.write(s, opDelResponse, op, r.del))
+	case opModDNRequest:
+		resps = append(resps, result(opModDNResponse, resultUnwillingToPerform, `ModifyDN is not supported`))
+	case opCompareRequest:
+		resps = append(resps, r.compare(op))
+	case opAbandonRequest:
+		// Operations are processed serially, so there
+		// is never anything in-flight to abandon.
+	case opExtendedRequest:
+		resps = append(resps, result(opExtendedResponse, resultProtocolError, `Extended operations are not supported`))
+	default:
+		err = errorf("Unknown protocolOp 0x%02x", op.id)
+	}
+
+	return
+}
+
+/*
+bind processes a BindRequest, per section 4.2 of RFC 4511.
+*/
+func (r *Server) bind(s *session, op element) []byte {
+	kids, err := op.children()
+	if err != nil || len(kids) != 3 {
+		return result(opBindResponse, resultProtocolError, `Malformed BindRequest`)
+	}
+
+	if v, _ := kids[0].integer(); v != 3 {
+		return result(opBindResponse, resultProtocolError, `Only LDAPv3 is supported`)
+	} else if kids[2].id != classContext {
+		return result(opBindResponse, resultAuthMethodNotSupported, `Only simple authentication is supported`)
+	}
+
+	s.bindDN = ``
+	name, pass := kids[1].str(), kids[2].str()
+	switch {
+	case len(name) == 0 && len(pass) == 0:
+		return result(opBindResponse, resultSuccess, ``)
+	case len(pass) == 0:
+		// Unauthenticated binds, per s. 5.1.2 of RFC 4513.
+		return result(opBindResponse, resultUnwillingToPerform, `Unauthenticated binds are not supported`)
+	case !r.authenticate(name, pass):
+		return result(opBindResponse, resultInvalidCredentials, ``)
+	}
+
+	s.bindDN = name
+	return result(opBindResponse, resultSuccess, ``)
+}
+
+/*
+authenticate returns a boolean value indicative of whether the input
+DN and password are valid.
+*/
+func (r *Server) authenticate(name, pass string) bool {
+	for dn, pw := range r.Credentials {
+		if eq(dn, name) && pw == pass {
+			return true
+		}
+	}
+
+	if r.Directory == nil {
+		return false
+	}
+
+	attrs, err := r.Directory.Get(name)
+	if err != nil {
+		return false
+	}
+
+	for k, v := range attrs {
+		if !eq(k, `userPassword`) {
+			continue
+		}
+		for i := 0; i < len(v); i++ {
+			if v[i] == pass {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/*
+search processes a SearchRequest, per section 4.5 of RFC 4511. A base
+object search of the zero-length DN returns the Root DSE.
+*/
+func (r *Server) search(op element) (resps [][]byte) {
+	kids, err := op.children()
+	if err != nil || len(kids) != 8 {
+		return [][]byte{result(opSearchResultDone, resultProtocolError, `Malformed SearchRequest`)}
+	}
+
+	scope, _ := kids[1].integer()
+	size, _ := kids[3].integer()
+	if scope < 0 || scope > 2 {
+		return [][]byte{result(opSearchResultDone, resultProtocolError, `Bad scope`)}
+	}
+
+	req := dcxl.SearchRequest{
+		BaseDN:    kids[0].str(),
+		Scope:     dcxl.Scope(scope),
+		SizeLimit: int(size),
+	}
+	typesOnly := kids[5].boolean()
+
+	if req.Filter, err = decodeFilter(kids[6]); err != nil {
+		return [][]byte{result(opSearchResultDone, resultProtocolError, err.Error())}
+	}
+
+	var attrs []element
+	if attrs, err = kids[7].children(); err != nil {
+		return [][]byte{result(opSearchResultDone, resultProtocolError, err.Error())}
+	}
+	for i := 0; i < len(attrs); i++ {
+		req.Attributes = append(req.Attributes, attrs[i].str())
+	}
+
+	var entries []dcxl.Entry
+	if len(req.BaseDN) == 0 && req.Scope == dcxl.BaseObject {
+		entries, err = r.rootDSE(req)
+	} else if r.Directory == nil {
+		err = dcxl.NoSuchObjectErr
+	} else {
+		entries, err = r.Directory.Search(req)
+	}
+
+	for i := 0; i < len(entries); i++ {
+		resps = append(resps, searchResultEntry(entries[i], typesOnly))
+	}
+
+	code, diag := resultOf(err)
+	resps = append(resps, result(opSearchResultDone, code, diag))
+
+	return
+}
+
+/*
+rootDSE returns the Root DSE as a single Entry, provided it satisfies the
+Filter of the input request. The supportedLDAPVersion attribute type is
+added if not already present.
+*/
+func (r *Server) rootDSE(req dcxl.SearchRequest) (entries []dcxl.Entry, err error) {
+	dse := map[string][]string{`objectClass`: {`top`}}
+	if r.Directory != nil {
+		if dse, err = r.Directory.RootDSE(); err != nil {
+			return
+		}
+	}
+
+	var found bool
+	for k := range dse {
+		found = found || eq(k, `supportedLDAPVersion`)
+	}
+	if !found {
+		dse[`supportedLDAPVersion`] = []string{`3`}
+	}
+
+	if ok, _ := dcxl.MatchFilter(req.Filter, dse); !ok {
+		return
+	}
+
+	// All Root DSE attribute types are regarded as
+	// operational, but are returned without being
+	// explicitly requested for convenience.
+	sel := dse
+	if len(req.Attributes) > 0 && !inSlice(`*`, req.Attributes) && !inSlice(`+`, req.Attributes) {
+		sel = make(map[string][]string)
+		for k, v := range dse {
+			if inSlice(k, req.Attributes) {
+				sel[k] = v
+			}
+		}
+	}
+
+	entries = append(entries, dcxl.Entry{Attributes: sel})
+
+	return
+}
+
+func searchResultEntry(e dcxl.Entry, typesOnly bool) []byte {
+	var attrs [][]byte
+	for k, v := range e.Attributes {
+		var vals [][]byte
+		for i := 0; i < len(v) && !typesOnly; i++ {
+			vals = append(vals, encodeString(tagOctetString, v[i]))
+		}
+		attrs = append(attrs, encodeSeq(tagSequence,
+			encodeString(tagOctetString, k),
+			encodeSeq(tagSet, vals...)))
+	}
+
+	return encodeSeq(classApplication|constructed|opSearchResultItem,
+		encodeString(tagOctetString, e.DN),
+		encodeSeq(tagSequence, attrs...))
+}
+
+/*
+write guards the input update handler against anonymous sessions, and
+returns its result using the input response tag number.
+*/
+func (r *Server) write(s *session, resp byte, op element, fn func(element) error) []byte {
+	if len(s.bindDN) == 0 && !r.AllowAnonymousWrite {
+		return result(resp, resultInsufficientAccessRight, `Anonymous updates are not permitted`)
+	} else if r.Directory == nil {
+		return result(resp, resultUnwillingToPerform, `No directory configured`)
+	}
+
+	err := fn(op)
+	var perr protocolErr
+	if errors.As(err, &perr) {
+		return result(resp, resultProtocolError, perr.Error())
+	}
+
+	code, diag := resultOf(err)
+	return result(resp, code, diag)
+}
+
+/*
+protocolErr describes a malformed request.
+*/
+type protocolErr struct {
+	msg string
+}
+
+func (r protocolErr) Error() string {
+	return r.msg
+}
+
+/*
+add processes an AddRequest, per section 4.7 of RFC 4511.
+*/
+func (r *Server) add(op element) error {
+	kids, err := op.children()
+	if err != nil || len(kids) != 2 {
+		return protocolErr{`Malformed AddRequest`}
+	}
+
+	attrs, err := decodeAttributes(kids[1])
+	if err != nil {
+		return protocolErr{err.Error()}
+	}
+
+	return r.Directory.Add(kids[0].str(), attrs)
+}
+
+/*
+modify processes a ModifyRequest, per section 4.6 of RFC 4511.
+*/
+func (r *Server) modify(op element) error {
+	kids, err := op.children()
+	if err != nil || len(kids) != 2 {
+		return protocolErr{`Malformed ModifyRequest`}
+	}
+
+	changes, err := kids[1].children()
+	if err != nil {
+		return protocolErr{err.Error()}
+	}
+
+	var mods []dcxl.Modification
+	for i := 0; i < len(changes); i++ {
+		parts, err := changes[i].children()
+		if err != nil || len(parts) != 2 {
+			return protocolErr{`Malformed change`}
+		}
+
+		opn, _ := parts[0].integer()
+		if opn < 0 || opn > 2 {
+			return protocolErr{sprintf("Unknown modify operation %d", opn)}
+		}
+
+		attr, err := decodeAttributes(encodedSeq(parts[1]))
+		if err != nil || len(attr) != 1 {
+			return protocolErr{`Malformed modification`}
+		}
+
+		for k, v := range attr {
+			mods = append(mods, dcxl.Modification{
+				Op:     dcxl.ModifyOp(opn),
+				Attr:   k,
+				Values: v,
+			})
+		}
+	}
+
+	return r.Directory.Modify(kids[0].str(), mods...)
+}
+
+/*
+del processes a DelRequest, per section 4.8 of RFC 4511.
+*/
+func (r *Server) del(op element) error {
+	if op.id&constructed != 0 {
+		return protocolErr{`Malformed DelRequest`}
+	}
+
+	return r.Directory.Delete(op.str())
+}
+
+/*
+compare processes a CompareRequest, per section 4.10 of RFC 4511.
+*/
+func (r *Server) compare(op element) []byte {
+	kids, err := op.children()
+	if err != nil || len(kids) != 2 {
+		return result(opCompareResponse, resultProtocolError, `Malformed CompareRequest`)
+	}
+
+	ava, err := kids[1].children()
+	if err != nil || len(ava) != 2 {
+		return result(opCompareResponse, resultProtocolError, `Malformed AttributeValueAssertion`)
+	} else if r.Directory == nil {
+		return result(opCompareResponse, resultNoSuchObject, ``)
+	}
+
+	attrs, err := r.Directory.Get(kids[0].str())
+	if err != nil {
+		code, diag := resultOf(err)
+		return result(opCompareResponse, code, diag)
+	}
+
+	at := ava[0].str()
+	if ok, _ := dcxl.MatchFilter(dcxl.Present(at), attrs); !ok {
+		return result(opCompareResponse, resultNoSuchAttribute, ``)
+	} else if ok, _ = dcxl.MatchFilter(dcxl.Equal(at, ava[1].str()), attrs); ok {
+		return result(opCompareResponse, resultCompareTrue, ``)
+	}
+
+	return result(opCompareResponse, resultCompareFalse, ``)
+}
+
+/*
+decodeAttributes decodes an AttributeList (or the SEQUENCE OF PartialAttribute
+within a ModifyRequest), per section 4.1.7 of RFC 4511.
+*/
+func decodeAttributes(e element) (attrs map[string][]string, err error) {
+	var list []element
+	if list, err = e.children(); err != nil {
+		return
+	}
+
+	attrs = make(map[string][]string)
+	for i := 0; i < len(list); i++ {
+		var parts, vals []element
+		if parts, err = list[i].children(); err != nil {
+			return
+		} else if len(parts) != 2 {
+			err = errorf("Malformed attribute")
+			return
+		} else if vals, err = parts[1].children(); err != nil {
+			return
+		}
+
+		at := parts[0].str()
+		attrs[at] = []string{}
+		for j := 0; j < len(vals); j++ {
+			attrs[at] = append(attrs[at], vals[j].str())
+		}
+	}
+
+	return
+}
+
+/*
+encodedSeq wraps the input element within a constructed SEQUENCE
+element, such that a lone PartialAttribute may be processed using
+the decodeAttributes function.
+*/
+func encodedSeq(e element) element {
+	return element{id: tagSequence, data: encode(e.id, e.data)}
+}
+
+/*
+decodeFilter decodes the input Filter element into a dcxl.Filter, per
+section 4.5.1.7 of RFC 4511.
+*/
+func decodeFilter(e element) (f dcxl.Filter, err error) {
+	switch e.id {
+	case classContext | constructed | 0, classContext | constructed | 1:
+		var kids []element
+		if kids, err = e.children(); err != nil {
+			return
+		}
+
+		var subs []dcxl.Filter = make([]dcxl.Filter, 0, len(kids))
+		for i := 0; i < len(kids); i++ {
+			var sub dcxl.Filter
+			if sub, err = decodeFilter(kids[i]); err != nil {
+				return
+			}
+			subs = append(subs, sub)
+		}
+
+		if e.id&0x1f == 0 {
+			f = dcxl.AndFilter(subs)
+		} else {
+			f = dcxl.OrFilter(subs)
+		}
+	case classContext | constructed | 2:
+		var kids []element
+		if kids, err = e.children(); err != nil {
+			return
+		} else if len(kids) != 1 {
+			err = errorf("Malformed 'not' filter")
+			return
+		}
+
+		var sub dcxl.Filter
+		if sub, err = decodeFilter(kids[0]); err == nil {
+			f = dcxl.NotFilter{Filter: sub}
+		}
+	case classContext | constructed | 3, classContext | constructed | 5,
+		classContext | constructed | 6, classContext | constructed | 8:
+		var kids []element
+		if kids, err = e.children(); err != nil {
+			return
+		} else if len(kids) != 2 {
+			err = errorf("Malformed AttributeValueAssertion")
+			return
+		}
+
+		at, val := kids[0].str(), kids[1].str()
+		switch e.id & 0x1f {
+		case 3:
+			f = dcxl.EqualityFilter{Attr: at, Value: val}
+		case 5:
+			f = dcxl.GreaterOrEqualFilter{Attr: at, Value: val}
+		case 6:
+			f = dcxl.LessOrEqualFilter{Attr: at, Value: val}
+		case 8:
+			f = dcxl.ApproxFilter{Attr: at, Value: val}
+		}
+	case classContext | constructed | 4:
+		f, err = decodeSubstrings(e)
+	case classContext | 7:
+		f = dcxl.PresentFilter{Attr: e.str()}
+	case classContext | constructed | 9:
+		err = errorf("Extensible match filters are not supported")
+	default:
+		err = errorf("Unknown filter type 0x%02x", e.id)
+	}
+
+	return
+}
+
+func decodeSubstrings(e element) (f dcxl.Filter, err error) {
+	var kids, subs []element
+	if kids, err = e.children(); err != nil {
+		return
+	} else if len(kids) != 2 {
+		err = errorf("Malformed 'substrings' filter")
+		return
+	} else if subs, err = kids[1].children(); err != nil {
+		return
+	}
+
+	sf := dcxl.SubstringsFilter{Attr: kids[0].str()}
+	for i := 0; i < len(subs); i++ {
+		switch subs[i].id {
+		case classContext | 0:
+			sf.Initial = subs[i].str()
+		case classContext | 1:
+			sf.Any = append(sf.Any, subs[i].str())
+		case classContext | 2:
+			sf.Final = subs[i].str()
+		default:
+			err = errorf("Unknown substring type 0x%02x", subs[i].id)
+			return
+		}
+	}
+	f = sf
+
+	return
+}
+
+/*
+inSlice returns a boolean value indicative of whether the input string
+is present within the input slice, ignoring case.
+*/
+func inSlice(s string, sl []string) bool {
+	for i := 0; i < len(sl); i++ {
+		if eq(s, sl[i]) {
+			return true
+		}
+	}
+
+	return false
+}
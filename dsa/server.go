@@ -0,0 +1,247 @@
+package dsa
+
+/*
+server.go contains the Server type and its connection lifecycle.
+*/
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+var (
+	errorf  func(string, ...any) error  = fmt.Errorf
+	sprintf func(string, ...any) string = fmt.Sprintf
+	eq      func(string, string) bool   = strings.EqualFold
+)
+
+/*
+ServerClosedErr is returned by the Serve and ListenAndServe methods
+following a call to the Close method.
+*/
+var ServerClosedErr error = errors.New("dsa: Server closed")
+
+/*
+Server is a minimal LDAPv3 server backed by a dcxl.Directory instance.
+
+The Credentials field, if non-nil, maps bind DNs to their plaintext
+passwords. Simple binds are also honored against the (plaintext)
+userPassword values of any entry within the Directory. Bind DNs are
+compared in case-insensitive fashion.
+
+Add, Modify and Delete operations are refused for anonymous sessions
+unless the AllowAnonymousWrite field is true.
+
+The zero value is not usable; see the NewServer and NewMemoryServer
+functions.
+*/
+type Server struct {
+	Directory           dcxl.Directory
+	Credentials         map[string]string
+	AllowAnonymousWrite bool
+
+	mu     sync.Mutex
+	lns    map[net.Listener]struct{}
+	conns  map[net.Conn]struct{}
+	wg     sync.WaitGroup
+	closed bool
+}
+
+/*
+NewServer returns a freshly initialized instance of *Server backed by
+the input dcxl.Directory instance.
+*/
+func NewServer(dir dcxl.Directory) *Server {
+	return &Server{
+		Directory: dir,
+		lns:       make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+/*
+NewMemoryServer returns a freshly initialized instance of *Server backed
+by a *dcxl.MemoryDirectory, alongside an error. The directory is populated
+using the input Registrations and Registrants, and publishes the input
+*dcxl.DUAConfig by way of its Root DSE.
+*/
+func NewMemoryServer(d *dcxl.DUAConfig, regs dcxl.Registrations, rants dcxl.Registrants) (*Server, error) {
+	dir := dcxl.NewMemoryDirectory(d)
+	if err := dir.AddRegistrations(regs); err != nil {
+		return nil, err
+	} else if err = dir.AddRegistrants(rants); err != nil {
+		return nil, err
+	}
+
+	return NewServer(dir), nil
+}
+
+/*
+ListenAndServe listens upon the input TCP network address and then
+calls the Serve method.
+*/
+func (r *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen(`tcp`, addr)
+	if err != nil {
+		return err
+	}
+
+	return r.Serve(l)
+}
+
+/*
+Serve accepts incoming connections upon the input net.Listener, handling
+each within a dedicated goroutine. Serve always returns a non-nil error,
+which shall be ServerClosedErr following a call to the Close method. The
+listener is closed upon return.
+*/
+func (r *Server) Serve(l net.Listener) error {
+	if !r.track(l, true) {
+		l.Close()
+		return ServerClosedErr
+	}
+	defer r.track(l, false)
+	defer l.Close()
+
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			if r.isClosed() {
+				err = ServerClosedErr
+			}
+			return err
+		}
+
+		if !r.trackConn(c, true) {
+			c.Close()
+			return ServerClosedErr
+		}
+
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			defer r.trackConn(c, false)
+			defer c.Close()
+			r.serveConn(c)
+		}()
+	}
+}
+
+/*
+Close closes all listeners and connections, and waits for any in-flight
+connection handlers to return.
+*/
+func (r *Server) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	for l := range r.lns {
+		l.Close()
+	}
+	for c := range r.conns {
+		c.Close()
+	}
+	r.mu.Unlock()
+
+	r.wg.Wait()
+
+	return nil
+}
+
+func (r *Server) isClosed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.closed
+}
+
+func (r *Server) track(l net.Listener, add bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.lns == nil {
+		r.lns = make(map[net.Listener]struct{})
+	}
+
+	if !add {
+		delete(r.lns, l)
+	} else if r.closed {
+		return false
+	} else {
+		r.lns[l] = struct{}{}
+	}
+
+	return true
+}
+
+func (r *Server) trackConn(c net.Conn, add bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.conns == nil {
+		r.conns = make(map[net.Conn]struct{})
+	}
+
+	if !add {
+		delete(r.conns, c)
+	} else if r.closed {
+		return false
+	} else {
+		r.conns[c] = struct{}{}
+	}
+
+	return true
+}
+
+/*
+session contains the state of a single client connection.
+*/
+type session struct {
+	bindDN string // zero when anonymous
+}
+
+/*
+serveConn reads and processes LDAPMessage instances from the input
+connection until the client unbinds, disconnects or misbehaves.
+*/
+func (r *Server) serveConn(c net.Conn) {
+	rd := bufio.NewReader(c)
+	wr := bufio.NewWriter(c)
+	var s session
+
+	for {
+		e, err := readElement(rd)
+		if err != nil {
+			return
+		}
+
+		var id int64
+		var op element
+		var resps [][]byte
+		var quit bool
+		if id, op, err = parseMessage(e); err == nil {
+			resps, quit, err = r.handle(&s, op)
+		}
+
+		if err != nil {
+			// Per s. 4.4.1 of RFC 4511, emit a
+			// Notice of Disconnection and hang up.
+			wr.Write(noticeOfDisconnection(resultProtocolError, err.Error()))
+			wr.Flush()
+			return
+		}
+
+		for i := 0; i < len(resps); i++ {
+			wr.Write(message(id, resps[i]))
+		}
+
+		if err = wr.Flush(); err != nil || quit {
+			return
+		}
+	}
+}
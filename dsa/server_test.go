@@ -0,0 +1,362 @@
+package dsa
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/JesseCoretta/go-dcxl"
+)
+
+/*
+testClient is a minimal LDAP client connected to a *Server by way of
+net.Pipe.
+*/
+type testClient struct {
+	t  *testing.T
+	c  net.Conn
+	rd *bufio.Reader
+	id int64
+}
+
+/*
+newTestServer returns a *Server backed by a ThreeDimensional directory
+bearing a small registration hierarchy.
+*/
+func newTestServer(t *testing.T) *Server {
+	dua := &dcxl.DUAConfig{
+		DirectoryModel: dcxl.ThreeDimensional,
+		Registrations:  []string{`ou=Registrations,o=rA`},
+	}
+
+	srv, err := NewMemoryServer(dua, dcxl.Registrations{
+		&dcxl.RootArc{R_DN: `n=1,ou=Registrations,o=rA`, R_N: `1`, R_Id: `iso`},
+		&dcxl.SubArc{R_DN: `n=3,n=1,ou=Registrations,o=rA`, R_N: `3`, R_DotNot: `1.3`, R_Id: `identified-organization`},
+		&dcxl.SubArc{R_DN: `n=6,n=3,n=1,ou=Registrations,o=rA`, R_N: `6`, R_DotNot: `1.3.6`, R_Id: `dod`},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Credentials = map[string]string{`cn=admin,o=rA`: `secret`}
+
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *Server) *testClient {
+	c, s := net.Pipe()
+	go func() {
+		srv.serveConn(s)
+		s.Close()
+	}()
+	t.Cleanup(func() { c.Close() })
+
+	return &testClient{t: t, c: c, rd: bufio.NewReader(c)}
+}
+
+/*
+do sends the input encoded protocolOp, and returns the response protocolOps
+up to and including the first that is not a SearchResultEntry.
+*/
+func (r *testClient) do(op []byte) (resps []element) {
+	r.t.Helper()
+	r.id++
+	if _, err := r.c.Write(message(r.id, op)); err != nil {
+		r.t.Fatal(err)
+	}
+
+	for {
+		e, err := readElement(r.rd)
+		if err != nil {
+			r.t.Fatal(err)
+		}
+
+		id, resp, err := parseMessage(e)
+		if err != nil {
+			r.t.Fatal(err)
+		} else if id != r.id {
+			r.t.Fatalf("expected messageID %d, got %d", r.id, id)
+		}
+
+		resps = append(resps, resp)
+		if resp.id != classApplication|constructed|opSearchResultItem {
+			return
+		}
+	}
+}
+
+/*
+code returns the resultCode of the input LDAPResult protocolOp.
+*/
+func code(t *testing.T, op element) int64 {
+	t.Helper()
+	kids, err := op.children()
+	if err != nil || len(kids) < 3 {
+		t.Fatalf("malformed LDAPResult: %v", err)
+	}
+
+	c, err := kids[0].integer()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return c
+}
+
+/*
+entryAttrs returns the attribute types and values of the input
+SearchResultEntry protocolOp.
+*/
+func entryAttrs(t *testing.T, op element) map[string][]string {
+	t.Helper()
+	kids, err := op.children()
+	if err != nil || len(kids) != 2 {
+		t.Fatalf("malformed SearchResultEntry: %v", err)
+	}
+
+	attrs, err := decodeAttributes(kids[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return attrs
+}
+
+func bindReq(dn, pw string) []byte {
+	return encodeSeq(classApplication|constructed|opBindRequest,
+		encodeInt(tagInteger, 3),
+		encodeString(tagOctetString, dn),
+		encodeString(classContext, pw))
+}
+
+func searchReq(base string, scope, size int64) []byte {
+	return encodeSeq(classApplication|constructed|opSearchRequest,
+		encodeString(tagOctetString, base),
+		encodeInt(tagEnumerated, scope),
+		encodeInt(tagEnumerated, 0),
+		encodeInt(tagInteger, size),
+		encodeInt(tagInteger, 0),
+		encode(tagBoolean, []byte{0}),
+		encodeString(classContext|7, `objectClass`),
+		encodeSeq(tagSequence))
+}
+
+func attrSeq(at string, vals ...string) []byte {
+	var enc [][]byte
+	for _, v := range vals {
+		enc = append(enc, encodeString(tagOctetString, v))
+	}
+
+	return encodeSeq(tagSequence, encodeString(tagOctetString, at), encodeSeq(tagSet, enc...))
+}
+
+func addReq(dn string, attrs ...[]byte) []byte {
+	return encodeSeq(classApplication|constructed|opAddRequest,
+		encodeString(tagOctetString, dn),
+		encodeSeq(tagSequence, attrs...))
+}
+
+func modifyReq(dn string, op int64, attr []byte) []byte {
+	return encodeSeq(classApplication|constructed|opModifyRequest,
+		encodeString(tagOctetString, dn),
+		encodeSeq(tagSequence, encodeSeq(tagSequence, encodeInt(tagEnumerated, op), attr)))
+}
+
+func delReq(dn string) []byte {
+	return encodeString(classApplication|opDelRequest, dn)
+}
+
+func compareReq(dn, at, val string) []byte {
+	return encodeSeq(classApplication|constructed|opCompareRequest,
+		encodeString(tagOctetString, dn),
+		encodeSeq(tagSequence, encodeString(tagOctetString, at), encodeString(tagOctetString, val)))
+}
+
+/*
+TestServer verifies the result codes of each supported operation.
+*/
+func TestServer(t *testing.T) {
+	srv := newTestServer(t)
+	c := newTestClient(t, srv)
+
+	newArc := addReq(`n=2,n=3,n=1,ou=Registrations,o=rA`,
+		attrSeq(`objectClass`, `top`, `x660SubArc`),
+		attrSeq(`n`, `2`),
+		attrSeq(`dotNotation`, `1.3.2`))
+
+	for _, tc := range []struct {
+		name string
+		op   []byte
+		want int64
+	}{
+		{`anonymous bind`, bindReq(``, ``), resultSuccess},
+		{`anonymous add`, newArc, resultInsufficientAccessRight},
+		{`unauthenticated bind`, bindReq(`cn=admin,o=rA`, ``), resultUnwillingToPerform},
+		{`bad credentials`, bindReq(`cn=admin,o=rA`, `wrong`), resultInvalidCredentials},
+		{`good credentials`, bindReq(`CN=Admin,o=rA`, `secret`), resultSuccess},
+		{`add`, newArc, resultSuccess},
+		{`add existing`, newArc, resultEntryAlreadyExists},
+		{`add orphan`, addReq(`n=9,n=9,ou=Registrations,o=rA`, attrSeq(`objectClass`, `top`), attrSeq(`n`, `9`)), resultNoSuchObject},
+		{`modify add`, modifyReq(`n=2,n=3,n=1,ou=Registrations,o=rA`, 0, attrSeq(`identifier`, `two`)), resultSuccess},
+		{`modify add existing`, modifyReq(`n=2,n=3,n=1,ou=Registrations,o=rA`, 0, attrSeq(`identifier`, `TWO`)), resultAttributeOrValueExists},
+		{`modify delete absent`, modifyReq(`n=2,n=3,n=1,ou=Registrations,o=rA`, 1, attrSeq(`unicodeValue`)), resultNoSuchAttribute},
+		{`modify bad operation`, modifyReq(`n=2,n=3,n=1,ou=Registrations,o=rA`, 7, attrSeq(`n`, `2`)), resultProtocolError},
+		{`compare true`, compareReq(`n=6,n=3,n=1,ou=Registrations,o=rA`, `identifier`, `dod`), resultCompareTrue},
+		{`compare false`, compareReq(`n=6,n=3,n=1,ou=Registrations,o=rA`, `identifier`, `iso`), resultCompareFalse},
+		{`compare absent`, compareReq(`n=6,n=3,n=1,ou=Registrations,o=rA`, `unicodeValue`, `x`), resultNoSuchAttribute},
+		{`compare no such object`, compareReq(`n=7,n=3,n=1,ou=Registrations,o=rA`, `n`, `7`), resultNoSuchObject},
+		{`delete non-leaf`, delReq(`n=1,ou=Registrations,o=rA`), resultNotAllowedOnNonLeaf},
+		{`delete leaf`, delReq(`n=2,n=3,n=1,ou=Registrations,o=rA`), resultSuccess},
+		{`delete no such object`, delReq(`n=2,n=3,n=1,ou=Registrations,o=rA`), resultNoSuchObject},
+		{`modify DN`, encodeSeq(classApplication | constructed | opModDNRequest), resultUnwillingToPerform},
+	} {
+		resps := c.do(tc.op)
+		if got := code(t, resps[len(resps)-1]); got != tc.want {
+			t.Errorf("%s: expected result code %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+/*
+TestServer_search verifies search scopes and the enforcement of the size
+limit.
+*/
+func TestServer_search(t *testing.T) {
+	c := newTestClient(t, newTestServer(t))
+
+	for _, tc := range []struct {
+		name    string
+		op      []byte
+		entries int
+		want    int64
+	}{
+		{`base`, searchReq(`n=3,n=1,ou=Registrations,o=rA`, 0, 0), 1, resultSuccess},
+		{`one`, searchReq(`n=1,ou=Registrations,o=rA`, 1, 0), 1, resultSuccess},
+		{`subtree`, searchReq(`ou=Registrations,o=rA`, 2, 0), 3, resultSuccess},
+		{`size limit`, searchReq(`ou=Registrations,o=rA`, 2, 2), 2, resultSizeLimitExceeded},
+		{`no such object`, searchReq(`n=9,ou=Registrations,o=rA`, 0, 0), 0, resultNoSuchObject},
+		{`bad scope`, searchReq(`ou=Registrations,o=rA`, 3, 0), 0, resultProtocolError},
+	} {
+		resps := c.do(tc.op)
+		if n := len(resps) - 1; n != tc.entries {
+			t.Errorf("%s: expected %d entries, got %d", tc.name, tc.entries, n)
+		}
+		if got := code(t, resps[len(resps)-1]); got != tc.want {
+			t.Errorf("%s: expected result code %d, got %d", tc.name, tc.want, got)
+		}
+	}
+}
+
+/*
+TestServer_rootDSE verifies that the Root DSE publishes the DUAConfig of
+the backing directory.
+*/
+func TestServer_rootDSE(t *testing.T) {
+	c := newTestClient(t, newTestServer(t))
+
+	resps := c.do(searchReq(``, 0, 0))
+	if len(resps) != 2 {
+		t.Fatalf("expected 1 entry, got %d", len(resps)-1)
+	} else if got := code(t, resps[1]); got != resultSuccess {
+		t.Fatalf("expected result code %d, got %d", resultSuccess, got)
+	}
+
+	attrs := entryAttrs(t, resps[0])
+	for at, want := range map[string]string{
+		`supportedLDAPVersion`: `3`,
+		`rARegistrationBase`:   `ou=Registrations,o=rA`,
+		`namingContexts`:       `ou=Registrations,o=rA`,
+	} {
+		if vals := attrs[at]; len(vals) != 1 || vals[0] != want {
+			t.Errorf("%s: expected [%s], got %v", at, want, vals)
+		}
+	}
+
+	d := new(dcxl.DUAConfig)
+	if err := d.Marshal(attrs); err != nil {
+		t.Fatal(err)
+	} else if d.DirectoryModel != dcxl.ThreeDimensional {
+		t.Errorf("expected %v, got %v", dcxl.ThreeDimensional, d.DirectoryModel)
+	}
+}
+
+/*
+TestServer_malformed verifies that malformed or oversized messages result
+in disconnection, preceded by a Notice of Disconnection where the message
+could be read.
+*/
+func TestServer_malformed(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, tc := range []struct {
+		name   string
+		in     []byte
+		notice bool
+	}{
+		{`oversized`, []byte{tagSequence, 0x84, 0x7f, 0xff, 0xff, 0xff}, false},
+		{`bad length`, []byte{tagSequence, 0x85, 0x00, 0x00, 0x00, 0x00, 0x01}, false},
+		{`not a sequence`, encodeString(tagOctetString, `hello`), true},
+		{`no protocolOp`, encodeSeq(tagSequence, encodeInt(tagInteger, 1)), true},
+		{`unknown protocolOp`, message(1, encodeSeq(classApplication|constructed|30)), true},
+		{`negative messageID`, message(-1, bindReq(``, ``)), true},
+	} {
+		c := newTestClient(t, srv)
+		go c.c.Write(tc.in)
+
+		e, err := readElement(c.rd)
+		if !tc.notice {
+			if err == nil {
+				t.Errorf("%s: expected disconnection, got element 0x%02x", tc.name, e.id)
+			}
+			continue
+		} else if err != nil {
+			t.Errorf("%s: expected Notice of Disconnection, got %v", tc.name, err)
+			continue
+		}
+
+		_, op, err := parseMessage(e)
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+		} else if op.id != classApplication|constructed|opExtendedResponse {
+			t.Errorf("%s: expected ExtendedResponse, got 0x%02x", tc.name, op.id)
+		} else if got := code(t, op); got != resultProtocolError {
+			t.Errorf("%s: expected result code %d, got %d", tc.name, resultProtocolError, got)
+		}
+
+		if _, err = readElement(c.rd); err == nil {
+			t.Errorf("%s: expected disconnection", tc.name)
+		}
+	}
+}
+
+/*
+TestServe verifies the lifecycle of a *Server listening upon the loopback
+interface.
+*/
+func TestServe(t *testing.T) {
+	l, err := net.Listen(`tcp`, `127.0.0.1:0`)
+	if err != nil {
+		t.Skipf("loopback unavailable: %v", err)
+	}
+
+	srv := newTestServer(t)
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(l) }()
+
+	conn, err := net.Dial(`tcp`, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	c := &testClient{t: t, c: conn, rd: bufio.NewReader(conn)}
+	if got := code(t, c.do(bindReq(`cn=admin,o=rA`, `secret`))[0]); got != resultSuccess {
+		t.Errorf("expected result code %d, got %d", resultSuccess, got)
+	}
+
+	srv.Close()
+	if err = <-done; err != ServerClosedErr {
+		t.Errorf("expected %v, got %v", ServerClosedErr, err)
+	} else if err = srv.Serve(l); err != ServerClosedErr {
+		t.Errorf("expected %v from closed server, got %v", ServerClosedErr, err)
+	}
+}
@@ -1,46 +1,43 @@
 package dcxl
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 /*
 err.go contains predefined error instances that
-describe certain known aberrant conditions.
+describe certain known aberrant conditions, as well
+as the structured ValidationError type that wraps
+them when reporting schema violations.
 */
 
 var (
-	RegistrationValidityErr,
-	UnsupportedInputTypeErr,
-	IllegalASN1NotationErr,
-	RegistrantValidityErr,
-	DUAConfigValidityErr,
-	IllegalNumberFormErr,
-	InvalidDimensionErr,
-	NilRegistrationErr,
-	IllegalLongArcErr,
-	MismatchedLeafErr,
-	NilRegistrantErr,
-	IllegalRootErr,
-	InvalidOIDErr,
-	InvalidDNErr error
+	RegistrationValidityErr   = errors.New("Registration instance did not pass validity checks")
+	UnresolvedReferenceErr    = errors.New("Unresolved ASN.1 value reference")
+	CircularReferenceErr      = errors.New("Circular ASN.1 value reference")
+	UnsupportedInputTypeErr   = errors.New("Unsupported input type")
+	NotAllowedOnNonLeafErr    = errors.New("Operation not allowed on non-leaf entry")
+	IllegalASN1NotationErr    = errors.New("ASN.1 Notation value is malformed or zero-length")
+	SizeLimitExceededErr      = errors.New("Size limit exceeded")
+	NoSuchAttributeErr        = errors.New("No such attribute or value")
+	AttributeOrValueExistsErr = errors.New("Attribute or value already exists")
+	RegistrantValidityErr     = errors.New("Registrant instance did not pass validity checks")
+	DUAConfigValidityErr      = errors.New("DUAConfig instance did not pass validity checks")
+	IllegalNumberFormErr      = errors.New("N (Number Form) is malformed or zero length")
+	InvalidDimensionErr       = errors.New("Unknown dimension; must be TwoDimensional or ThreeDimensional")
+	NilRegistrationErr        = errors.New("Registration instance is nil")
+	MismatchedLeafErr         = errors.New("Mismatched NumberForm with leaf node of ASN.1 and/or DotNotation")
+	IllegalLongArcErr         = errors.New("LongArc cannot be applied to this registration type or root")
+	NilRegistrantErr          = errors.New("Registrant instance is nil")
+	UnknownDraftErr           = errors.New("Unknown or unregistered draft revision")
+	NoSuchObjectErr           = errors.New("No such object")
+	EntryExistsErr            = errors.New("Entry already exists")
+	IllegalRootErr            = errors.New("Illegal root (must be 0, 1 or 2)")
+	InvalidOIDErr             = errors.New("OID value is malformed or zero length")
+	InvalidDNErr              = errors.New("DN value is malformed or zero length")
 )
 
-func init() {
-	RegistrationValidityErr = errorf("Registration instance did not pass validity checks")
-	UnsupportedInputTypeErr = errorf("Unsupported input type")
-	IllegalASN1NotationErr = errorf("ASN.1 Notation value is malformed or zero-length")
-	RegistrantValidityErr = errorf("Registrant instance did not pass validity checks")
-	DUAConfigValidityErr = errorf("DUAConfig instance did not pass validity checks")
-	IllegalNumberFormErr = errorf("N (Number Form) is malformed or zero length")
-	InvalidDimensionErr = errorf("Unknown dimension; must be TwoDimensional or ThreeDimensional")
-	NilRegistrationErr = errorf("Registration instance is nil")
-	MismatchedLeafErr = errorf("Mismatched NumberForm with leaf node of ASN.1 and/or DotNotation")
-	IllegalLongArcErr = errorf("LongArc cannot be applied to this registration type or root")
-	NilRegistrantErr = errorf("Registration instance is nil")
-	IllegalRootErr = errorf("Illegal root (must be 0, 1 or 2)")
-	InvalidOIDErr = errorf("OID value is malformed or zero length")
-	InvalidDNErr = errorf("DN value is malformed or zero length")
-}
-
 func errorf(msg any, x ...any) error {
 	switch tv := msg.(type) {
 	case string:
@@ -55,3 +52,181 @@ func errorf(msg any, x ...any) error {
 
 	return nil
 }
+
+/*
+errorw returns an error that wraps the input error (err), such that
+errors.Is will continue to identify it, alongside the message produced
+by the input format string and arguments.
+*/
+func errorw(err error, msg string, x ...any) error {
+	return fmt.Errorf("%w: %s", err, sprintf(msg, x...))
+}
+
+/*
+Severity describes the gravity of a *ValidationError.
+*/
+type Severity uint8
+
+const (
+	SeverityError   Severity = iota // the entry violates the ID
+	SeverityWarning                 // the entry is questionable, but not in violation
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r Severity) String() string {
+	switch r {
+	case SeverityError:
+		return `error`
+	case SeverityWarning:
+		return `warning`
+	}
+
+	return `unknown`
+}
+
+/*
+ViolationKind describes the nature of a *ValidationError, allowing the
+problem to be identified without regard for the wording of its message.
+*/
+type ViolationKind uint8
+
+const (
+	UnknownViolation            ViolationKind = iota // unclassified
+	ObjectClassViolation                             // objectClass values are absent or undefined
+	MissingAttributeViolation                        // a required attribute type is absent
+	NoValuesViolation                                // an attribute type bears no values
+	SingleValueViolation                             // a SINGLE-VALUE attribute type bears several values
+	NotPermittedViolation                            // an attribute type is not permitted by any objectClass
+	UndefinedAttributeViolation                      // an attribute type is not defined within the schema
+	ValueSyntaxViolation                             // a value does not conform to the syntax of its attribute type
+)
+
+/*
+ValidationError describes a single problem found within an entry, such
+as a missing or malformed attribute value. The Err field contains the
+predefined error instance (e.g.: RegistrationValidityErr) describing the
+general condition, which remains identifiable using errors.Is.
+
+Instances are produced by the Schema.Check method, and are borne by the
+CSVRowError instances returned by CSVCodec.Read for rows that violate the
+schema. Other checks, such as those of the Valid and Set methods and the
+CheckConformance function, do not produce instances of this type.
+*/
+type ValidationError struct {
+	Kind     ViolationKind
+	Attr     string   // e.g.: `dotNotation`
+	Value    string   // offending value, if any
+	Section  string   // section of the ID defining Attr, e.g.: `2.1.2`
+	Severity Severity // SeverityError or SeverityWarning
+	Msg      string   // e.g.: `required attribute type missing`
+	Err      error    // predefined error instance wrapped by the receiver
+}
+
+/*
+Error returns the string representation of the receiver, e.g.:
+
+	dotNotation: value is malformed: '1.3.x' (s. 2.1.2)
+*/
+func (r *ValidationError) Error() string {
+	msg := r.Msg
+	if len(msg) == 0 && r.Err != nil {
+		msg = r.Err.Error()
+	}
+
+	if len(r.Attr) > 0 {
+		msg = r.Attr + `: ` + msg
+	}
+	if len(r.Value) > 0 {
+		msg += `: '` + r.Value + `'`
+	}
+	if len(r.Section) > 0 {
+		msg += ` (s. ` + r.Section + `)`
+	}
+	if r.Severity == SeverityWarning {
+		msg = `warning: ` + msg
+	}
+
+	return msg
+}
+
+/*
+Unwrap returns the predefined error instance wrapped by the receiver.
+*/
+func (r *ValidationError) Unwrap() error {
+	return r.Err
+}
+
+/*
+ValidationErrors aggregates *ValidationError instances, and is itself an
+error. errors.Is and errors.As consider each of its instances.
+*/
+type ValidationErrors []*ValidationError
+
+/*
+Error returns the string representations of the receiver's instances,
+delimited by semicolons.
+*/
+func (r ValidationErrors) Error() string {
+	msgs := make([]string, len(r))
+	for i := 0; i < len(r); i++ {
+		msgs[i] = r[i].Error()
+	}
+
+	return join(msgs, `; `)
+}
+
+/*
+Unwrap returns the receiver's instances as slices of error.
+*/
+func (r ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(r))
+	for i := 0; i < len(r); i++ {
+		errs[i] = r[i]
+	}
+
+	return errs
+}
+
+/*
+Err returns the receiver as an error, or nil if the receiver is zero
+length. This avoids the non-nil error that would otherwise result from
+returning an empty ValidationErrors instance.
+*/
+func (r ValidationErrors) Err() error {
+	if len(r) == 0 {
+		return nil
+	}
+
+	return r
+}
+
+/*
+Severity returns the instances within the receiver bearing the input
+Severity.
+*/
+func (r ValidationErrors) Severity(s Severity) (v ValidationErrors) {
+	for i := 0; i < len(r); i++ {
+		if r[i].Severity == s {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Attr returns the instances within the receiver concerning the input
+attribute type, which is matched without regard for case.
+*/
+func (r ValidationErrors) Attr(at string) (v ValidationErrors) {
+	at = canonicalAttr(at)
+	for i := 0; i < len(r); i++ {
+		if eq(canonicalAttr(r[i].Attr), at) {
+			v = append(v, r[i])
+		}
+	}
+
+	return
+}
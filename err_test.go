@@ -0,0 +1,67 @@
+package dcxl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testDUA2D = &DUAConfig{
+	DirectoryModel: TwoDimensional,
+	Registrations:  []string{`ou=Registrations,o=rA`},
+	Registrants:    []string{`ou=Registrants,o=rA`},
+}
+
+/*
+TestSentinelWrapping verifies that errors describing known conditions
+wrap the predefined error instances, such that they may be identified
+using errors.Is.
+*/
+func TestSentinelWrapping(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{`malformed OID-IRI`, second(IRIToDotNot(`ISO`, nil)), InvalidOIDErr},
+		{`unknown OID-IRI label`, second(IRIToDotNot(`/ISO/Bogus`, nil)), InvalidOIDErr},
+		{`DN beyond registration base`, second(testDUA2D.RegistrationOID(`n=1,ou=Other,o=rA`)), InvalidDNErr},
+		{`unsupported RDN`, second(testDUA2D.RegistrationOID(`cn=x,ou=Registrations,o=rA`)), InvalidDNErr},
+		{`no registration objectClass`, second(MarshalRegistration(map[string][]string{`objectClass`: {`top`}})), RegistrationValidityErr},
+		{`no registrant attribute types`, second(MarshalRegistrants(map[string][]string{`objectClass`: {`top`}})), RegistrantValidityErr},
+		{`no DUAConfig in Root DSE`, second(FetchChildren(NewMemoryDirectory(nil), nil, `1.3`)), DUAConfigValidityErr},
+		{`unknown CSV column`, third((&CSVCodec{}).Read(strings.NewReader("dotNotation,bogus\n1.3,x\n"))), NoSuchAttributeErr},
+		{`underivable CSV DN`, csvRowErr((&CSVCodec{}).Read(strings.NewReader("dotNotation\n1.3\n"))), InvalidDNErr},
+		{`malformed PEN`, third((&PENImporter{DUAConfig: testDUA2D}).Import([]PENRecord{{Number: `x`}})), IllegalNumberFormErr},
+	} {
+		if !errors.Is(tc.err, tc.want) {
+			t.Errorf("%s: expected %v to wrap %v", tc.name, tc.err, tc.want)
+		}
+	}
+}
+
+/*
+second returns the error of the input pair.
+*/
+func second[T any](_ T, err error) error {
+	return err
+}
+
+/*
+third returns the error of the input triple.
+*/
+func third[T, U any](_ T, _ U, err error) error {
+	return err
+}
+
+/*
+csvRowErr returns the first row error of the input triple, or the error
+itself if there are none.
+*/
+func csvRowErr(_ Registrations, rowErrs []CSVRowError, err error) error {
+	if len(rowErrs) > 0 {
+		return rowErrs[0]
+	}
+
+	return err
+}
@@ -0,0 +1,659 @@
+package dcxl
+
+/*
+eval.go contains an RFC 4515 string filter parser, as well as an
+in-memory evaluator that runs Filter instances against Registration
+and Registrant instances (or their Unmarshal maps) without the need
+for a DSA.
+*/
+
+import "math/big"
+
+/*
+matchRule describes the manner in which assertion values are compared
+with attribute values during Filter evaluation.
+*/
+type matchRule uint8
+
+const (
+	exactMatch      matchRule = iota // caseExactMatch, octetStringMatch, et al.
+	caseIgnoreMatch                  // caseIgnoreMatch, caseIgnoreIA5Match
+	telephoneMatch                   // telephoneNumberMatch
+	dnMatch                          // distinguishedNameMatch
+	integerMatch                     // integerMatch
+	oidMatch                         // objectIdentifierMatch
+	timeMatch                        // generalizedTimeMatch
+	booleanMatch                     // booleanMatch
+)
+
+/*
+registrantSuffixRules maps the suffixes of the registrant attribute
+types (e.g.: the 'Org' in 'sponsorOrg') to the matching behavior of
+their respective super types, per s. 2.1.29 through s. 2.1.83.
+*/
+var registrantSuffixRules map[string]matchRule = map[string]matchRule{
+	`commonname`:     caseIgnoreMatch, // SUP cn
+	`countrycode`:    caseIgnoreMatch, // SUP c
+	`countryname`:    caseIgnoreMatch, // SUP co
+	`email`:          caseIgnoreMatch, // SUP mail
+	`locality`:       caseIgnoreMatch, // SUP l
+	`org`:            caseIgnoreMatch, // SUP o
+	`pobox`:          caseIgnoreMatch, // SUP postOfficeBox
+	`postaladdress`:  caseIgnoreMatch, // SUP postalAddress
+	`postalcode`:     caseIgnoreMatch, // SUP postalCode
+	`state`:          caseIgnoreMatch, // SUP st
+	`street`:         caseIgnoreMatch, // SUP street
+	`title`:          caseIgnoreMatch, // SUP title
+	`telephone`:      telephoneMatch,  // SUP telephoneNumber
+	`fax`:            telephoneMatch,  // SUP facsimileTelephoneNumber
+	`mobile`:         telephoneMatch,  // SUP mobile
+	`starttimestamp`: timeMatch,
+	`endtimestamp`:   timeMatch,
+	`uri`:            exactMatch, // SUP labeledURI
+}
+
+/*
+attributeRules maps the lower-cased names of the remaining attribute
+types defined in the ID (as well as a few standard types) to their
+matching behavior. Types not present here (e.g.: 'iRI') are matched
+exactly.
+*/
+var attributeRules map[string]matchRule = map[string]matchRule{
+	`n`:                    integerMatch, // s. 2.1.1
+	`dotnotation`:          oidMatch,     // s. 2.1.2
+	`asn1notation`:         caseIgnoreMatch,
+	`identifier`:           caseIgnoreMatch, // SUP name
+	`additionalidentifier`: caseIgnoreMatch, // SUP name
+	`registrationcreated`:  timeMatch,
+	`registrationmodified`: timeMatch,
+	`registrationrange`:    integerMatch,
+	`registrationstatus`:   caseIgnoreMatch, // SUP description
+	`isleafnode`:           booleanMatch,
+	`isfrozen`:             booleanMatch,
+	`suparc`:               dnMatch,
+	`toparc`:               dnMatch,
+	`subarc`:               dnMatch,
+	`leftarc`:              dnMatch,
+	`firstarc`:             dnMatch,
+	`rightarc`:             dnMatch,
+	`finalarc`:             dnMatch,
+	`discloseto`:           dnMatch,
+	`currentauthority`:     dnMatch,
+	`firstauthority`:       dnMatch,
+	`sponsor`:              dnMatch,
+	`raregistrationbase`:   dnMatch,
+	`raregistrantbase`:     dnMatch,
+	`radirectorymodel`:     oidMatch,
+	`raservicemail`:        caseIgnoreMatch, // SUP mail
+	`description`:          caseIgnoreMatch,
+	`objectclass`:          caseIgnoreMatch,
+}
+
+/*
+matchingRuleOf returns the matchRule appropriate for the input attribute
+type descriptor.
+*/
+func matchingRuleOf(at string) matchRule {
+	at = lc(canonicalAttr(at))
+	if rule, ok := attributeRules[at]; ok {
+		return rule
+	}
+
+	for _, pfx := range []string{`firstauthority`, `currentauthority`, `sponsor`} {
+		if hasPrefix(at, pfx) {
+			if rule, ok := registrantSuffixRules[at[len(pfx):]]; ok {
+				return rule
+			}
+		}
+	}
+
+	return exactMatch
+}
+
+/*
+filterEntry is a map of lower-cased attribute type descriptors and their
+values, produced once per evaluation so as to allow case-insensitive
+attribute type resolution.
+*/
+type filterEntry map[string][]string
+
+func (r filterEntry) values(at string) []string {
+	if idx := idxRune(at, ';'); idx != -1 {
+		at = at[:idx] // ignore attribute options
+	}
+
+	return r[lc(canonicalAttr(at))]
+}
+
+func newFilterEntry(m map[string][]string) filterEntry {
+	e := make(filterEntry, len(m))
+	for k, v := range m {
+		key := lc(canonicalAttr(k))
+		e[key] = append(e[key], v...)
+	}
+
+	return e
+}
+
+/*
+MatchFilter returns a boolean value indicative of whether the input value
+(x) is matched by the input Filter (f), alongside an error.
+
+Valid types for x are any Registration or Registrant instance (pointer or
+otherwise), an instance of *DUAConfig or an instance of map[string][]string
+as produced by the Unmarshal methods. Values are read from the `ldap`
+tagged struct fields, and thus an 'objectClass' assertion is honored as
+well.
+
+Attribute type descriptors are resolved case-insensitively, and the
+'numberForm' and 'nameForm' aliases are honored. Values of attribute types
+based upon the directoryString syntax (e.g.: 'identifier', 'asn1Notation'
+or 'sponsorOrg') are matched case-insensitively, as are DN values. Values
+of other types (e.g.: 'iRI' or 'unicodeValue') are matched exactly, except
+for those of integer, boolean and generalizedTime syntaxes, which are
+compared by value.
+
+Approximate (~=) assertions are satisfied when the value, once folded to
+lower case and stripped of white space and punctuation, is equal to or
+within one (1) character edit of the assertion value similarly processed.
+*/
+func MatchFilter(f Filter, x any) (bool, error) {
+	if f == nil {
+		return false, errorf("Nil %T provided; aborting", f)
+	}
+
+	var m map[string][]string
+	switch tv := x.(type) {
+	case map[string][]string:
+		m = tv
+	case interface{ Unmarshal() map[string][]string }:
+		m = tv.Unmarshal()
+	case *DUAConfig:
+		if tv == nil {
+			return false, errorf("Nil %T provided; aborting", tv)
+		}
+		m = tv.unmarshal()
+	case DUAConfig:
+		m = tv.unmarshal()
+	default:
+		return false, errorf("%v: %T", UnsupportedInputTypeErr, tv)
+	}
+
+	return f.match(newFilterEntry(m)), nil
+}
+
+/*
+Match returns the Registration instances within the receiver that
+are matched by the input Filter. See the MatchFilter function for
+details regarding the evaluation of filters.
+*/
+func (r Registrations) Match(f Filter) (m Registrations) {
+	m = make(Registrations, 0)
+	for i := 0; i < len(r); i++ {
+		if r[i] == nil {
+			continue
+		}
+		if ok, err := MatchFilter(f, r[i]); ok && err == nil {
+			m = append(m, r[i])
+		}
+	}
+
+	return
+}
+
+/*
+Match returns the Registrant instances within the receiver that are
+matched by the input Filter. See the MatchFilter function for details
+regarding the evaluation of filters.
+*/
+func (r Registrants) Match(f Filter) (m Registrants) {
+	m = make(Registrants, 0)
+	for i := 0; i < len(r); i++ {
+		if r[i] == nil {
+			continue
+		}
+		if ok, err := MatchFilter(f, r[i]); ok && err == nil {
+			m = append(m, r[i])
+		}
+	}
+
+	return
+}
+
+func (r AndFilter) match(e filterEntry) bool {
+	for i := 0; i < len(r); i++ {
+		if !r[i].match(e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r OrFilter) match(e filterEntry) bool {
+	for i := 0; i < len(r); i++ {
+		if r[i].match(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r NotFilter) match(e filterEntry) bool {
+	if r.Filter == nil {
+		return false
+	}
+
+	return !r.Filter.match(e)
+}
+
+func (r PresentFilter) match(e filterEntry) bool {
+	return len(e.values(r.Attr)) > 0
+}
+
+func (r EqualityFilter) match(e filterEntry) bool {
+	rule := matchingRuleOf(r.Attr)
+	vals := e.values(r.Attr)
+	for i := 0; i < len(vals); i++ {
+		if compareValues(rule, vals[i], r.Value) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r GreaterOrEqualFilter) match(e filterEntry) bool {
+	rule := matchingRuleOf(r.Attr)
+	vals := e.values(r.Attr)
+	for i := 0; i < len(vals); i++ {
+		if compareValues(rule, vals[i], r.Value) >= 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r LessOrEqualFilter) match(e filterEntry) bool {
+	rule := matchingRuleOf(r.Attr)
+	vals := e.values(r.Attr)
+	for i := 0; i < len(vals); i++ {
+		if c := compareValues(rule, vals[i], r.Value); c <= 0 && c != incomparable {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r ApproxFilter) match(e filterEntry) bool {
+	want := approxNormalize(r.Value)
+	vals := e.values(r.Attr)
+	for i := 0; i < len(vals); i++ {
+		have := approxNormalize(vals[i])
+		if have == want || (len(want) > 3 && editDistance(have, want) <= 1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r SubstringsFilter) match(e filterEntry) bool {
+	rule := matchingRuleOf(r.Attr)
+	norm := func(s string) string { return normalizeValue(rule, s) }
+
+	vals := e.values(r.Attr)
+	for i := 0; i < len(vals); i++ {
+		v := norm(vals[i])
+		if !hasPrefix(v, norm(r.Initial)) {
+			continue
+		}
+		v = v[len(norm(r.Initial)):]
+
+		var ok bool = true
+		for j := 0; j < len(r.Any) && ok; j++ {
+			a := norm(r.Any[j])
+			if idx := indexOf(v, a); idx == -1 {
+				ok = false
+			} else {
+				v = v[idx+len(a):]
+			}
+		}
+
+		if ok && hasSuffix(v, norm(r.Final)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// incomparable is returned by compareValues when
+// the values cannot be ordered relative to one
+// another (e.g.: a non-numeric integer value).
+const incomparable int = -2
+
+/*
+compareValues returns 0 if the attribute value (val) and assertion
+value (assn) are equal per the input matchRule, -1 if val is less
+than assn, 1 if val is greater than assn, or incomparable.
+*/
+func compareValues(rule matchRule, val, assn string) int {
+	switch rule {
+	case integerMatch:
+		x, okx := new(big.Int).SetString(trimS(val), 10)
+		y, oky := new(big.Int).SetString(trimS(assn), 10)
+		if !okx || !oky {
+			return incomparable
+		}
+		return x.Cmp(y)
+	case timeMatch:
+		x, okx := genTimeToTime(trimS(val))
+		y, oky := genTimeToTime(trimS(assn))
+		if !okx || !oky {
+			return incomparable
+		}
+		if x.Equal(y) {
+			return 0
+		} else if x.Before(y) {
+			return -1
+		}
+		return 1
+	}
+
+	x, y := normalizeValue(rule, val), normalizeValue(rule, assn)
+	switch {
+	case x == y:
+		return 0
+	case x < y:
+		return -1
+	}
+
+	return 1
+}
+
+/*
+normalizeValue prepares the input value for comparison according to
+the input matchRule.
+*/
+func normalizeValue(rule matchRule, val string) string {
+	switch rule {
+	case caseIgnoreMatch:
+		return lc(join(fields(val), ` `))
+	case telephoneMatch:
+		return lc(replaceAll(replaceAll(val, ` `, ``), `-`, ``))
+	case dnMatch:
+		return normalizeDN(val)
+	case booleanMatch:
+		return uc(trimS(val))
+	case oidMatch:
+		return trimS(val)
+	}
+
+	return val
+}
+
+func approxNormalize(val string) string {
+	var norm []rune
+	for _, c := range lc(val) {
+		if isLetter(c) || isDigit(c) {
+			norm = append(norm, c)
+		}
+	}
+
+	return string(norm)
+}
+
+/*
+editDistance returns the Levenshtein distance between a and b.
+*/
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := 0; j <= len(rb); j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(minInt(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+/*
+ParseFilter returns an instance of Filter alongside an error following
+an attempt to parse the input RFC 4515 string filter (e.g.:
+"(&(objectClass=x660SubArc)(identifier=enterprise*))").
+
+Equality, approximate, ordering, presence and substring assertions are
+supported, as are the and (&), or (|) and not (!) constructs. Assertion
+values may contain RFC 4515 escape sequences (e.g.: "\2a"). Extensible
+match assertions are not supported.
+
+As a convenience, a sole assertion lacking its enclosing parentheses
+(e.g.: "identifier=enterprise*") is also accepted.
+*/
+func ParseFilter(s string) (f Filter, err error) {
+	s = trimS(s)
+	if len(s) == 0 {
+		err = errorf("Zero length filter provided; aborting")
+		return
+	} else if s[0] != '(' {
+		s = `(` + s + `)`
+	}
+
+	var pos int
+	if f, pos, err = parseFilterAt(s, 0); err == nil && pos != len(s) {
+		err = errorf("Unexpected trailing content in filter at position %d: '%s'", pos, s[pos:])
+		f = nil
+	}
+
+	return
+}
+
+/*
+parseFilterAt parses the filter beginning at position pos, which must
+be an opening parenthesis, returning the Filter and the position just
+beyond its closing parenthesis.
+*/
+func parseFilterAt(s string, pos int) (f Filter, next int, err error) {
+	if pos >= len(s) || s[pos] != '(' {
+		err = errorf("Expected '(' at position %d of filter '%s'", pos, s)
+		return
+	}
+	pos++
+
+	if pos >= len(s) {
+		err = errorf("Unexpected end of filter '%s'", s)
+		return
+	}
+
+	switch s[pos] {
+	case '&', '|':
+		var set []Filter
+		op := s[pos]
+		pos++
+		for pos < len(s) && s[pos] == '(' {
+			var sub Filter
+			if sub, pos, err = parseFilterAt(s, pos); err != nil {
+				return
+			}
+			set = append(set, sub)
+		}
+		if op == '&' {
+			f = AndFilter(set)
+		} else {
+			f = OrFilter(set)
+		}
+	case '!':
+		var sub Filter
+		if sub, pos, err = parseFilterAt(s, pos+1); err != nil {
+			return
+		}
+		f = NotFilter{Filter: sub}
+	default:
+		end := pos
+		for end < len(s) && s[end] != ')' {
+			if s[end] == '(' {
+				err = errorf("Unescaped '(' at position %d of filter '%s'", end, s)
+				return
+			}
+			end++
+		}
+		if f, err = parseItem(s[pos:end]); err != nil {
+			return
+		}
+		pos = end
+	}
+
+	if pos >= len(s) || s[pos] != ')' {
+		err = errorf("Expected ')' at position %d of filter '%s'", pos, s)
+		return
+	}
+	next = pos + 1
+
+	return
+}
+
+/*
+parseItem parses a simple, present or substring filter item, sans its
+enclosing parentheses, e.g.: "identifier=enter*".
+*/
+func parseItem(item string) (f Filter, err error) {
+	idx := idxRune(item, '=')
+	if idx < 1 {
+		err = errorf("Malformed filter item '%s'", item)
+		return
+	}
+
+	at, raw := item[:idx], item[idx+1:]
+	var kind byte = '='
+	switch at[len(at)-1] {
+	case '~', '>', '<':
+		kind = at[len(at)-1]
+		at = at[:len(at)-1]
+	case ':':
+		err = errorf("Extensible match filter '%s' is not supported", item)
+		return
+	}
+
+	if !validAttrDesc(at) {
+		err = errorf("Invalid attribute description '%s' in filter item '%s'", at, item)
+		return
+	}
+
+	if kind != '=' {
+		var val string
+		if val, err = unescapeFilterValue(raw); err != nil {
+			return
+		}
+		switch kind {
+		case '~':
+			f = ApproxFilter{Attr: at, Value: val}
+		case '>':
+			f = GreaterOrEqualFilter{Attr: at, Value: val}
+		default:
+			f = LessOrEqualFilter{Attr: at, Value: val}
+		}
+		return
+	}
+
+	if raw == `*` {
+		f = PresentFilter{Attr: at}
+		return
+	} else if !contains(raw, `*`) {
+		var val string
+		if val, err = unescapeFilterValue(raw); err == nil {
+			f = EqualityFilter{Attr: at, Value: val}
+		}
+		return
+	}
+
+	// Escaped asterisks manifest as "\2a", so it
+	// is safe to split the raw value on literal
+	// asterisks prior to unescaping.
+	comps := split(raw, `*`)
+	for i := 0; i < len(comps); i++ {
+		if comps[i], err = unescapeFilterValue(comps[i]); err != nil {
+			return
+		}
+	}
+
+	sub := SubstringsFilter{Attr: at, Initial: comps[0], Final: comps[len(comps)-1]}
+	for i := 1; i < len(comps)-1; i++ {
+		if len(comps[i]) == 0 {
+			err = errorf("Empty substring component in filter item '%s'", item)
+			return
+		}
+		sub.Any = append(sub.Any, comps[i])
+	}
+	f = sub
+
+	return
+}
+
+/*
+validAttrDesc returns a boolean value indicative of whether the input
+is a syntactically valid attribute description, whether a descriptor
+(with optional options) or a numeric OID.
+*/
+func validAttrDesc(at string) bool {
+	if len(at) == 0 {
+		return false
+	}
+
+	for i := 0; i < len(at); i++ {
+		c := rune(at[i])
+		if !(isLetter(c) || isDigit(c) || c == '-' || c == ';' || c == '.') {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+unescapeFilterValue resolves the RFC 4515 escape sequences (e.g.: "\28")
+present within the input assertion value.
+*/
+func unescapeFilterValue(raw string) (string, error) {
+	if !contains(raw, `\`) {
+		return raw, nil
+	}
+
+	var val []byte = make([]byte, 0, len(raw))
+	for i := 0; i < len(raw); i++ {
+		if raw[i] != '\\' {
+			val = append(val, raw[i])
+			continue
+		}
+
+		if i+2 >= len(raw) || !isHex(raw[i+1]) || !isHex(raw[i+2]) {
+			return ``, errorf("Invalid escape sequence in assertion value '%s'", raw)
+		}
+		val = append(val, hexByte(raw[i+1])<<4|hexByte(raw[i+2]))
+		i += 2
+	}
+
+	return string(val), nil
+}
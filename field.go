@@ -0,0 +1,235 @@
+package dcxl
+
+/*
+field.go contains the Field type, which enumerates the attribute types
+of the entry types, as well as the generic typed accessors and the typed
+stock converters used with them.
+*/
+
+import (
+	"math/big"
+	"net/url"
+	"time"
+)
+
+/*
+Field identifies a single attribute type borne by a Registration or
+Registrant, allowing attribute access to be checked at compile time.
+One constant exists per attribute type (e.g.: FieldDotNotation and
+FieldRegistrationStatus); see marshal_gen.go.
+
+The zero value identifies no attribute type.
+*/
+type Field uint8
+
+/*
+String returns the attribute type name of the receiver, e.g.: dotNotation
+for FieldDotNotation.
+*/
+func (r Field) String() string {
+	if r == 0 || int(r) >= len(fieldNames) {
+		return `unknown`
+	}
+
+	return fieldNames[r]
+}
+
+/*
+ParseField returns the Field identified by the input attribute type name
+alongside an error. The name is matched case-insensitively, and may be
+an alternative name, such as numberForm (n) or nameForm (identifier).
+*/
+func ParseField(name string) (Field, error) {
+	at := canonicalAttr(trimS(name))
+	for i := 1; i < len(fieldNames); i++ {
+		if eq(fieldNames[i], at) {
+			return Field(i), nil
+		}
+	}
+
+	return 0, errorf("Unknown attribute type '%s'", name)
+}
+
+/*
+Fields returns all Field constants in order.
+*/
+func Fields() (fields []Field) {
+	for i := 1; i < len(fieldNames); i++ {
+		fields = append(fields, Field(i))
+	}
+
+	return
+}
+
+/*
+Get returns the first value of the input Field within the input entry,
+converted using fn, alongside an error. The entry may be any Registration
+or Registrant. If the Field is unset, the zero value of T is returned
+alongside a nil error, and fn is not called.
+
+For example:
+
+	created, err := Get(reg, FieldRegistrationCreated, AsTime)
+*/
+func Get[T any](r interface{ Unmarshal() map[string][]string }, field Field, fn func(string) (T, error)) (v T, err error) {
+	var vals []string
+	if vals, err = fieldValues(r, field, fn != nil); err == nil && len(vals) > 0 {
+		v, err = fn(vals[0])
+	}
+
+	return
+}
+
+/*
+GetAll returns all values of the input Field within the input entry,
+converted using fn, alongside an error. The entry may be any Registration
+or Registrant. Conversion stops at the first error encountered.
+
+For example:
+
+	modified, err := GetAll(reg, FieldRegistrationModified, AsTime)
+*/
+func GetAll[T any](r interface{ Unmarshal() map[string][]string }, field Field, fn func(string) (T, error)) (v []T, err error) {
+	var vals []string
+	if vals, err = fieldValues(r, field, fn != nil); err != nil {
+		return
+	}
+
+	for i := 0; i < len(vals); i++ {
+		var x T
+		if x, err = fn(vals[i]); err != nil {
+			return nil, err
+		}
+		v = append(v, x)
+	}
+
+	return
+}
+
+/*
+fieldValues returns the values of the input Field within the input entry,
+alongside an error.
+*/
+func fieldValues(r interface{ Unmarshal() map[string][]string }, field Field, fn bool) (vals []string, err error) {
+	switch {
+	case r == nil:
+		err = errorf("Nil entry provided; aborting")
+	case !fn:
+		err = errorf("Nil conversion function provided; aborting")
+	case field == 0 || int(field) >= len(fieldNames):
+		err = errorf("Unknown %T '%d'", field, field)
+	default:
+		vals = r.Unmarshal()[field.String()]
+	}
+
+	return
+}
+
+/*
+OID describes an ASN.1 object identifier as a sequence of numberForm
+values, each of which may exceed the bounds of the native integer types.
+*/
+type OID []*big.Int
+
+/*
+String returns the dotNotation of the receiver.
+*/
+func (r OID) String() string {
+	arcs := make([]string, len(r))
+	for i := 0; i < len(r); i++ {
+		arcs[i] = r[i].String()
+	}
+
+	return join(arcs, `.`)
+}
+
+/*
+AsString returns the input value as-is, and qualifies for use with the
+Get and GetAll functions.
+*/
+func AsString(s string) (string, error) {
+	return s, nil
+}
+
+/*
+AsTime returns the time.Time instance described by the input generalized-
+Time value alongside an error, and qualifies for use with the Get and
+GetAll functions.
+*/
+func AsTime(s string) (t time.Time, err error) {
+	var ok bool
+	if t, ok = genTimeToTime(s); !ok {
+		err = errorf("Invalid generalizedTime value '%s'", s)
+	}
+
+	return
+}
+
+/*
+AsBigInt returns the *big.Int instance described by the input numberForm
+value alongside an error, and qualifies for use with the Get and GetAll
+functions.
+*/
+func AsBigInt(s string) (n *big.Int, err error) {
+	var ok bool
+	if n, ok = new(big.Int).SetString(s, 10); !ok || n.Sign() < 0 {
+		n, err = nil, errorf("Invalid numberForm value '%s'", s)
+	}
+
+	return
+}
+
+/*
+AsOID returns the OID instance described by the input dotNotation value
+alongside an error, and qualifies for use with the Get and GetAll
+functions.
+*/
+func AsOID(s string) (o OID, err error) {
+	arcs := split(s, `.`)
+	if len(s) == 0 || (arcs[0] != `0` && arcs[0] != `1` && arcs[0] != `2`) {
+		err = errorf("Invalid dotNotation value '%s'", s)
+		return
+	}
+
+	o = make(OID, len(arcs))
+	for i := 0; i < len(arcs); i++ {
+		if o[i], err = AsBigInt(arcs[i]); err != nil {
+			return nil, errorf("Invalid dotNotation value '%s'", s)
+		}
+	}
+
+	return
+}
+
+/*
+AsURL returns the *url.URL instance described by the input value, such
+as that of a registrationURI, alongside an error, and qualifies for use
+with the Get and GetAll functions. Any label following the URI, per the
+labeledURI syntax (RFC 2079), is discarded.
+*/
+func AsURL(s string) (u *url.URL, err error) {
+	if fields := split(trimS(s), ` `); len(fields[0]) > 0 {
+		u, err = url.Parse(fields[0])
+	} else {
+		err = errorf("Invalid URI value '%s'", s)
+	}
+
+	return
+}
+
+/*
+AsBool returns the boolean value described by the input LDAP Boolean
+value ("TRUE" or "FALSE") alongside an error, and qualifies for use with
+the Get and GetAll functions.
+*/
+func AsBool(s string) (b bool, err error) {
+	switch uc(s) {
+	case `TRUE`:
+		b = true
+	case `FALSE`:
+	default:
+		err = errorf("Invalid Boolean value '%s'", s)
+	}
+
+	return
+}
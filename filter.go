@@ -0,0 +1,634 @@
+package dcxl
+
+/*
+filter.go contains the Filter abstract syntax tree, its RFC 4515
+string encoding and a handful of convenience constructors for the
+registration lookups described in section 3.7.2 of the ID.
+*/
+
+/*
+Filter is implemented by all LDAP search filter node types defined
+in this package. The String method returns the RFC 4515 string
+encoding of the receiver, with all assertion values escaped.
+
+Filter instances may be crafted manually by way of the node types
+(e.g.: AndFilter, EqualityFilter), through the And, Or, Not, Equal,
+Present, Substrings, GreaterOrEqual, LessOrEqual and Approx functions,
+or through one of the "By" lookup constructors.
+*/
+type Filter interface {
+	// String returns the RFC 4515 string encoding of the receiver.
+	String() string
+
+	// isFilter is a private marker method that prevents types defined
+	// outside of this package from qualifying as a Filter.
+	isFilter()
+
+	// match returns a boolean value indicative of whether the receiver
+	// matches the input entry. See the MatchFilter function.
+	match(filterEntry) bool
+}
+
+/*
+AndFilter describes an RFC 4515 'and' filter, which matches when all
+of its component filters match. A zero length instance is the absolute
+true filter '(&)', per RFC 4526.
+*/
+type AndFilter []Filter
+
+/*
+OrFilter describes an RFC 4515 'or' filter, which matches when any one
+of its component filters match. A zero length instance is the absolute
+false filter '(|)', per RFC 4526.
+*/
+type OrFilter []Filter
+
+/*
+NotFilter describes an RFC 4515 'not' filter, which matches when its
+component filter does not match.
+*/
+type NotFilter struct {
+	Filter Filter
+}
+
+/*
+EqualityFilter describes an RFC 4515 equality match, such as
+'(identifier=enterprise)'.
+*/
+type EqualityFilter struct {
+	Attr  string
+	Value string
+}
+
+/*
+ApproxFilter describes an RFC 4515 approximate match, such as
+'(identifier~=enterprize)'.
+*/
+type ApproxFilter struct {
+	Attr  string
+	Value string
+}
+
+/*
+GreaterOrEqualFilter describes an RFC 4515 ordering match, such as
+'(registrationCreated>=20200101000000Z)'.
+*/
+type GreaterOrEqualFilter struct {
+	Attr  string
+	Value string
+}
+
+/*
+LessOrEqualFilter describes an RFC 4515 ordering match, such as
+'(registrationCreated<=20200101000000Z)'.
+*/
+type LessOrEqualFilter struct {
+	Attr  string
+	Value string
+}
+
+/*
+PresentFilter describes an RFC 4515 presence match, such as
+'(dotNotation=*)'.
+*/
+type PresentFilter struct {
+	Attr string
+}
+
+/*
+SubstringsFilter describes an RFC 4515 substrings match, such as
+'(identifier=enter*)'. Any of the Initial, Any and Final fields may
+be zero, but at least one of them should be set.
+*/
+type SubstringsFilter struct {
+	Attr    string
+	Initial string
+	Any     []string
+	Final   string
+}
+
+func (r AndFilter) isFilter()            {}
+func (r OrFilter) isFilter()             {}
+func (r NotFilter) isFilter()            {}
+func (r EqualityFilter) isFilter()       {}
+func (r ApproxFilter) isFilter()         {}
+func (r GreaterOrEqualFilter) isFilter() {}
+func (r LessOrEqualFilter) isFilter()    {}
+func (r PresentFilter) isFilter()        {}
+func (r SubstringsFilter) isFilter()     {}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r AndFilter) String() string {
+	return `(&` + filterSetString(r) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r OrFilter) String() string {
+	return `(|` + filterSetString(r) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r NotFilter) String() string {
+	if r.Filter == nil {
+		return `(!)`
+	}
+
+	return `(!` + r.Filter.String() + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r EqualityFilter) String() string {
+	return `(` + r.Attr + `=` + EscapeFilterValue(r.Value) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r ApproxFilter) String() string {
+	return `(` + r.Attr + `~=` + EscapeFilterValue(r.Value) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r GreaterOrEqualFilter) String() string {
+	return `(` + r.Attr + `>=` + EscapeFilterValue(r.Value) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r LessOrEqualFilter) String() string {
+	return `(` + r.Attr + `<=` + EscapeFilterValue(r.Value) + `)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r PresentFilter) String() string {
+	return `(` + r.Attr + `=*)`
+}
+
+/*
+String returns the RFC 4515 string encoding of the receiver.
+*/
+func (r SubstringsFilter) String() string {
+	var val string = EscapeFilterValue(r.Initial) + `*`
+	for i := 0; i < len(r.Any); i++ {
+		val += EscapeFilterValue(r.Any[i]) + `*`
+	}
+	val += EscapeFilterValue(r.Final)
+
+	return `(` + r.Attr + `=` + val + `)`
+}
+
+func filterSetString(set []Filter) (s string) {
+	for i := 0; i < len(set); i++ {
+		if set[i] != nil {
+			s += set[i].String()
+		}
+	}
+
+	return
+}
+
+/*
+And returns an instance of AndFilter containing the non-nil input
+Filter instances.
+*/
+func And(f ...Filter) AndFilter {
+	return AndFilter(compactFilters(f))
+}
+
+/*
+Or returns an instance of OrFilter containing the non-nil input
+Filter instances.
+*/
+func Or(f ...Filter) OrFilter {
+	return OrFilter(compactFilters(f))
+}
+
+/*
+Not returns an instance of NotFilter enveloping the input Filter.
+*/
+func Not(f Filter) NotFilter {
+	return NotFilter{Filter: f}
+}
+
+/*
+Equal returns an instance of EqualityFilter for the input attribute
+type and (unescaped) assertion value.
+*/
+func Equal(at, val string) EqualityFilter {
+	return EqualityFilter{Attr: at, Value: val}
+}
+
+/*
+Approx returns an instance of ApproxFilter for the input attribute
+type and (unescaped) assertion value.
+*/
+func Approx(at, val string) ApproxFilter {
+	return ApproxFilter{Attr: at, Value: val}
+}
+
+/*
+GreaterOrEqual returns an instance of GreaterOrEqualFilter for the
+input attribute type and (unescaped) assertion value.
+*/
+func GreaterOrEqual(at, val string) GreaterOrEqualFilter {
+	return GreaterOrEqualFilter{Attr: at, Value: val}
+}
+
+/*
+LessOrEqual returns an instance of LessOrEqualFilter for the input
+attribute type and (unescaped) assertion value.
+*/
+func LessOrEqual(at, val string) LessOrEqualFilter {
+	return LessOrEqualFilter{Attr: at, Value: val}
+}
+
+/*
+Present returns an instance of PresentFilter for the input attribute
+type.
+*/
+func Present(at string) PresentFilter {
+	return PresentFilter{Attr: at}
+}
+
+/*
+Substrings returns a Filter for the input attribute type based upon
+the wildcard pattern (pat), in which asterisks (*) delimit substring
+components, e.g.: "enter*" or "*prise*". If pat contains no asterisks,
+an instance of EqualityFilter is returned instead. If pat is a sole
+asterisk, an instance of PresentFilter is returned.
+*/
+func Substrings(at, pat string) Filter {
+	if !contains(pat, `*`) {
+		return Equal(at, pat)
+	} else if pat == `*` {
+		return Present(at)
+	}
+
+	comps := split(pat, `*`)
+	sub := SubstringsFilter{
+		Attr:    at,
+		Initial: comps[0],
+		Final:   comps[len(comps)-1],
+	}
+
+	// Collapse successive asterisks, which
+	// would otherwise produce zero length
+	// any components.
+	for i := 1; i < len(comps)-1; i++ {
+		if len(comps[i]) > 0 {
+			sub.Any = append(sub.Any, comps[i])
+		}
+	}
+
+	return sub
+}
+
+func compactFilters(f []Filter) (c []Filter) {
+	c = make([]Filter, 0, len(f))
+	for i := 0; i < len(f); i++ {
+		if f[i] != nil {
+			c = append(c, f[i])
+		}
+	}
+
+	return
+}
+
+/*
+EscapeFilterValue returns the input assertion value with the asterisk
+(*), parentheses, backslash (\) and NUL characters escaped per section
+3 of RFC 4515. All other characters, including UTF-8 sequences, are
+returned as-is.
+*/
+func EscapeFilterValue(val string) string {
+	var esc []byte = make([]byte, 0, len(val))
+	for i := 0; i < len(val); i++ {
+		switch c := val[i]; c {
+		case '*', '(', ')', '\\', 0:
+			esc = append(esc, []byte(sprintf("\\%02x", c))...)
+		default:
+			esc = append(esc, c)
+		}
+	}
+
+	return string(esc)
+}
+
+/*
+registrationClassFilter returns an OrFilter that matches either of the
+two (2) STRUCTURAL registration classes defined in s. 2.2.1 and 2.2.2.
+*/
+func registrationClassFilter() OrFilter {
+	return Or(
+		Equal(`objectClass`, RootArc{}.ObjectClass()),
+		Equal(`objectClass`, SubArc{}.ObjectClass()),
+	)
+}
+
+/*
+ByIdentifier returns a Filter suitable for a subtree search (per s. 3.7.2
+of the ID) of registrations bearing the input nameForm as an 'identifier'
+or 'additionalIdentifier' value. If the input value contains asterisks
+(*), the substring form is used, e.g.: "enter*".
+
+The *DUAConfig instance (d) must be valid. When registrations and their
+registrants are stored as so-called "combined entries", the objectClass
+clause ensures only registration entries are matched.
+*/
+func ByIdentifier(d *DUAConfig, id string) (Filter, error) {
+	if !d.Valid() {
+		return nil, DUAConfigValidityErr
+	} else if len(id) == 0 {
+		return nil, errorf("Zero length identifier provided; aborting")
+	}
+
+	return And(
+		registrationClassFilter(),
+		Or(Substrings(`identifier`, id), Substrings(`additionalIdentifier`, id)),
+	), nil
+}
+
+/*
+ByIRI returns a Filter suitable for a subtree search (per s. 3.7.2 of the
+ID) of registrations bearing the input internationalized resource identifier
+as an 'iRI' value, e.g.: "/ISO/Identified-Organization". If the input value
+contains asterisks (*), the substring form is used.
+
+The *DUAConfig instance (d) must be valid.
+*/
+func ByIRI(d *DUAConfig, iri string) (Filter, error) {
+	if !d.Valid() {
+		return nil, DUAConfigValidityErr
+	} else if !hasPrefix(iri, `/`) && !contains(iri, `*`) {
+		return nil, errorf("Invalid IRI '%s' (must begin with a solidus)", iri)
+	}
+
+	return And(registrationClassFilter(), Substrings(`iRI`, iri)), nil
+}
+
+/*
+ByDotNotation returns a Filter suitable for locating the registration
+that bears the input dotNotation object identifier value, per s. 3.6.1.1
+of the ID.
+
+When the *DUAConfig instance (d) describes a TwoDimensional model, the
+literal 'dotNotation' value is asserted, e.g.:
+
+	(&(objectClass=x660SubArc)(dotNotation=1.3.6.1))
+
+When the *DUAConfig instance describes a ThreeDimensional model, for which
+'dotNotation' values are often absent, only the leaf numberForm is asserted
+and the Filter is meant for use alongside a baseObject search upon the DN
+inferred through the DotNotToDN3D function, e.g.:
+
+	(&(objectClass=x660SubArc)(n=1))
+
+Single-arc values (e.g.: "1") always produce a root registration filter,
+regardless of the model in use.
+*/
+func ByDotNotation(d *DUAConfig, oid string) (Filter, error) {
+	if !d.Valid() {
+		return nil, DUAConfigValidityErr
+	}
+
+	arcs, err := splitDotNot(oid)
+	if err != nil {
+		return nil, err
+	}
+	leaf := arcs[len(arcs)-1]
+
+	if len(arcs) == 1 {
+		return And(Equal(`objectClass`, RootArc{}.ObjectClass()), Equal(`n`, leaf)), nil
+	}
+
+	oc := Equal(`objectClass`, SubArc{}.ObjectClass())
+	if d.DirectoryModel == TwoDimensional {
+		return And(oc, Equal(`dotNotation`, oid)), nil
+	}
+
+	return And(oc, Equal(`n`, leaf)), nil
+}
+
+/*
+ChildrenOf returns a Filter suitable for locating the immediate subordinate
+registrations of the registration bearing the input dotNotation value.
+
+When the *DUAConfig instance (d) describes a ThreeDimensional model, the
+Filter is meant for use alongside a singleLevel search beneath the DN of
+the parent registration, and merely asserts the objectClass:
+
+	(objectClass=x660SubArc)
+
+When the *DUAConfig instance describes a TwoDimensional model, all of the
+registrations are siblings, and the 'supArc' value of each child is asserted
+instead, e.g.:
+
+	(&(objectClass=x660SubArc)(supArc=dotNotation=1.3.6,ou=Registrations,o=rA))
+
+As 'supArc' bears the distinguishedNameMatch equality rule, such assertions
+are honored by any compliant DSA. Note that registrations lacking a 'supArc'
+value will not be matched.
+*/
+func ChildrenOf(d *DUAConfig, oid string) (Filter, error) {
+	if !d.Valid() {
+		return nil, DUAConfigValidityErr
+	}
+
+	if _, err := splitDotNot(oid); err != nil {
+		return nil, err
+	}
+
+	oc := Equal(`objectClass`, SubArc{}.ObjectClass())
+	if d.DirectoryModel == ThreeDimensional {
+		return oc, nil
+	}
+
+	dn, err := d.RegistrationDN(oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return And(oc, Equal(`supArc`, dn)), nil
+}
+
+/*
+RegistrantsOf returns a Filter suitable for locating the registrant entries
+associated with the input Registration instance, which must bear a valid
+*DUAConfig instance.
+
+If the *DUAConfig describes dedicated registrant entries (s. 3.4.2.2), each
+'firstAuthority', 'currentAuthority' and 'sponsor' DN value assigned to the
+Registration is reduced to an assertion of its leaf RDN, e.g.:
+
+	(&(objectClass=x660Registrant)(|(registrantID=X)(registrantID=Y)))
+
+If the *DUAConfig describes combined entries (s. 3.4.2.1), the registrant
+information resides within the registration entry itself, and the Filter
+will assert the identity of the registration alongside the x660Registrant
+objectClass. Within the ThreeDimensional model, that identity is merely the
+leaf numberForm (n), which is shared by arcs throughout the registration
+base, e.g.:
+
+	(&(objectClass=x660Registrant)(n=11))
+
+In such cases, the Filter must be used with a baseObject search of the
+DN of the Registration (see the FetchRegistrants function), as a subtree
+search would match the registrants of every arc bearing that leaf.
+
+An error is returned if no registrants can be located using the DUAConfig
+or the Registration's contents.
+*/
+func RegistrantsOf(r Registration) (Filter, error) {
+	if r == nil {
+		return nil, NilRegistrationErr
+	}
+
+	d := r.DUAConfig()
+	if !d.Valid() {
+		return nil, DUAConfigValidityErr
+	} else if len(d.Registrants) == 0 {
+		// s. 3.5: no registrant base means no
+		// registrant info is stored at all.
+		return nil, errorf("No registrant base defined in %T; registrant information is not stored", d)
+	}
+
+	oc := Equal(`objectClass`, `x660Registrant`)
+	if len(d.Registrations) > 0 && eq(d.Registrations[0], d.Registrants[0]) {
+		// combined entries
+		if dot := r.DotNotation(); len(dot) > 0 && d.DirectoryModel == TwoDimensional {
+			return And(oc, Equal(`dotNotation`, dot)), nil
+		} else if n := r.N(); len(n) > 0 {
+			return And(oc, Equal(`n`, n)), nil
+		}
+		return nil, errorf("Cannot identify combined %T entry; no dotNotation or n value", r)
+	}
+
+	var dns []string
+	dns = append(dns, r.FirstAuthority()...)
+	dns = append(dns, r.CurrentAuthority()...)
+	dns = append(dns, r.Sponsor()...)
+
+	var refs []Filter
+	for i := 0; i < len(dns); i++ {
+		at, val, err := leafRDN(dns[i])
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, Equal(at, val))
+	}
+
+	if len(refs) == 0 {
+		return nil, errorf("No registrant references found in %T", r)
+	}
+
+	return And(oc, Or(refs...)), nil
+}
+
+/*
+splitDotNot verifies the input dotNotation value and returns its
+individual numberForm components.
+*/
+func splitDotNot(oid string) (arcs []string, err error) {
+	if len(oid) == 0 {
+		err = InvalidOIDErr
+		return
+	}
+
+	arcs = split(oid, `.`)
+	for i := 0; i < len(arcs); i++ {
+		if !isNumber(arcs[i]) {
+			err = errorf("Bogus numberForm value '%v' (slice[%d])", arcs[i], i)
+			return
+		}
+	}
+
+	if arcs[0] != `0` && arcs[0] != `1` && arcs[0] != `2` {
+		err = IllegalRootErr
+	}
+
+	return
+}
+
+/*
+leafRDN returns the attribute type and (unescaped) value of the leftmost
+RDN within the input string DN. Multi-valued RDNs are not supported.
+*/
+func leafRDN(dn string) (at, val string, err error) {
+	rdn := dn
+	for i := 0; i < len(dn); i++ {
+		if dn[i] == '\\' {
+			i++
+			continue
+		} else if dn[i] == ',' {
+			rdn = dn[:i]
+			break
+		}
+	}
+
+	idx := idxRune(rdn, '=')
+	if idx < 1 || idx == len(rdn)-1 {
+		err = errorf("%v: '%s'", InvalidDNErr, dn)
+		return
+	}
+
+	at = trimS(rdn[:idx])
+	val = unescapeDNValue(trimS(rdn[idx+1:]))
+
+	return
+}
+
+/*
+unescapeDNValue returns the input RDN attribute value with any RFC 4514
+escape sequences (e.g.: `\,` or `\2C`) resolved.
+*/
+func unescapeDNValue(val string) string {
+	if !contains(val, `\`) {
+		return val
+	}
+
+	var raw []byte = make([]byte, 0, len(val))
+	for i := 0; i < len(val); i++ {
+		if val[i] != '\\' || i == len(val)-1 {
+			raw = append(raw, val[i])
+			continue
+		}
+
+		if i+2 < len(val) && isHex(val[i+1]) && isHex(val[i+2]) {
+			raw = append(raw, hexByte(val[i+1])<<4|hexByte(val[i+2]))
+			i += 2
+		} else {
+			raw = append(raw, val[i+1])
+			i++
+		}
+	}
+
+	return string(raw)
+}
+
+func isHex(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func hexByte(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+
+	return c - 'A' + 10
+}
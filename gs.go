@@ -446,3 +446,184 @@ func DNToDotNot3D(X, R any) (id any, err error) {
 	id = join(nfs, `.`)
 	return
 }
+
+/*
+ASN1NotToDotNot returns a dotNotation-based ASN.1 Object Identifier (id)
+based upon the contents of the input ASN.1 Notation value (X) alongside
+an error. This function qualifies for the GetOrSetFunc type signature.
+The second input argument (R) is not used, and may be nil.
+
+Each component must bear a numberForm (e.g.: dod(6) or 6), unless it
+is a well-known arc name in a position where such names are permitted,
+such as iso or identified-organization. Value references cannot be
+resolved by this function; see the ScanASN1 function instead.
+
+For more information about functions such as this one, as well as
+information on writing your own speciality functions/methods, see
+the documentation for the GetOrSetFunc closure type.
+*/
+func ASN1NotToDotNot(X, R any) (id any, err error) {
+	A, ok := X.(string)
+	if !ok {
+		err = errorf("Unsupported ASN.1 Notation type (%T)", X)
+		return
+	}
+
+	var arcs []ASN1Component
+	if arcs, err = parseASN1Notation(A); err == nil {
+		id = asn1Dot(arcs)
+	}
+
+	return
+}
+
+/*
+DotNotToASN1Not returns an ASN.1 Notation value (a) based upon the
+contents of the input dotNotation value (X) alongside an error. This
+function qualifies for the GetOrSetFunc type signature. The second
+input argument (R) is not used, and may be nil.
+
+Names are supplied for the well-known root and second-level arcs only,
+e.g.: {iso(1) identified-organization(3) 6 1}.
+
+For more information about functions such as this one, as well as
+information on writing your own speciality functions/methods, see
+the documentation for the GetOrSetFunc closure type.
+*/
+func DotNotToASN1Not(X, R any) (a any, err error) {
+	O, ok := X.(string)
+	if !ok {
+		err = errorf("Unsupported OID type (%T)", X)
+		return
+	}
+
+	var arcs []string
+	if arcs, err = splitDotNot(O); err != nil {
+		return
+	}
+
+	comps := append([]string{}, arcs...)
+	roots := []string{`itu-t`, `iso`, `joint-iso-itu-t`}
+	if n, _ := atoi(arcs[0]); n < len(roots) {
+		comps[0] = roots[n] + `(` + arcs[0] + `)`
+	}
+	for name, n := range asn1Seconds[arcs[0]] {
+		if len(arcs) > 1 && n == arcs[1] {
+			comps[1] = name + `(` + n + `)`
+		}
+	}
+
+	a = `{` + join(comps, ` `) + `}`
+	return
+}
+
+/*
+IRIToDotNot returns a dotNotation-based ASN.1 Object Identifier (id)
+based upon the contents of the input OID-IRI value (X), such as
+/ISO/Identified-Organization/6/1, alongside an error. This function
+qualifies for the GetOrSetFunc type signature. The second input
+argument (R) is not used, and may be nil.
+
+Numeric labels are always accepted. Non-numeric labels must be those of
+well-known arcs, namely the roots, the second-level arcs beneath ITU-T
+and ISO, and the well-known long arcs beneath Joint-ISO-ITU-T (e.g.:
+/UUID). Labels are matched without regard to case.
+
+For more information about functions such as this one, as well as
+information on writing your own speciality functions/methods, see
+the documentation for the GetOrSetFunc closure type.
+*/
+func IRIToDotNot(X, R any) (id any, err error) {
+	I, ok := X.(string)
+	if !ok {
+		err = errorf("Unsupported OID-IRI type (%T)", X)
+		return
+	} else if !hasPrefix(I, `/`) || len(I) < 2 {
+		err = errorw(InvalidOIDErr, "OID-IRI '%s' is malformed", I)
+		return
+	}
+
+	var nfs []string
+	for i, label := range split(I[1:], `/`) {
+		nf := label
+		switch {
+		case isNumber(label):
+		case i == 0:
+			for n := 0; n < len(iriRoots); n++ {
+				if eq(label, iriRoots[n]) {
+					nf = itoa(n)
+				}
+			}
+			for n, l := range iriLongArcs {
+				if eq(label, l) {
+					nfs, nf = append(nfs, `2`), n
+				}
+			}
+		case len(nfs) == 1 && nfs[0] == `2`:
+			for n, l := range iriLongArcs {
+				if eq(label, l) {
+					nf = n
+				}
+			}
+		case len(nfs) == 1:
+			for n, l := range iriSeconds[nfs[0]] {
+				if eq(label, l) {
+					nf = n
+				}
+			}
+		}
+
+		if !isNumber(nf) {
+			err = errorw(InvalidOIDErr, "OID-IRI label '%s' is not well-known", label)
+			return
+		}
+		nfs = append(nfs, nf)
+	}
+
+	id = join(nfs, `.`)
+	return
+}
+
+/*
+DotNotToIRI returns an OID-IRI value (iri) based upon the contents of
+the input dotNotation value (X) alongside an error. This function
+qualifies for the GetOrSetFunc type signature. The second input
+argument (R) is not used, and may be nil.
+
+Unicode labels are supplied for well-known arcs only (see IRIToDotNot),
+and numeric labels are used for all others, e.g.:
+/ISO/Identified-Organization/6/1. Well-known long arcs beneath
+Joint-ISO-ITU-T are used as the first label, e.g.: /UUID/1234.
+
+For more information about functions such as this one, as well as
+information on writing your own speciality functions/methods, see
+the documentation for the GetOrSetFunc closure type.
+*/
+func DotNotToIRI(X, R any) (iri any, err error) {
+	O, ok := X.(string)
+	if !ok {
+		err = errorf("Unsupported OID type (%T)", X)
+		return
+	}
+
+	var arcs []string
+	if arcs, err = splitDotNot(O); err != nil {
+		return
+	}
+
+	labels := append([]string{}, arcs...)
+	if n, _ := atoi(arcs[0]); n < len(iriRoots) {
+		labels[0] = iriRoots[n]
+	}
+	if len(arcs) > 1 {
+		if l, found := iriLongArcs[arcs[1]]; found && arcs[0] == `2` {
+			labels = labels[1:]
+			labels[0] = l
+		} else if l, found = iriSeconds[arcs[0]][arcs[1]]; found {
+			labels[1] = l
+		}
+	}
+
+	iri = `/` + join(labels, `/`)
+	return
+}
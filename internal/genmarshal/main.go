@@ -0,0 +1,381 @@
+/*
+Command genmarshal generates reflection-free unmarshal, marshal,
+multiValued, attributeTypes, getAttr and setAttr methods for the entry types of package dcxl, using the `ldap` struct tags found
+within type.go and the alternative attribute type names found within the
+altnames map of util.go. It also generates the Field constants, one per
+distinct attribute type, in order of appearance.
+
+It is invoked by way of go generate from the package directory:
+
+	go generate github.com/JesseCoretta/go-dcxl
+
+The output, marshal_gen.go, must not be edited by hand.
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	typesFile = flag.String(`types`, `type.go`, `file containing the struct types`)
+	utilFile  = flag.String(`util`, `util.go`, `file containing the altnames map`)
+	output    = flag.String(`o`, `marshal_gen.go`, `output file`)
+	typeNames = flag.String(`t`, `RootArc,SubArc,FirstAuthority,CurrentAuthority,Sponsor,DUAConfig`,
+		`comma-separated struct type names`)
+)
+
+/*
+field describes a single `ldap`-tagged struct field.
+*/
+type field struct {
+	name  string   // e.g.: R_N
+	attr  string   // e.g.: n
+	alts  []string // e.g.: numberForm
+	slice bool     // []string, else string
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix(`genmarshal: `)
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	tf, err := parser.ParseFile(fset, *typesFile, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	uf, err := parser.ParseFile(fset, *utilFile, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	alts := altnames(uf)
+	structs := structTypes(tf)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genmarshal from %s; DO NOT EDIT.\n\n", *typesFile)
+	fmt.Fprintf(&buf, "package %s\n", tf.Name.Name)
+	fmt.Fprintf(&buf, "\n/*\nmarshal_gen.go contains reflection-free unmarshal, marshal,\nmultiValued, attributeTypes, getAttr and setAttr methods generated from the `ldap` struct tags of the entry types, as well as the Field constants.\n*/\n")
+
+	var attrs []string
+	seen := make(map[string]bool)
+
+	for _, name := range strings.Split(*typeNames, `,`) {
+		st, found := structs[name]
+		if !found {
+			log.Fatalf("struct type %s not found in %s", name, *typesFile)
+		}
+
+		fields, err := tagged(st, alts)
+		if err != nil {
+			log.Fatalf("%s: %v", name, err)
+		}
+		writeUnmarshal(&buf, name, fields)
+		writeMarshal(&buf, name, fields)
+		writeMultiValued(&buf, name, fields)
+		writeAttributeTypes(&buf, name, fields)
+		writeGetAttr(&buf, name, fields)
+		writeSetAttr(&buf, name, fields)
+
+		for _, f := range fields {
+			if f.attr != `dn` && !seen[f.attr] {
+				seen[f.attr] = true
+				attrs = append(attrs, f.attr)
+			}
+		}
+	}
+	writeFields(&buf, attrs)
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting output: %v\n%s", err, buf.Bytes())
+	}
+
+	if err = os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+/*
+altnames returns the contents of the altnames map literal, keyed by the
+preferred name and valued with the alternative names.
+*/
+func altnames(f *ast.File) (alts map[string][]string) {
+	alts = make(map[string][]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		vs, ok := n.(*ast.ValueSpec)
+		if !ok || len(vs.Names) != 1 || vs.Names[0].Name != `altnames` || len(vs.Values) != 1 {
+			return true
+		}
+
+		cl, ok := vs.Values[0].(*ast.CompositeLit)
+		if !ok {
+			return false
+		}
+
+		for _, elt := range cl.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				continue
+			}
+			alt, pref := unquote(kv.Key), unquote(kv.Value)
+			alts[pref] = append(alts[pref], alt)
+		}
+
+		return false
+	})
+
+	return
+}
+
+/*
+structTypes returns all struct types declared within the input file.
+*/
+func structTypes(f *ast.File) (m map[string]*ast.StructType) {
+	m = make(map[string]*ast.StructType)
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				m[ts.Name.Name] = st
+			}
+		}
+	}
+
+	return
+}
+
+/*
+tagged returns the `ldap`-tagged fields of the input struct type, in
+declaration order. Only string and []string fields may bear tags.
+*/
+func tagged(st *ast.StructType, alts map[string][]string) (fields []field, err error) {
+	for _, f := range st.Fields.List {
+		if f.Tag == nil {
+			continue
+		}
+
+		tag, found := reflect.StructTag(unquote(f.Tag)).Lookup(`ldap`)
+		if !found {
+			continue
+		}
+		attr := strings.Split(tag, `,`)[0]
+
+		var slice bool
+		switch t := f.Type.(type) {
+		case *ast.Ident:
+			if t.Name != `string` {
+				return nil, fmt.Errorf("field %s: unsupported type %s", f.Names[0].Name, t.Name)
+			}
+		case *ast.ArrayType:
+			if id, ok := t.Elt.(*ast.Ident); !ok || id.Name != `string` || t.Len != nil {
+				return nil, fmt.Errorf("field %s: unsupported array type", f.Names[0].Name)
+			}
+			slice = true
+		default:
+			return nil, fmt.Errorf("field %s: unsupported type", f.Names[0].Name)
+		}
+
+		for _, n := range f.Names {
+			fields = append(fields, field{name: n.Name, attr: attr, alts: alts[attr], slice: slice})
+		}
+	}
+
+	return
+}
+
+/*
+writeUnmarshal writes the unmarshal method for the named type, which
+mirrors the output of the reflective toMap function.
+*/
+func writeUnmarshal(buf *bytes.Buffer, name string, fields []field) {
+	fmt.Fprintf(buf, "\nfunc (r %s) unmarshal() map[string][]string {\n", name)
+	fmt.Fprintf(buf, "\tm := make(map[string][]string, %d)\n", len(fields)+1)
+	fmt.Fprintf(buf, "\tm[`objectClass`] = []string{`top`, r.ObjectClass()}\n")
+	for _, f := range fields {
+		if f.attr == `dn` {
+			continue // DN is not an attribute
+		}
+
+		if f.slice {
+			fmt.Fprintf(buf, "\tif r.%s != nil {\n\t\tm[%s] = r.%s\n\t}\n", f.name, quote(f.attr), f.name)
+		} else {
+			fmt.Fprintf(buf, "\tif len(r.%s) > 0 {\n\t\tm[%s] = []string{r.%s}\n\t}\n", f.name, quote(f.attr), f.name)
+		}
+	}
+	fmt.Fprintf(buf, "\n\treturn m\n}\n")
+}
+
+/*
+writeMarshal writes the marshal method for the named type, which mirrors
+the behavior of the reflective fromMap function. Keys are matched exactly
+before falling back to case-insensitive matching, which spares an
+allocation for the common case.
+*/
+func writeMarshal(buf *bytes.Buffer, name string, fields []field) {
+	fmt.Fprintf(buf, "\nfunc (r *%s) marshal(m map[string][]string) {\n", name)
+	fmt.Fprintf(buf, "\tfor k, v := range m {\n\t\tif len(v) == 0 {\n\t\t\tcontinue\n\t\t}\n\n")
+	fmt.Fprintf(buf, "\t\tswitch k {\n")
+	writeCases(buf, fields, func(s string) string { return s })
+	fmt.Fprintf(buf, "\t\tdefault:\n\t\t\tswitch lc(k) {\n")
+	writeCases(buf, fields, strings.ToLower)
+	fmt.Fprintf(buf, "\t\t\t}\n\t\t}\n\t}\n}\n")
+}
+
+/*
+writeMultiValued writes the multiValued method for the named type, which
+reports whether the input attribute type, as named by unmarshal, is held
+within a []string field.
+*/
+func writeMultiValued(buf *bytes.Buffer, name string, fields []field) {
+	var multi []string
+	for _, f := range fields {
+		if f.slice {
+			multi = append(multi, quote(f.attr))
+		}
+	}
+
+	fmt.Fprintf(buf, "\nfunc (r %s) multiValued(at string) bool {\n", name)
+	if len(multi) > 0 {
+		fmt.Fprintf(buf, "\tswitch at {\n\tcase %s:\n\t\treturn true\n\t}\n\n", strings.Join(multi, `, `))
+	}
+	fmt.Fprintf(buf, "\treturn false\n}\n")
+}
+
+/*
+writeAttributeTypes writes the attributeTypes method for the named type,
+which returns the names of the attribute types held within its tagged
+fields, in declaration order.
+*/
+func writeAttributeTypes(buf *bytes.Buffer, name string, fields []field) {
+	var names []string
+	for _, f := range fields {
+		if f.attr != `dn` {
+			names = append(names, quote(f.attr))
+		}
+	}
+
+	fmt.Fprintf(buf, "\nfunc (r %s) attributeTypes() []string {\n", name)
+	fmt.Fprintf(buf, "\treturn []string{%s}\n}\n", strings.Join(names, `, `))
+}
+
+/*
+writeGetAttr writes the getAttr method for the named type, which returns
+a copy of the value(s) of the input attribute type, as named by
+attributeTypes, or nil if unset.
+*/
+func writeGetAttr(buf *bytes.Buffer, name string, fields []field) {
+	fmt.Fprintf(buf, "\nfunc (r %s) getAttr(at string) []string {\n\tswitch at {\n", name)
+	for _, f := range fields {
+		if f.attr == `dn` {
+			continue
+		}
+
+		fmt.Fprintf(buf, "\tcase %s:\n", quote(f.attr))
+		if f.slice {
+			fmt.Fprintf(buf, "\t\treturn append([]string(nil), r.%s...)\n", f.name)
+		} else {
+			fmt.Fprintf(buf, "\t\tif len(r.%s) > 0 {\n\t\t\treturn []string{r.%s}\n\t\t}\n", f.name, f.name)
+		}
+	}
+	fmt.Fprintf(buf, "\t}\n\n\treturn nil\n}\n")
+}
+
+/*
+writeSetAttr writes the setAttr method for the named type, which replaces
+the value(s) of the input attribute type, as named by attributeTypes. A
+single-valued field receives the first value, if any.
+*/
+func writeSetAttr(buf *bytes.Buffer, name string, fields []field) {
+	fmt.Fprintf(buf, "\nfunc (r *%s) setAttr(at string, v []string) {\n\tswitch at {\n", name)
+	for _, f := range fields {
+		if f.attr == `dn` {
+			continue
+		}
+
+		fmt.Fprintf(buf, "\tcase %s:\n", quote(f.attr))
+		if f.slice {
+			fmt.Fprintf(buf, "\t\tr.%s = append([]string(nil), v...)\n", f.name)
+		} else {
+			fmt.Fprintf(buf, "\t\tr.%s = ``\n\t\tif len(v) > 0 {\n\t\t\tr.%s = v[0]\n\t\t}\n", f.name, f.name)
+		}
+	}
+	fmt.Fprintf(buf, "\t}\n}\n")
+}
+
+/*
+writeFields writes a Field constant for each of the input attribute types,
+as well as the fieldNames array used to name them.
+*/
+func writeFields(buf *bytes.Buffer, attrs []string) {
+	fmt.Fprintf(buf, "\nconst (\n\t_ Field = iota\n")
+	for _, at := range attrs {
+		fmt.Fprintf(buf, "\t%s // %s\n", fieldConst(at), at)
+	}
+	fmt.Fprintf(buf, ")\n\nvar fieldNames = [...]string{\n")
+	for _, at := range attrs {
+		fmt.Fprintf(buf, "\t%s: %s,\n", fieldConst(at), quote(at))
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+/*
+fieldConst returns the name of the Field constant of the input attribute
+type, e.g.: FieldDotNotation for dotNotation, or FieldASN1Notation for
+asn1Notation.
+*/
+func fieldConst(at string) string {
+	if strings.HasPrefix(at, `asn1`) {
+		return `FieldASN1` + at[4:]
+	}
+
+	return `Field` + strings.ToUpper(at[:1]) + at[1:]
+}
+
+/*
+writeCases writes a switch case for each field, using the input function
+to transform the attribute type names.
+*/
+func writeCases(buf *bytes.Buffer, fields []field, fn func(string) string) {
+	for _, f := range fields {
+		cases := []string{quote(fn(f.attr))}
+		for _, alt := range f.alts {
+			cases = append(cases, quote(fn(alt)))
+		}
+
+		fmt.Fprintf(buf, "\t\tcase %s:\n", strings.Join(cases, `, `))
+		if f.slice {
+			fmt.Fprintf(buf, "\t\t\tr.%s = append([]string{}, v...)\n", f.name)
+		} else {
+			fmt.Fprintf(buf, "\t\t\tr.%s = v[0]\n", f.name)
+		}
+	}
+}
+
+func quote(s string) string {
+	return "`" + s + "`"
+}
+
+func unquote(x ast.Expr) (s string) {
+	if bl, ok := x.(*ast.BasicLit); ok && bl.Kind == token.STRING {
+		s, _ = strconv.Unquote(bl.Value)
+	}
+
+	return
+}
@@ -0,0 +1,497 @@
+package dcxl
+
+/*
+json.go contains the JSON encoding and decoding methods of the entry
+types, as well as the polymorphic decoding of Registrations and
+Registrants instances.
+*/
+
+import "encoding/json"
+
+/*
+The following keys are used for values that have no corresponding
+attribute type within the ID.
+*/
+const (
+	jsonCombinedFirst   = `combinedFirstAuthority`   // R_FAuthy
+	jsonCombinedCurrent = `combinedCurrentAuthority` // R_CAuthy
+	jsonCombinedSponsor = `combinedSponsor`          // R_SAuthy
+	jsonDUAConfig       = `duaConfig`                // R_DUAConfig
+	jsonRegistrantType  = `registrantType`           // Registrant.Type
+	jsonSettings        = `settings`                 // DUAConfig.Settings
+)
+
+/*
+jsonObject returns a map suitable for JSON encoding, keyed by the names
+of the attribute types within the input map, per the output of an
+unmarshal method. Values of attribute types held within string fields
+are encoded as JSON strings; the remainder are encoded as arrays.
+*/
+func jsonObject(dn string, m map[string][]string, multi func(string) bool) map[string]any {
+	obj := make(map[string]any, len(m)+1)
+	if len(dn) > 0 {
+		obj[`dn`] = dn
+	}
+
+	for k, v := range m {
+		if k == `objectClass` || multi(k) {
+			obj[k] = v
+		} else if len(v) > 0 {
+			obj[k] = v[0]
+		}
+	}
+
+	return obj
+}
+
+/*
+jsonFields decodes the input JSON object into a map of attribute values,
+per the input of a marshal method. Each value may be a JSON string or an
+array of strings. The raw values of the named keys, which do not describe
+attribute types, are returned separately.
+*/
+func jsonFields(b []byte, keys ...string) (m map[string][]string, other map[string]json.RawMessage, err error) {
+	var raw map[string]json.RawMessage
+	if err = json.Unmarshal(b, &raw); err != nil {
+		return
+	}
+
+	m = make(map[string][]string, len(raw))
+	other = make(map[string]json.RawMessage)
+	for k, v := range raw {
+		if strInSlice(k, keys) {
+			other[k] = v
+			continue
+		}
+
+		var s string
+		var vals []string
+		if err = json.Unmarshal(v, &s); err == nil {
+			m[k] = []string{s}
+		} else if err = json.Unmarshal(v, &vals); err == nil {
+			m[k] = vals
+		} else {
+			err = errorf("%s: expected string or array of strings", k)
+			return
+		}
+	}
+
+	return
+}
+
+/*
+jsonCheckClass returns an error if the input map bears objectClass values,
+none of which are the expected objectClass.
+*/
+func jsonCheckClass(m map[string][]string, oc string, sentinel error) error {
+	if ocs := mapValues(m, `objectClass`); len(ocs) > 0 && !strInSlice(oc, ocs) {
+		return errorw(sentinel, "objectClass '%s' not found", oc)
+	}
+
+	return nil
+}
+
+/*
+jsonNested decodes the named value within the input map, if present,
+into the input pointer.
+*/
+func jsonNested(other map[string]json.RawMessage, key string, x any) (found bool, err error) {
+	var raw json.RawMessage
+	if raw, found = other[key]; found && string(raw) != `null` {
+		err = json.Unmarshal(raw, x)
+	} else {
+		found = false
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included. COMBINED registrants and any *DUAConfig
+instance are encoded as nested objects.
+*/
+func (r RootArc) MarshalJSON() ([]byte, error) {
+	obj := jsonObject(r.R_DN, r.unmarshal(), r.multiValued)
+	if r.R_FAuthy != nil {
+		obj[jsonCombinedFirst] = r.R_FAuthy
+	}
+	if r.R_CAuthy != nil {
+		obj[jsonCombinedCurrent] = r.R_CAuthy
+	}
+	if r.R_DUAConfig != nil {
+		obj[jsonDUAConfig] = r.R_DUAConfig
+	}
+
+	return json.Marshal(obj)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver. An error is returned if the JSON
+bears objectClass values which do not include x660RootArc.
+*/
+func (r *RootArc) UnmarshalJSON(b []byte) error {
+	m, other, err := jsonFields(b, jsonCombinedFirst, jsonCombinedCurrent, jsonDUAConfig)
+	if err != nil {
+		return err
+	} else if err = jsonCheckClass(m, r.ObjectClass(), RegistrationValidityErr); err != nil {
+		return err
+	}
+
+	var x RootArc
+	x.marshal(m)
+
+	var found bool
+	fa, ca, d := new(FirstAuthority), new(CurrentAuthority), new(DUAConfig)
+	if found, err = jsonNested(other, jsonCombinedFirst, fa); err != nil {
+		return err
+	} else if found {
+		x.R_FAuthy = fa
+	}
+	if found, err = jsonNested(other, jsonCombinedCurrent, ca); err != nil {
+		return err
+	} else if found {
+		x.R_CAuthy = ca
+	}
+	if found, err = jsonNested(other, jsonDUAConfig, d); err != nil {
+		return err
+	} else if found {
+		x.R_DUAConfig = d
+	}
+
+	*r = x
+
+	return nil
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included. COMBINED registrants and any *DUAConfig
+instance are encoded as nested objects.
+*/
+func (r SubArc) MarshalJSON() ([]byte, error) {
+	obj := jsonObject(r.R_DN, r.unmarshal(), r.multiValued)
+	if r.R_FAuthy != nil {
+		obj[jsonCombinedFirst] = r.R_FAuthy
+	}
+	if r.R_CAuthy != nil {
+		obj[jsonCombinedCurrent] = r.R_CAuthy
+	}
+	if r.R_SAuthy != nil {
+		obj[jsonCombinedSponsor] = r.R_SAuthy
+	}
+	if r.R_DUAConfig != nil {
+		obj[jsonDUAConfig] = r.R_DUAConfig
+	}
+
+	return json.Marshal(obj)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver. An error is returned if the JSON
+bears objectClass values which do not include x660SubArc.
+*/
+func (r *SubArc) UnmarshalJSON(b []byte) error {
+	m, other, err := jsonFields(b, jsonCombinedFirst, jsonCombinedCurrent,
+		jsonCombinedSponsor, jsonDUAConfig)
+	if err != nil {
+		return err
+	} else if err = jsonCheckClass(m, r.ObjectClass(), RegistrationValidityErr); err != nil {
+		return err
+	}
+
+	var x SubArc
+	x.marshal(m)
+
+	var found bool
+	fa, ca, sa, d := new(FirstAuthority), new(CurrentAuthority), new(Sponsor), new(DUAConfig)
+	if found, err = jsonNested(other, jsonCombinedFirst, fa); err != nil {
+		return err
+	} else if found {
+		x.R_FAuthy = fa
+	}
+	if found, err = jsonNested(other, jsonCombinedCurrent, ca); err != nil {
+		return err
+	} else if found {
+		x.R_CAuthy = ca
+	}
+	if found, err = jsonNested(other, jsonCombinedSponsor, sa); err != nil {
+		return err
+	} else if found {
+		x.R_SAuthy = sa
+	}
+	if found, err = jsonNested(other, jsonDUAConfig, d); err != nil {
+		return err
+	} else if found {
+		x.R_DUAConfig = d
+	}
+
+	*r = x
+
+	return nil
+}
+
+/*
+registrantJSON returns the JSON encoding of a Registrant. As the
+x660Registrant objectClass is shared by all Registrant types, the value
+of the Type method (typ) is included using the registrantType key,
+allowing polymorphic decoding.
+*/
+func registrantJSON(typ, dn string, m map[string][]string, multi func(string) bool, d *DUAConfig) ([]byte, error) {
+	obj := jsonObject(dn, m, multi)
+	obj[jsonRegistrantType] = typ
+	if d != nil {
+		obj[jsonDUAConfig] = d
+	}
+
+	return json.Marshal(obj)
+}
+
+/*
+registrantFromJSON decodes the input JSON into a map suitable for the
+marshal method of the Registrant type named by typ, alongside any nested
+*DUAConfig instance. An error is returned if the JSON describes another
+Registrant type.
+*/
+func registrantFromJSON(b []byte, typ string) (m map[string][]string, d *DUAConfig, err error) {
+	var other map[string]json.RawMessage
+	if m, other, err = jsonFields(b, jsonRegistrantType, jsonDUAConfig); err != nil {
+		return
+	} else if err = jsonCheckClass(m, `x660Registrant`, RegistrantValidityErr); err != nil {
+		return
+	}
+
+	if raw, found := other[jsonRegistrantType]; found {
+		var t string
+		if err = json.Unmarshal(raw, &t); err != nil {
+			return
+		} else if !eq(t, typ) {
+			err = errorw(RegistrantValidityErr, "%s registrant, not %s", t, typ)
+			return
+		}
+	}
+
+	var found bool
+	d = new(DUAConfig)
+	if found, err = jsonNested(other, jsonDUAConfig, d); !found {
+		d = nil
+	}
+
+	return
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included, as is the value of the Type method by
+way of the registrantType key. Any *DUAConfig instance is encoded as a
+nested object.
+*/
+func (r FirstAuthority) MarshalJSON() ([]byte, error) {
+	return registrantJSON(r.Type(), r.R_DN, r.unmarshal(), r.multiValued, r.R_DUAConfig)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver.
+*/
+func (r *FirstAuthority) UnmarshalJSON(b []byte) error {
+	m, d, err := registrantFromJSON(b, r.Type())
+	if err == nil {
+		var x FirstAuthority
+		x.marshal(m)
+		x.R_DUAConfig = d
+		*r = x
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included, as is the value of the Type method by
+way of the registrantType key. Any *DUAConfig instance is encoded as a
+nested object.
+*/
+func (r CurrentAuthority) MarshalJSON() ([]byte, error) {
+	return registrantJSON(r.Type(), r.R_DN, r.unmarshal(), r.multiValued, r.R_DUAConfig)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver.
+*/
+func (r *CurrentAuthority) UnmarshalJSON(b []byte) error {
+	m, d, err := registrantFromJSON(b, r.Type())
+	if err == nil {
+		var x CurrentAuthority
+		x.marshal(m)
+		x.R_DUAConfig = d
+		*r = x
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included, as is the value of the Type method by
+way of the registrantType key. Any *DUAConfig instance is encoded as a
+nested object.
+*/
+func (r Sponsor) MarshalJSON() ([]byte, error) {
+	return registrantJSON(r.Type(), r.R_DN, r.unmarshal(), r.multiValued, r.R_DUAConfig)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver.
+*/
+func (r *Sponsor) UnmarshalJSON(b []byte) error {
+	m, d, err := registrantFromJSON(b, r.Type())
+	if err == nil {
+		var x Sponsor
+		x.marshal(m)
+		x.R_DUAConfig = d
+		*r = x
+	}
+
+	return err
+}
+
+/*
+MarshalJSON returns the JSON encoding of the receiver, alongside an error.
+Attribute types are keyed using the names defined in the ID, and the
+objectClass values are included. The Settings field, if non-nil, is
+encoded using the settings key.
+*/
+func (r DUAConfig) MarshalJSON() ([]byte, error) {
+	obj := jsonObject(``, r.unmarshal(), r.multiValued)
+	if r.Settings != nil {
+		obj[jsonSettings] = r.Settings
+	}
+
+	return json.Marshal(obj)
+}
+
+/*
+UnmarshalJSON decodes the input JSON, such as that produced by the
+MarshalJSON method, into the receiver.
+*/
+func (r *DUAConfig) UnmarshalJSON(b []byte) error {
+	m, other, err := jsonFields(b, jsonSettings)
+	if err != nil {
+		return err
+	} else if err = jsonCheckClass(m, r.ObjectClass(), DUAConfigValidityErr); err != nil {
+		return err
+	}
+
+	var x DUAConfig
+	x.marshal(m)
+	if _, err = jsonNested(other, jsonSettings, &x.Settings); err == nil {
+		*r = x
+	}
+
+	return err
+}
+
+/*
+UnmarshalJSON decodes the input JSON array into the receiver, such as
+that produced by encoding a Registrations instance. The objectClass values
+of each element determine whether a *RootArc or *SubArc is produced. A
+null element produces a nil Registration.
+*/
+func (r *Registrations) UnmarshalJSON(b []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return err
+	}
+
+	regs := make(Registrations, len(raws))
+	for i := 0; i < len(raws); i++ {
+		if string(raws[i]) == `null` {
+			continue
+		}
+
+		var peek struct {
+			OC []string `json:"objectClass"`
+		}
+		if err := json.Unmarshal(raws[i], &peek); err != nil {
+			return err
+		}
+
+		switch {
+		case strInSlice(RootArc{}.ObjectClass(), peek.OC):
+			regs[i] = new(RootArc)
+		case strInSlice(SubArc{}.ObjectClass(), peek.OC):
+			regs[i] = new(SubArc)
+		default:
+			return errorw(RegistrationValidityErr, "element %d: unknown objectClass %v", i, peek.OC)
+		}
+
+		if err := json.Unmarshal(raws[i], regs[i]); err != nil {
+			return err
+		}
+	}
+	*r = regs
+
+	return nil
+}
+
+/*
+UnmarshalJSON decodes the input JSON array into the receiver, such as
+that produced by encoding a Registrants instance. The registrantType value
+of each element determines whether a *FirstAuthority, *CurrentAuthority
+or *Sponsor is produced. Lacking that, the prefixes of the attribute
+types present are used (e.g.: sponsorOrg). A null element produces a nil
+Registrant.
+*/
+func (r *Registrants) UnmarshalJSON(b []byte) error {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(b, &raws); err != nil {
+		return err
+	}
+
+	rants := make(Registrants, len(raws))
+	for i := 0; i < len(raws); i++ {
+		if string(raws[i]) == `null` {
+			continue
+		}
+
+		m, other, err := jsonFields(raws[i], jsonRegistrantType, jsonDUAConfig)
+		if err != nil {
+			return err
+		}
+
+		var typ string
+		if raw, found := other[jsonRegistrantType]; found {
+			if err = json.Unmarshal(raw, &typ); err != nil {
+				return err
+			}
+		}
+
+		switch {
+		case eq(typ, FirstAuthority{}.Type()) || len(typ) == 0 && hasPrefixedAttr(m, `firstAuthority`):
+			rants[i] = new(FirstAuthority)
+		case eq(typ, CurrentAuthority{}.Type()) || len(typ) == 0 && hasPrefixedAttr(m, `currentAuthority`):
+			rants[i] = new(CurrentAuthority)
+		case eq(typ, Sponsor{}.Type()) || len(typ) == 0 && hasPrefixedAttr(m, `sponsor`):
+			rants[i] = new(Sponsor)
+		default:
+			return errorw(RegistrantValidityErr, "element %d: unknown registrant type", i)
+		}
+
+		if err = json.Unmarshal(raws[i], rants[i]); err != nil {
+			return err
+		}
+	}
+	*r = rants
+
+	return nil
+}
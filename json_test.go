@@ -0,0 +1,62 @@
+package dcxl
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+TestJSONRoundTrip verifies that Registrations and Registrants instances,
+including COMBINED registrants and *DUAConfig instances, survive a JSON
+round trip intact.
+*/
+func TestJSONRoundTrip(t *testing.T) {
+	xs := marshalTypes()
+	root, sub := xs[0].(*RootArc), xs[1].(*SubArc)
+	fa, ca, sp := xs[2].(*FirstAuthority), xs[3].(*CurrentAuthority), xs[4].(*Sponsor)
+	d := xs[5].(*DUAConfig)
+	d.Settings = map[string][]string{`key`: {`value`}}
+
+	sub.R_FAuthy, sub.R_CAuthy, sub.R_SAuthy = fa, ca, sp
+	sub.R_DUAConfig = d
+
+	regs := Registrations{root, sub, nil}
+	b, err := json.Marshal(regs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var regs2 Registrations
+	if err = json.Unmarshal(b, &regs2); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(regs, regs2) {
+		t.Errorf("Registrations mismatch:\n got %#v\nwant %#v", regs2, regs)
+	}
+
+	rants := Registrants{fa, ca, sp}
+	if b, err = json.Marshal(rants); err != nil {
+		t.Fatal(err)
+	}
+
+	var rants2 Registrants
+	if err = json.Unmarshal(b, &rants2); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(rants, rants2) {
+		t.Errorf("Registrants mismatch:\n got %#v\nwant %#v", rants2, rants)
+	}
+
+	var root2 RootArc
+	if err = json.Unmarshal([]byte(`{"objectClass":["top","x660SubArc"]}`), &root2); !isErr(err, RegistrationValidityErr) {
+		t.Errorf("expected %v, got %v", RegistrationValidityErr, err)
+	}
+
+	var fa2 FirstAuthority
+	err = json.Unmarshal([]byte(`{"objectClass":["top","x660Registrant"],"registrantType":"50%x"}`), &fa2)
+	if !isErr(err, RegistrantValidityErr) {
+		t.Errorf("expected %v, got %v", RegistrantValidityErr, err)
+	} else if want := `50%x registrant, not firstAuthority`; !strings.Contains(err.Error(), want) {
+		t.Errorf("expected '%s' within '%v'", want, err)
+	}
+}
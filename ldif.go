@@ -0,0 +1,187 @@
+package dcxl
+
+/*
+ldif.go contains the reader and writer of LDIF (RFC 2849) content records,
+allowing Entry instances to be exchanged with common directory tooling.
+*/
+
+import (
+	"bufio"
+	"encoding/base64"
+	"io"
+	"sort"
+)
+
+/*
+WriteLDIF writes the input Entry instances upon the input io.Writer as
+LDIF content records, preceded by a version-spec, returning an error if
+writing fails. Within each record, objectClass values are written first,
+followed by all other attribute types in alphabetical order. Unsafe
+values are base64-encoded, and long lines are folded.
+*/
+func WriteLDIF(w io.Writer, entries ...Entry) error {
+	ew := &errWriter{w: w}
+	ew.line(`version: 1`)
+
+	for _, e := range entries {
+		ew.line(``)
+		ew.attr(`dn`, e.DN)
+		for _, v := range e.Attributes[`objectClass`] {
+			ew.attr(`objectClass`, v)
+		}
+
+		keys := make([]string, 0, len(e.Attributes))
+		for k := range e.Attributes {
+			if k != `objectClass` {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			for _, v := range e.Attributes[k] {
+				ew.attr(k, v)
+			}
+		}
+	}
+
+	return ew.err
+}
+
+/*
+ReadLDIF returns Entry instances following an attempt to read LDIF
+content records from the input io.Reader, alongside an error. Folded
+lines, comments, base64-encoded values and an optional version-spec
+are supported.
+
+An error is returned if the input is malformed, or if it contains change
+records or URL-referenced values (attrval-spec ":<"), neither of which
+are supported.
+*/
+func ReadLDIF(rd io.Reader) (entries []Entry, err error) {
+	sc := bufio.NewScanner(rd)
+	sc.Buffer(nil, 16<<20)
+
+	var (
+		lines   []string // unfolded lines of the current record
+		starts  []int    // starting line number of each
+		line    int
+		comment bool // within a comment, which may be folded
+	)
+
+	flush := func() error {
+		if len(lines) == 0 {
+			return nil
+		}
+		e, ferr := ldifRecord(lines, starts, len(entries) == 0)
+		lines, starts = lines[:0], starts[:0]
+		if ferr == nil && len(e.DN) > 0 {
+			entries = append(entries, e)
+		}
+		return ferr
+	}
+
+	for sc.Scan() {
+		line++
+		s := trimR(sc.Text(), "\r")
+		switch {
+		case len(s) == 0:
+			comment = false
+			if err = flush(); err != nil {
+				return
+			}
+		case s[0] == ' ':
+			// Continuation of the preceding line.
+			if comment {
+				continue
+			} else if len(lines) == 0 {
+				err = errorf("LDIF line %d: unexpected continuation", line)
+				return
+			}
+			lines[len(lines)-1] += s[1:]
+		case s[0] == '#':
+			comment = true
+		default:
+			comment = false
+			lines, starts = append(lines, s), append(starts, line)
+		}
+	}
+
+	if err = sc.Err(); err == nil {
+		err = flush()
+	}
+
+	return
+}
+
+/*
+ldifRecord returns the Entry described by the input unfolded lines of a
+single LDIF record, alongside an error. If first is true, a leading
+version-spec is permitted.
+*/
+func ldifRecord(lines []string, starts []int, first bool) (e Entry, err error) {
+	e.Attributes = make(map[string][]string)
+	for i, s := range lines {
+		var at, val string
+		if at, val, err = ldifAttrVal(s); err != nil {
+			err = errorf("LDIF line %d: %v", starts[i], err)
+			return
+		}
+
+		switch {
+		case i == 0 && first && eq(at, `version`):
+			if val != `1` {
+				err = errorf("LDIF line %d: unsupported version '%s'", starts[i], val)
+				return
+			}
+			first = false
+			continue
+		case eq(at, `dn`):
+			if len(e.DN) > 0 || len(e.Attributes) > 0 {
+				err = errorf("LDIF line %d: unexpected dn", starts[i])
+				return
+			}
+			e.DN = val
+			continue
+		case len(e.DN) == 0:
+			err = errorf("LDIF line %d: record does not begin with a dn", starts[i])
+			return
+		case eq(at, `changetype`):
+			err = errorf("LDIF line %d: change records are not supported", starts[i])
+			return
+		}
+
+		e.Attributes[at] = append(e.Attributes[at], val)
+	}
+
+	return
+}
+
+/*
+ldifAttrVal returns the attribute type and decoded value of the input
+unfolded attrval-spec.
+*/
+func ldifAttrVal(s string) (at, val string, err error) {
+	idx := idxRune(s, ':')
+	if idx < 1 {
+		err = errorf("malformed attrval-spec '%s'", s)
+		return
+	}
+
+	at, val = s[:idx], s[idx+1:]
+	switch {
+	case hasPrefix(val, `:`):
+		var b []byte
+		if b, err = base64.StdEncoding.DecodeString(trimS(val[1:])); err != nil {
+			err = errorf("%s: invalid base64 value", at)
+			return
+		}
+		val = string(b)
+	case hasPrefix(val, `<`):
+		err = errorf("%s: URL-referenced values are not supported", at)
+	default:
+		val = trimL(val, ` `)
+	}
+
+	return
+}
@@ -0,0 +1,2083 @@
+// Code generated by genmarshal from type.go; DO NOT EDIT.
+
+package dcxl
+
+/*
+marshal_gen.go contains reflection-free unmarshal, marshal,
+multiValued, attributeTypes, getAttr and setAttr methods generated from the `ldap` struct tags of the entry types, as well as the Field constants.
+*/
+
+func (r RootArc) unmarshal() map[string][]string {
+	m := make(map[string][]string, 20)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.R_N) > 0 {
+		m[`n`] = []string{r.R_N}
+	}
+	if len(r.R_Desc) > 0 {
+		m[`description`] = []string{r.R_Desc}
+	}
+	if len(r.R_ASN1Not) > 0 {
+		m[`asn1Notation`] = []string{r.R_ASN1Not}
+	}
+	if len(r.R_Id) > 0 {
+		m[`identifier`] = []string{r.R_Id}
+	}
+	if len(r.R_Created) > 0 {
+		m[`registrationCreated`] = []string{r.R_Created}
+	}
+	if len(r.R_NaNF) > 0 {
+		m[`nameAndNumberForm`] = []string{r.R_NaNF}
+	}
+	if len(r.R_LeftArc) > 0 {
+		m[`leftArc`] = []string{r.R_LeftArc}
+	}
+	if len(r.R_RightArc) > 0 {
+		m[`rightArc`] = []string{r.R_RightArc}
+	}
+	if r.R_Modified != nil {
+		m[`registrationModified`] = r.R_Modified
+	}
+	if r.R_SubArc != nil {
+		m[`subArc`] = r.R_SubArc
+	}
+	if r.R_StdNF != nil {
+		m[`stdNameForm`] = r.R_StdNF
+	}
+	if r.R_IRI != nil {
+		m[`iRI`] = r.R_IRI
+	}
+	if r.R_UVal != nil {
+		m[`unicodeValue`] = r.R_UVal
+	}
+	if r.R_AddlId != nil {
+		m[`additionalIdentifier`] = r.R_AddlId
+	}
+	if r.R_Info != nil {
+		m[`registrationInformation`] = r.R_Info
+	}
+	if r.R_URI != nil {
+		m[`registrationURI`] = r.R_URI
+	}
+	if r.R_FAuthyDN != nil {
+		m[`firstAuthority`] = r.R_FAuthyDN
+	}
+	if r.R_CAuthyDN != nil {
+		m[`currentAuthority`] = r.R_CAuthyDN
+	}
+
+	return m
+}
+
+func (r *RootArc) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `dn`:
+			r.R_DN = v[0]
+		case `n`, `numberForm`:
+			r.R_N = v[0]
+		case `description`:
+			r.R_Desc = v[0]
+		case `asn1Notation`:
+			r.R_ASN1Not = v[0]
+		case `identifier`, `nameForm`:
+			r.R_Id = v[0]
+		case `registrationCreated`:
+			r.R_Created = v[0]
+		case `nameAndNumberForm`:
+			r.R_NaNF = v[0]
+		case `leftArc`:
+			r.R_LeftArc = v[0]
+		case `rightArc`:
+			r.R_RightArc = v[0]
+		case `registrationModified`:
+			r.R_Modified = append([]string{}, v...)
+		case `subArc`:
+			r.R_SubArc = append([]string{}, v...)
+		case `stdNameForm`:
+			r.R_StdNF = append([]string{}, v...)
+		case `iRI`:
+			r.R_IRI = append([]string{}, v...)
+		case `unicodeValue`:
+			r.R_UVal = append([]string{}, v...)
+		case `additionalIdentifier`:
+			r.R_AddlId = append([]string{}, v...)
+		case `registrationInformation`:
+			r.R_Info = append([]string{}, v...)
+		case `registrationURI`:
+			r.R_URI = append([]string{}, v...)
+		case `firstAuthority`:
+			r.R_FAuthyDN = append([]string{}, v...)
+		case `currentAuthority`:
+			r.R_CAuthyDN = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `dn`:
+				r.R_DN = v[0]
+			case `n`, `numberform`:
+				r.R_N = v[0]
+			case `description`:
+				r.R_Desc = v[0]
+			case `asn1notation`:
+				r.R_ASN1Not = v[0]
+			case `identifier`, `nameform`:
+				r.R_Id = v[0]
+			case `registrationcreated`:
+				r.R_Created = v[0]
+			case `nameandnumberform`:
+				r.R_NaNF = v[0]
+			case `leftarc`:
+				r.R_LeftArc = v[0]
+			case `rightarc`:
+				r.R_RightArc = v[0]
+			case `registrationmodified`:
+				r.R_Modified = append([]string{}, v...)
+			case `subarc`:
+				r.R_SubArc = append([]string{}, v...)
+			case `stdnameform`:
+				r.R_StdNF = append([]string{}, v...)
+			case `iri`:
+				r.R_IRI = append([]string{}, v...)
+			case `unicodevalue`:
+				r.R_UVal = append([]string{}, v...)
+			case `additionalidentifier`:
+				r.R_AddlId = append([]string{}, v...)
+			case `registrationinformation`:
+				r.R_Info = append([]string{}, v...)
+			case `registrationuri`:
+				r.R_URI = append([]string{}, v...)
+			case `firstauthority`:
+				r.R_FAuthyDN = append([]string{}, v...)
+			case `currentauthority`:
+				r.R_CAuthyDN = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r RootArc) multiValued(at string) bool {
+	switch at {
+	case `registrationModified`, `subArc`, `stdNameForm`, `iRI`, `unicodeValue`, `additionalIdentifier`, `registrationInformation`, `registrationURI`, `firstAuthority`, `currentAuthority`:
+		return true
+	}
+
+	return false
+}
+
+func (r RootArc) attributeTypes() []string {
+	return []string{`n`, `description`, `asn1Notation`, `identifier`, `registrationCreated`, `nameAndNumberForm`, `leftArc`, `rightArc`, `registrationModified`, `subArc`, `stdNameForm`, `iRI`, `unicodeValue`, `additionalIdentifier`, `registrationInformation`, `registrationURI`, `firstAuthority`, `currentAuthority`}
+}
+
+func (r RootArc) getAttr(at string) []string {
+	switch at {
+	case `n`:
+		if len(r.R_N) > 0 {
+			return []string{r.R_N}
+		}
+	case `description`:
+		if len(r.R_Desc) > 0 {
+			return []string{r.R_Desc}
+		}
+	case `asn1Notation`:
+		if len(r.R_ASN1Not) > 0 {
+			return []string{r.R_ASN1Not}
+		}
+	case `identifier`:
+		if len(r.R_Id) > 0 {
+			return []string{r.R_Id}
+		}
+	case `registrationCreated`:
+		if len(r.R_Created) > 0 {
+			return []string{r.R_Created}
+		}
+	case `nameAndNumberForm`:
+		if len(r.R_NaNF) > 0 {
+			return []string{r.R_NaNF}
+		}
+	case `leftArc`:
+		if len(r.R_LeftArc) > 0 {
+			return []string{r.R_LeftArc}
+		}
+	case `rightArc`:
+		if len(r.R_RightArc) > 0 {
+			return []string{r.R_RightArc}
+		}
+	case `registrationModified`:
+		return append([]string(nil), r.R_Modified...)
+	case `subArc`:
+		return append([]string(nil), r.R_SubArc...)
+	case `stdNameForm`:
+		return append([]string(nil), r.R_StdNF...)
+	case `iRI`:
+		return append([]string(nil), r.R_IRI...)
+	case `unicodeValue`:
+		return append([]string(nil), r.R_UVal...)
+	case `additionalIdentifier`:
+		return append([]string(nil), r.R_AddlId...)
+	case `registrationInformation`:
+		return append([]string(nil), r.R_Info...)
+	case `registrationURI`:
+		return append([]string(nil), r.R_URI...)
+	case `firstAuthority`:
+		return append([]string(nil), r.R_FAuthyDN...)
+	case `currentAuthority`:
+		return append([]string(nil), r.R_CAuthyDN...)
+	}
+
+	return nil
+}
+
+func (r *RootArc) setAttr(at string, v []string) {
+	switch at {
+	case `n`:
+		r.R_N = ``
+		if len(v) > 0 {
+			r.R_N = v[0]
+		}
+	case `description`:
+		r.R_Desc = ``
+		if len(v) > 0 {
+			r.R_Desc = v[0]
+		}
+	case `asn1Notation`:
+		r.R_ASN1Not = ``
+		if len(v) > 0 {
+			r.R_ASN1Not = v[0]
+		}
+	case `identifier`:
+		r.R_Id = ``
+		if len(v) > 0 {
+			r.R_Id = v[0]
+		}
+	case `registrationCreated`:
+		r.R_Created = ``
+		if len(v) > 0 {
+			r.R_Created = v[0]
+		}
+	case `nameAndNumberForm`:
+		r.R_NaNF = ``
+		if len(v) > 0 {
+			r.R_NaNF = v[0]
+		}
+	case `leftArc`:
+		r.R_LeftArc = ``
+		if len(v) > 0 {
+			r.R_LeftArc = v[0]
+		}
+	case `rightArc`:
+		r.R_RightArc = ``
+		if len(v) > 0 {
+			r.R_RightArc = v[0]
+		}
+	case `registrationModified`:
+		r.R_Modified = append([]string(nil), v...)
+	case `subArc`:
+		r.R_SubArc = append([]string(nil), v...)
+	case `stdNameForm`:
+		r.R_StdNF = append([]string(nil), v...)
+	case `iRI`:
+		r.R_IRI = append([]string(nil), v...)
+	case `unicodeValue`:
+		r.R_UVal = append([]string(nil), v...)
+	case `additionalIdentifier`:
+		r.R_AddlId = append([]string(nil), v...)
+	case `registrationInformation`:
+		r.R_Info = append([]string(nil), v...)
+	case `registrationURI`:
+		r.R_URI = append([]string(nil), v...)
+	case `firstAuthority`:
+		r.R_FAuthyDN = append([]string(nil), v...)
+	case `currentAuthority`:
+		r.R_CAuthyDN = append([]string(nil), v...)
+	}
+}
+
+func (r SubArc) unmarshal() map[string][]string {
+	m := make(map[string][]string, 32)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.R_N) > 0 {
+		m[`n`] = []string{r.R_N}
+	}
+	if len(r.R_Desc) > 0 {
+		m[`description`] = []string{r.R_Desc}
+	}
+	if len(r.R_DotNot) > 0 {
+		m[`dotNotation`] = []string{r.R_DotNot}
+	}
+	if len(r.R_ASN1Not) > 0 {
+		m[`asn1Notation`] = []string{r.R_ASN1Not}
+	}
+	if len(r.R_Id) > 0 {
+		m[`identifier`] = []string{r.R_Id}
+	}
+	if len(r.R_Created) > 0 {
+		m[`registrationCreated`] = []string{r.R_Created}
+	}
+	if len(r.R_Range) > 0 {
+		m[`registrationRange`] = []string{r.R_Range}
+	}
+	if len(r.R_Status) > 0 {
+		m[`registrationStatus`] = []string{r.R_Status}
+	}
+	if len(r.R_LeafNode) > 0 {
+		m[`isLeafNode`] = []string{r.R_LeafNode}
+	}
+	if len(r.R_Frozen) > 0 {
+		m[`isFrozen`] = []string{r.R_Frozen}
+	}
+	if len(r.R_NaNF) > 0 {
+		m[`nameAndNumberForm`] = []string{r.R_NaNF}
+	}
+	if len(r.R_SupArc) > 0 {
+		m[`supArc`] = []string{r.R_SupArc}
+	}
+	if len(r.R_TopArc) > 0 {
+		m[`topArc`] = []string{r.R_TopArc}
+	}
+	if len(r.R_LeftArc) > 0 {
+		m[`leftArc`] = []string{r.R_LeftArc}
+	}
+	if len(r.R_FirstArc) > 0 {
+		m[`firstArc`] = []string{r.R_FirstArc}
+	}
+	if len(r.R_RightArc) > 0 {
+		m[`rightArc`] = []string{r.R_RightArc}
+	}
+	if len(r.R_FinalArc) > 0 {
+		m[`finalArc`] = []string{r.R_FinalArc}
+	}
+	if r.R_SubArc != nil {
+		m[`subArc`] = r.R_SubArc
+	}
+	if r.R_LongArc != nil {
+		m[`longArc`] = r.R_LongArc
+	}
+	if r.R_Modified != nil {
+		m[`registrationModified`] = r.R_Modified
+	}
+	if r.R_DiscloseTo != nil {
+		m[`discloseTo`] = r.R_DiscloseTo
+	}
+	if r.R_StdNF != nil {
+		m[`stdNameForm`] = r.R_StdNF
+	}
+	if r.R_IRI != nil {
+		m[`iRI`] = r.R_IRI
+	}
+	if r.R_UVal != nil {
+		m[`unicodeValue`] = r.R_UVal
+	}
+	if r.R_AddlId != nil {
+		m[`additionalIdentifier`] = r.R_AddlId
+	}
+	if r.R_Info != nil {
+		m[`registrationInformation`] = r.R_Info
+	}
+	if r.R_URI != nil {
+		m[`registrationURI`] = r.R_URI
+	}
+	if r.R_FAuthyDN != nil {
+		m[`firstAuthority`] = r.R_FAuthyDN
+	}
+	if r.R_CAuthyDN != nil {
+		m[`currentAuthority`] = r.R_CAuthyDN
+	}
+	if r.R_SAuthyDN != nil {
+		m[`sponsor`] = r.R_SAuthyDN
+	}
+
+	return m
+}
+
+func (r *SubArc) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `dn`:
+			r.R_DN = v[0]
+		case `n`, `numberForm`:
+			r.R_N = v[0]
+		case `description`:
+			r.R_Desc = v[0]
+		case `dotNotation`:
+			r.R_DotNot = v[0]
+		case `asn1Notation`:
+			r.R_ASN1Not = v[0]
+		case `identifier`, `nameForm`:
+			r.R_Id = v[0]
+		case `registrationCreated`:
+			r.R_Created = v[0]
+		case `registrationRange`:
+			r.R_Range = v[0]
+		case `registrationStatus`:
+			r.R_Status = v[0]
+		case `isLeafNode`:
+			r.R_LeafNode = v[0]
+		case `isFrozen`:
+			r.R_Frozen = v[0]
+		case `nameAndNumberForm`:
+			r.R_NaNF = v[0]
+		case `supArc`:
+			r.R_SupArc = v[0]
+		case `topArc`:
+			r.R_TopArc = v[0]
+		case `leftArc`:
+			r.R_LeftArc = v[0]
+		case `firstArc`:
+			r.R_FirstArc = v[0]
+		case `rightArc`:
+			r.R_RightArc = v[0]
+		case `finalArc`:
+			r.R_FinalArc = v[0]
+		case `subArc`:
+			r.R_SubArc = append([]string{}, v...)
+		case `longArc`:
+			r.R_LongArc = append([]string{}, v...)
+		case `registrationModified`:
+			r.R_Modified = append([]string{}, v...)
+		case `discloseTo`:
+			r.R_DiscloseTo = append([]string{}, v...)
+		case `stdNameForm`:
+			r.R_StdNF = append([]string{}, v...)
+		case `iRI`:
+			r.R_IRI = append([]string{}, v...)
+		case `unicodeValue`:
+			r.R_UVal = append([]string{}, v...)
+		case `additionalIdentifier`:
+			r.R_AddlId = append([]string{}, v...)
+		case `registrationInformation`:
+			r.R_Info = append([]string{}, v...)
+		case `registrationURI`:
+			r.R_URI = append([]string{}, v...)
+		case `firstAuthority`:
+			r.R_FAuthyDN = append([]string{}, v...)
+		case `currentAuthority`:
+			r.R_CAuthyDN = append([]string{}, v...)
+		case `sponsor`:
+			r.R_SAuthyDN = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `dn`:
+				r.R_DN = v[0]
+			case `n`, `numberform`:
+				r.R_N = v[0]
+			case `description`:
+				r.R_Desc = v[0]
+			case `dotnotation`:
+				r.R_DotNot = v[0]
+			case `asn1notation`:
+				r.R_ASN1Not = v[0]
+			case `identifier`, `nameform`:
+				r.R_Id = v[0]
+			case `registrationcreated`:
+				r.R_Created = v[0]
+			case `registrationrange`:
+				r.R_Range = v[0]
+			case `registrationstatus`:
+				r.R_Status = v[0]
+			case `isleafnode`:
+				r.R_LeafNode = v[0]
+			case `isfrozen`:
+				r.R_Frozen = v[0]
+			case `nameandnumberform`:
+				r.R_NaNF = v[0]
+			case `suparc`:
+				r.R_SupArc = v[0]
+			case `toparc`:
+				r.R_TopArc = v[0]
+			case `leftarc`:
+				r.R_LeftArc = v[0]
+			case `firstarc`:
+				r.R_FirstArc = v[0]
+			case `rightarc`:
+				r.R_RightArc = v[0]
+			case `finalarc`:
+				r.R_FinalArc = v[0]
+			case `subarc`:
+				r.R_SubArc = append([]string{}, v...)
+			case `longarc`:
+				r.R_LongArc = append([]string{}, v...)
+			case `registrationmodified`:
+				r.R_Modified = append([]string{}, v...)
+			case `discloseto`:
+				r.R_DiscloseTo = append([]string{}, v...)
+			case `stdnameform`:
+				r.R_StdNF = append([]string{}, v...)
+			case `iri`:
+				r.R_IRI = append([]string{}, v...)
+			case `unicodevalue`:
+				r.R_UVal = append([]string{}, v...)
+			case `additionalidentifier`:
+				r.R_AddlId = append([]string{}, v...)
+			case `registrationinformation`:
+				r.R_Info = append([]string{}, v...)
+			case `registrationuri`:
+				r.R_URI = append([]string{}, v...)
+			case `firstauthority`:
+				r.R_FAuthyDN = append([]string{}, v...)
+			case `currentauthority`:
+				r.R_CAuthyDN = append([]string{}, v...)
+			case `sponsor`:
+				r.R_SAuthyDN = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r SubArc) multiValued(at string) bool {
+	switch at {
+	case `subArc`, `longArc`, `registrationModified`, `discloseTo`, `stdNameForm`, `iRI`, `unicodeValue`, `additionalIdentifier`, `registrationInformation`, `registrationURI`, `firstAuthority`, `currentAuthority`, `sponsor`:
+		return true
+	}
+
+	return false
+}
+
+func (r SubArc) attributeTypes() []string {
+	return []string{`n`, `description`, `dotNotation`, `asn1Notation`, `identifier`, `registrationCreated`, `registrationRange`, `registrationStatus`, `isLeafNode`, `isFrozen`, `nameAndNumberForm`, `supArc`, `topArc`, `leftArc`, `firstArc`, `rightArc`, `finalArc`, `subArc`, `longArc`, `registrationModified`, `discloseTo`, `stdNameForm`, `iRI`, `unicodeValue`, `additionalIdentifier`, `registrationInformation`, `registrationURI`, `firstAuthority`, `currentAuthority`, `sponsor`}
+}
+
+func (r SubArc) getAttr(at string) []string {
+	switch at {
+	case `n`:
+		if len(r.R_N) > 0 {
+			return []string{r.R_N}
+		}
+	case `description`:
+		if len(r.R_Desc) > 0 {
+			return []string{r.R_Desc}
+		}
+	case `dotNotation`:
+		if len(r.R_DotNot) > 0 {
+			return []string{r.R_DotNot}
+		}
+	case `asn1Notation`:
+		if len(r.R_ASN1Not) > 0 {
+			return []string{r.R_ASN1Not}
+		}
+	case `identifier`:
+		if len(r.R_Id) > 0 {
+			return []string{r.R_Id}
+		}
+	case `registrationCreated`:
+		if len(r.R_Created) > 0 {
+			return []string{r.R_Created}
+		}
+	case `registrationRange`:
+		if len(r.R_Range) > 0 {
+			return []string{r.R_Range}
+		}
+	case `registrationStatus`:
+		if len(r.R_Status) > 0 {
+			return []string{r.R_Status}
+		}
+	case `isLeafNode`:
+		if len(r.R_LeafNode) > 0 {
+			return []string{r.R_LeafNode}
+		}
+	case `isFrozen`:
+		if len(r.R_Frozen) > 0 {
+			return []string{r.R_Frozen}
+		}
+	case `nameAndNumberForm`:
+		if len(r.R_NaNF) > 0 {
+			return []string{r.R_NaNF}
+		}
+	case `supArc`:
+		if len(r.R_SupArc) > 0 {
+			return []string{r.R_SupArc}
+		}
+	case `topArc`:
+		if len(r.R_TopArc) > 0 {
+			return []string{r.R_TopArc}
+		}
+	case `leftArc`:
+		if len(r.R_LeftArc) > 0 {
+			return []string{r.R_LeftArc}
+		}
+	case `firstArc`:
+		if len(r.R_FirstArc) > 0 {
+			return []string{r.R_FirstArc}
+		}
+	case `rightArc`:
+		if len(r.R_RightArc) > 0 {
+			return []string{r.R_RightArc}
+		}
+	case `finalArc`:
+		if len(r.R_FinalArc) > 0 {
+			return []string{r.R_FinalArc}
+		}
+	case `subArc`:
+		return append([]string(nil), r.R_SubArc...)
+	case `longArc`:
+		return append([]string(nil), r.R_LongArc...)
+	case `registrationModified`:
+		return append([]string(nil), r.R_Modified...)
+	case `discloseTo`:
+		return append([]string(nil), r.R_DiscloseTo...)
+	case `stdNameForm`:
+		return append([]string(nil), r.R_StdNF...)
+	case `iRI`:
+		return append([]string(nil), r.R_IRI...)
+	case `unicodeValue`:
+		return append([]string(nil), r.R_UVal...)
+	case `additionalIdentifier`:
+		return append([]string(nil), r.R_AddlId...)
+	case `registrationInformation`:
+		return append([]string(nil), r.R_Info...)
+	case `registrationURI`:
+		return append([]string(nil), r.R_URI...)
+	case `firstAuthority`:
+		return append([]string(nil), r.R_FAuthyDN...)
+	case `currentAuthority`:
+		return append([]string(nil), r.R_CAuthyDN...)
+	case `sponsor`:
+		return append([]string(nil), r.R_SAuthyDN...)
+	}
+
+	return nil
+}
+
+func (r *SubArc) setAttr(at string, v []string) {
+	switch at {
+	case `n`:
+		r.R_N = ``
+		if len(v) > 0 {
+			r.R_N = v[0]
+		}
+	case `description`:
+		r.R_Desc = ``
+		if len(v) > 0 {
+			r.R_Desc = v[0]
+		}
+	case `dotNotation`:
+		r.R_DotNot = ``
+		if len(v) > 0 {
+			r.R_DotNot = v[0]
+		}
+	case `asn1Notation`:
+		r.R_ASN1Not = ``
+		if len(v) > 0 {
+			r.R_ASN1Not = v[0]
+		}
+	case `identifier`:
+		r.R_Id = ``
+		if len(v) > 0 {
+			r.R_Id = v[0]
+		}
+	case `registrationCreated`:
+		r.R_Created = ``
+		if len(v) > 0 {
+			r.R_Created = v[0]
+		}
+	case `registrationRange`:
+		r.R_Range = ``
+		if len(v) > 0 {
+			r.R_Range = v[0]
+		}
+	case `registrationStatus`:
+		r.R_Status = ``
+		if len(v) > 0 {
+			r.R_Status = v[0]
+		}
+	case `isLeafNode`:
+		r.R_LeafNode = ``
+		if len(v) > 0 {
+			r.R_LeafNode = v[0]
+		}
+	case `isFrozen`:
+		r.R_Frozen = ``
+		if len(v) > 0 {
+			r.R_Frozen = v[0]
+		}
+	case `nameAndNumberForm`:
+		r.R_NaNF = ``
+		if len(v) > 0 {
+			r.R_NaNF = v[0]
+		}
+	case `supArc`:
+		r.R_SupArc = ``
+		if len(v) > 0 {
+			r.R_SupArc = v[0]
+		}
+	case `topArc`:
+		r.R_TopArc = ``
+		if len(v) > 0 {
+			r.R_TopArc = v[0]
+		}
+	case `leftArc`:
+		r.R_LeftArc = ``
+		if len(v) > 0 {
+			r.R_LeftArc = v[0]
+		}
+	case `firstArc`:
+		r.R_FirstArc = ``
+		if len(v) > 0 {
+			r.R_FirstArc = v[0]
+		}
+	case `rightArc`:
+		r.R_RightArc = ``
+		if len(v) > 0 {
+			r.R_RightArc = v[0]
+		}
+	case `finalArc`:
+		r.R_FinalArc = ``
+		if len(v) > 0 {
+			r.R_FinalArc = v[0]
+		}
+	case `subArc`:
+		r.R_SubArc = append([]string(nil), v...)
+	case `longArc`:
+		r.R_LongArc = append([]string(nil), v...)
+	case `registrationModified`:
+		r.R_Modified = append([]string(nil), v...)
+	case `discloseTo`:
+		r.R_DiscloseTo = append([]string(nil), v...)
+	case `stdNameForm`:
+		r.R_StdNF = append([]string(nil), v...)
+	case `iRI`:
+		r.R_IRI = append([]string(nil), v...)
+	case `unicodeValue`:
+		r.R_UVal = append([]string(nil), v...)
+	case `additionalIdentifier`:
+		r.R_AddlId = append([]string(nil), v...)
+	case `registrationInformation`:
+		r.R_Info = append([]string(nil), v...)
+	case `registrationURI`:
+		r.R_URI = append([]string(nil), v...)
+	case `firstAuthority`:
+		r.R_FAuthyDN = append([]string(nil), v...)
+	case `currentAuthority`:
+		r.R_CAuthyDN = append([]string(nil), v...)
+	case `sponsor`:
+		r.R_SAuthyDN = append([]string(nil), v...)
+	}
+}
+
+func (r FirstAuthority) unmarshal() map[string][]string {
+	m := make(map[string][]string, 21)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.R_Id) > 0 {
+		m[`registrantID`] = []string{r.R_Id}
+	}
+	if len(r.R_L) > 0 {
+		m[`firstAuthorityLocality`] = []string{r.R_L}
+	}
+	if len(r.R_O) > 0 {
+		m[`firstAuthorityOrg`] = []string{r.R_O}
+	}
+	if len(r.R_C) > 0 {
+		m[`firstAuthorityCountryCode`] = []string{r.R_C}
+	}
+	if len(r.R_CO) > 0 {
+		m[`firstAuthorityCountryName`] = []string{r.R_CO}
+	}
+	if len(r.R_ST) > 0 {
+		m[`firstAuthorityState`] = []string{r.R_ST}
+	}
+	if len(r.R_CN) > 0 {
+		m[`firstAuthorityCommonName`] = []string{r.R_CN}
+	}
+	if len(r.R_Tel) > 0 {
+		m[`firstAuthorityTelephone`] = []string{r.R_Tel}
+	}
+	if len(r.R_Fax) > 0 {
+		m[`firstAuthorityFax`] = []string{r.R_Fax}
+	}
+	if len(r.R_Title) > 0 {
+		m[`firstAuthorityTitle`] = []string{r.R_Title}
+	}
+	if len(r.R_Email) > 0 {
+		m[`firstAuthorityEmail`] = []string{r.R_Email}
+	}
+	if len(r.R_POBox) > 0 {
+		m[`firstAuthorityPOBox`] = []string{r.R_POBox}
+	}
+	if len(r.R_PCode) > 0 {
+		m[`firstAuthorityPostalCode`] = []string{r.R_PCode}
+	}
+	if len(r.R_PAddr) > 0 {
+		m[`firstAuthorityPostalAddress`] = []string{r.R_PAddr}
+	}
+	if len(r.R_Street) > 0 {
+		m[`firstAuthorityStreet`] = []string{r.R_Street}
+	}
+	if len(r.R_Mobile) > 0 {
+		m[`firstAuthorityMobile`] = []string{r.R_Mobile}
+	}
+	if len(r.R_StartTime) > 0 {
+		m[`firstAuthorityStartTimestamp`] = []string{r.R_StartTime}
+	}
+	if len(r.R_EndTime) > 0 {
+		m[`firstAuthorityEndTimestamp`] = []string{r.R_EndTime}
+	}
+	if r.R_URI != nil {
+		m[`firstAuthorityURI`] = r.R_URI
+	}
+
+	return m
+}
+
+func (r *FirstAuthority) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `dn`:
+			r.R_DN = v[0]
+		case `registrantID`:
+			r.R_Id = v[0]
+		case `firstAuthorityLocality`:
+			r.R_L = v[0]
+		case `firstAuthorityOrg`:
+			r.R_O = v[0]
+		case `firstAuthorityCountryCode`:
+			r.R_C = v[0]
+		case `firstAuthorityCountryName`:
+			r.R_CO = v[0]
+		case `firstAuthorityState`:
+			r.R_ST = v[0]
+		case `firstAuthorityCommonName`:
+			r.R_CN = v[0]
+		case `firstAuthorityTelephone`:
+			r.R_Tel = v[0]
+		case `firstAuthorityFax`:
+			r.R_Fax = v[0]
+		case `firstAuthorityTitle`:
+			r.R_Title = v[0]
+		case `firstAuthorityEmail`:
+			r.R_Email = v[0]
+		case `firstAuthorityPOBox`:
+			r.R_POBox = v[0]
+		case `firstAuthorityPostalCode`:
+			r.R_PCode = v[0]
+		case `firstAuthorityPostalAddress`:
+			r.R_PAddr = v[0]
+		case `firstAuthorityStreet`:
+			r.R_Street = v[0]
+		case `firstAuthorityMobile`:
+			r.R_Mobile = v[0]
+		case `firstAuthorityStartTimestamp`:
+			r.R_StartTime = v[0]
+		case `firstAuthorityEndTimestamp`:
+			r.R_EndTime = v[0]
+		case `firstAuthorityURI`:
+			r.R_URI = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `dn`:
+				r.R_DN = v[0]
+			case `registrantid`:
+				r.R_Id = v[0]
+			case `firstauthoritylocality`:
+				r.R_L = v[0]
+			case `firstauthorityorg`:
+				r.R_O = v[0]
+			case `firstauthoritycountrycode`:
+				r.R_C = v[0]
+			case `firstauthoritycountryname`:
+				r.R_CO = v[0]
+			case `firstauthoritystate`:
+				r.R_ST = v[0]
+			case `firstauthoritycommonname`:
+				r.R_CN = v[0]
+			case `firstauthoritytelephone`:
+				r.R_Tel = v[0]
+			case `firstauthorityfax`:
+				r.R_Fax = v[0]
+			case `firstauthoritytitle`:
+				r.R_Title = v[0]
+			case `firstauthorityemail`:
+				r.R_Email = v[0]
+			case `firstauthoritypobox`:
+				r.R_POBox = v[0]
+			case `firstauthoritypostalcode`:
+				r.R_PCode = v[0]
+			case `firstauthoritypostaladdress`:
+				r.R_PAddr = v[0]
+			case `firstauthoritystreet`:
+				r.R_Street = v[0]
+			case `firstauthoritymobile`:
+				r.R_Mobile = v[0]
+			case `firstauthoritystarttimestamp`:
+				r.R_StartTime = v[0]
+			case `firstauthorityendtimestamp`:
+				r.R_EndTime = v[0]
+			case `firstauthorityuri`:
+				r.R_URI = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r FirstAuthority) multiValued(at string) bool {
+	switch at {
+	case `firstAuthorityURI`:
+		return true
+	}
+
+	return false
+}
+
+func (r FirstAuthority) attributeTypes() []string {
+	return []string{`registrantID`, `firstAuthorityLocality`, `firstAuthorityOrg`, `firstAuthorityCountryCode`, `firstAuthorityCountryName`, `firstAuthorityState`, `firstAuthorityCommonName`, `firstAuthorityTelephone`, `firstAuthorityFax`, `firstAuthorityTitle`, `firstAuthorityEmail`, `firstAuthorityPOBox`, `firstAuthorityPostalCode`, `firstAuthorityPostalAddress`, `firstAuthorityStreet`, `firstAuthorityMobile`, `firstAuthorityStartTimestamp`, `firstAuthorityEndTimestamp`, `firstAuthorityURI`}
+}
+
+func (r FirstAuthority) getAttr(at string) []string {
+	switch at {
+	case `registrantID`:
+		if len(r.R_Id) > 0 {
+			return []string{r.R_Id}
+		}
+	case `firstAuthorityLocality`:
+		if len(r.R_L) > 0 {
+			return []string{r.R_L}
+		}
+	case `firstAuthorityOrg`:
+		if len(r.R_O) > 0 {
+			return []string{r.R_O}
+		}
+	case `firstAuthorityCountryCode`:
+		if len(r.R_C) > 0 {
+			return []string{r.R_C}
+		}
+	case `firstAuthorityCountryName`:
+		if len(r.R_CO) > 0 {
+			return []string{r.R_CO}
+		}
+	case `firstAuthorityState`:
+		if len(r.R_ST) > 0 {
+			return []string{r.R_ST}
+		}
+	case `firstAuthorityCommonName`:
+		if len(r.R_CN) > 0 {
+			return []string{r.R_CN}
+		}
+	case `firstAuthorityTelephone`:
+		if len(r.R_Tel) > 0 {
+			return []string{r.R_Tel}
+		}
+	case `firstAuthorityFax`:
+		if len(r.R_Fax) > 0 {
+			return []string{r.R_Fax}
+		}
+	case `firstAuthorityTitle`:
+		if len(r.R_Title) > 0 {
+			return []string{r.R_Title}
+		}
+	case `firstAuthorityEmail`:
+		if len(r.R_Email) > 0 {
+			return []string{r.R_Email}
+		}
+	case `firstAuthorityPOBox`:
+		if len(r.R_POBox) > 0 {
+			return []string{r.R_POBox}
+		}
+	case `firstAuthorityPostalCode`:
+		if len(r.R_PCode) > 0 {
+			return []string{r.R_PCode}
+		}
+	case `firstAuthorityPostalAddress`:
+		if len(r.R_PAddr) > 0 {
+			return []string{r.R_PAddr}
+		}
+	case `firstAuthorityStreet`:
+		if len(r.R_Street) > 0 {
+			return []string{r.R_Street}
+		}
+	case `firstAuthorityMobile`:
+		if len(r.R_Mobile) > 0 {
+			return []string{r.R_Mobile}
+		}
+	case `firstAuthorityStartTimestamp`:
+		if len(r.R_StartTime) > 0 {
+			return []string{r.R_StartTime}
+		}
+	case `firstAuthorityEndTimestamp`:
+		if len(r.R_EndTime) > 0 {
+			return []string{r.R_EndTime}
+		}
+	case `firstAuthorityURI`:
+		return append([]string(nil), r.R_URI...)
+	}
+
+	return nil
+}
+
+func (r *FirstAuthority) setAttr(at string, v []string) {
+	switch at {
+	case `registrantID`:
+		r.R_Id = ``
+		if len(v) > 0 {
+			r.R_Id = v[0]
+		}
+	case `firstAuthorityLocality`:
+		r.R_L = ``
+		if len(v) > 0 {
+			r.R_L = v[0]
+		}
+	case `firstAuthorityOrg`:
+		r.R_O = ``
+		if len(v) > 0 {
+			r.R_O = v[0]
+		}
+	case `firstAuthorityCountryCode`:
+		r.R_C = ``
+		if len(v) > 0 {
+			r.R_C = v[0]
+		}
+	case `firstAuthorityCountryName`:
+		r.R_CO = ``
+		if len(v) > 0 {
+			r.R_CO = v[0]
+		}
+	case `firstAuthorityState`:
+		r.R_ST = ``
+		if len(v) > 0 {
+			r.R_ST = v[0]
+		}
+	case `firstAuthorityCommonName`:
+		r.R_CN = ``
+		if len(v) > 0 {
+			r.R_CN = v[0]
+		}
+	case `firstAuthorityTelephone`:
+		r.R_Tel = ``
+		if len(v) > 0 {
+			r.R_Tel = v[0]
+		}
+	case `firstAuthorityFax`:
+		r.R_Fax = ``
+		if len(v) > 0 {
+			r.R_Fax = v[0]
+		}
+	case `firstAuthorityTitle`:
+		r.R_Title = ``
+		if len(v) > 0 {
+			r.R_Title = v[0]
+		}
+	case `firstAuthorityEmail`:
+		r.R_Email = ``
+		if len(v) > 0 {
+			r.R_Email = v[0]
+		}
+	case `firstAuthorityPOBox`:
+		r.R_POBox = ``
+		if len(v) > 0 {
+			r.R_POBox = v[0]
+		}
+	case `firstAuthorityPostalCode`:
+		r.R_PCode = ``
+		if len(v) > 0 {
+			r.R_PCode = v[0]
+		}
+	case `firstAuthorityPostalAddress`:
+		r.R_PAddr = ``
+		if len(v) > 0 {
+			r.R_PAddr = v[0]
+		}
+	case `firstAuthorityStreet`:
+		r.R_Street = ``
+		if len(v) > 0 {
+			r.R_Street = v[0]
+		}
+	case `firstAuthorityMobile`:
+		r.R_Mobile = ``
+		if len(v) > 0 {
+			r.R_Mobile = v[0]
+		}
+	case `firstAuthorityStartTimestamp`:
+		r.R_StartTime = ``
+		if len(v) > 0 {
+			r.R_StartTime = v[0]
+		}
+	case `firstAuthorityEndTimestamp`:
+		r.R_EndTime = ``
+		if len(v) > 0 {
+			r.R_EndTime = v[0]
+		}
+	case `firstAuthorityURI`:
+		r.R_URI = append([]string(nil), v...)
+	}
+}
+
+func (r CurrentAuthority) unmarshal() map[string][]string {
+	m := make(map[string][]string, 20)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.R_Id) > 0 {
+		m[`registrantID`] = []string{r.R_Id}
+	}
+	if len(r.R_L) > 0 {
+		m[`currentAuthorityLocality`] = []string{r.R_L}
+	}
+	if len(r.R_O) > 0 {
+		m[`currentAuthorityOrg`] = []string{r.R_O}
+	}
+	if len(r.R_C) > 0 {
+		m[`currentAuthorityCountryCode`] = []string{r.R_C}
+	}
+	if len(r.R_CO) > 0 {
+		m[`currentAuthorityCountryName`] = []string{r.R_CO}
+	}
+	if len(r.R_ST) > 0 {
+		m[`currentAuthorityState`] = []string{r.R_ST}
+	}
+	if len(r.R_CN) > 0 {
+		m[`currentAuthorityCommonName`] = []string{r.R_CN}
+	}
+	if len(r.R_Tel) > 0 {
+		m[`currentAuthorityTelephone`] = []string{r.R_Tel}
+	}
+	if len(r.R_Fax) > 0 {
+		m[`currentAuthorityFax`] = []string{r.R_Fax}
+	}
+	if len(r.R_Title) > 0 {
+		m[`currentAuthorityTitle`] = []string{r.R_Title}
+	}
+	if len(r.R_Email) > 0 {
+		m[`currentAuthorityEmail`] = []string{r.R_Email}
+	}
+	if len(r.R_POBox) > 0 {
+		m[`currentAuthorityPOBox`] = []string{r.R_POBox}
+	}
+	if len(r.R_PCode) > 0 {
+		m[`currentAuthorityPostalCode`] = []string{r.R_PCode}
+	}
+	if len(r.R_PAddr) > 0 {
+		m[`currentAuthorityPostalAddress`] = []string{r.R_PAddr}
+	}
+	if len(r.R_Street) > 0 {
+		m[`currentAuthorityStreet`] = []string{r.R_Street}
+	}
+	if len(r.R_Mobile) > 0 {
+		m[`currentAuthorityMobile`] = []string{r.R_Mobile}
+	}
+	if len(r.R_StartTime) > 0 {
+		m[`currentAuthorityStartTimestamp`] = []string{r.R_StartTime}
+	}
+	if r.R_URI != nil {
+		m[`currentAuthorityURI`] = r.R_URI
+	}
+
+	return m
+}
+
+func (r *CurrentAuthority) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `dn`:
+			r.R_DN = v[0]
+		case `registrantID`:
+			r.R_Id = v[0]
+		case `currentAuthorityLocality`:
+			r.R_L = v[0]
+		case `currentAuthorityOrg`:
+			r.R_O = v[0]
+		case `currentAuthorityCountryCode`:
+			r.R_C = v[0]
+		case `currentAuthorityCountryName`:
+			r.R_CO = v[0]
+		case `currentAuthorityState`:
+			r.R_ST = v[0]
+		case `currentAuthorityCommonName`:
+			r.R_CN = v[0]
+		case `currentAuthorityTelephone`:
+			r.R_Tel = v[0]
+		case `currentAuthorityFax`:
+			r.R_Fax = v[0]
+		case `currentAuthorityTitle`:
+			r.R_Title = v[0]
+		case `currentAuthorityEmail`:
+			r.R_Email = v[0]
+		case `currentAuthorityPOBox`:
+			r.R_POBox = v[0]
+		case `currentAuthorityPostalCode`:
+			r.R_PCode = v[0]
+		case `currentAuthorityPostalAddress`:
+			r.R_PAddr = v[0]
+		case `currentAuthorityStreet`:
+			r.R_Street = v[0]
+		case `currentAuthorityMobile`:
+			r.R_Mobile = v[0]
+		case `currentAuthorityStartTimestamp`:
+			r.R_StartTime = v[0]
+		case `currentAuthorityURI`:
+			r.R_URI = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `dn`:
+				r.R_DN = v[0]
+			case `registrantid`:
+				r.R_Id = v[0]
+			case `currentauthoritylocality`:
+				r.R_L = v[0]
+			case `currentauthorityorg`:
+				r.R_O = v[0]
+			case `currentauthoritycountrycode`:
+				r.R_C = v[0]
+			case `currentauthoritycountryname`:
+				r.R_CO = v[0]
+			case `currentauthoritystate`:
+				r.R_ST = v[0]
+			case `currentauthoritycommonname`:
+				r.R_CN = v[0]
+			case `currentauthoritytelephone`:
+				r.R_Tel = v[0]
+			case `currentauthorityfax`:
+				r.R_Fax = v[0]
+			case `currentauthoritytitle`:
+				r.R_Title = v[0]
+			case `currentauthorityemail`:
+				r.R_Email = v[0]
+			case `currentauthoritypobox`:
+				r.R_POBox = v[0]
+			case `currentauthoritypostalcode`:
+				r.R_PCode = v[0]
+			case `currentauthoritypostaladdress`:
+				r.R_PAddr = v[0]
+			case `currentauthoritystreet`:
+				r.R_Street = v[0]
+			case `currentauthoritymobile`:
+				r.R_Mobile = v[0]
+			case `currentauthoritystarttimestamp`:
+				r.R_StartTime = v[0]
+			case `currentauthorityuri`:
+				r.R_URI = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r CurrentAuthority) multiValued(at string) bool {
+	switch at {
+	case `currentAuthorityURI`:
+		return true
+	}
+
+	return false
+}
+
+func (r CurrentAuthority) attributeTypes() []string {
+	return []string{`registrantID`, `currentAuthorityLocality`, `currentAuthorityOrg`, `currentAuthorityCountryCode`, `currentAuthorityCountryName`, `currentAuthorityState`, `currentAuthorityCommonName`, `currentAuthorityTelephone`, `currentAuthorityFax`, `currentAuthorityTitle`, `currentAuthorityEmail`, `currentAuthorityPOBox`, `currentAuthorityPostalCode`, `currentAuthorityPostalAddress`, `currentAuthorityStreet`, `currentAuthorityMobile`, `currentAuthorityStartTimestamp`, `currentAuthorityURI`}
+}
+
+func (r CurrentAuthority) getAttr(at string) []string {
+	switch at {
+	case `registrantID`:
+		if len(r.R_Id) > 0 {
+			return []string{r.R_Id}
+		}
+	case `currentAuthorityLocality`:
+		if len(r.R_L) > 0 {
+			return []string{r.R_L}
+		}
+	case `currentAuthorityOrg`:
+		if len(r.R_O) > 0 {
+			return []string{r.R_O}
+		}
+	case `currentAuthorityCountryCode`:
+		if len(r.R_C) > 0 {
+			return []string{r.R_C}
+		}
+	case `currentAuthorityCountryName`:
+		if len(r.R_CO) > 0 {
+			return []string{r.R_CO}
+		}
+	case `currentAuthorityState`:
+		if len(r.R_ST) > 0 {
+			return []string{r.R_ST}
+		}
+	case `currentAuthorityCommonName`:
+		if len(r.R_CN) > 0 {
+			return []string{r.R_CN}
+		}
+	case `currentAuthorityTelephone`:
+		if len(r.R_Tel) > 0 {
+			return []string{r.R_Tel}
+		}
+	case `currentAuthorityFax`:
+		if len(r.R_Fax) > 0 {
+			return []string{r.R_Fax}
+		}
+	case `currentAuthorityTitle`:
+		if len(r.R_Title) > 0 {
+			return []string{r.R_Title}
+		}
+	case `currentAuthorityEmail`:
+		if len(r.R_Email) > 0 {
+			return []string{r.R_Email}
+		}
+	case `currentAuthorityPOBox`:
+		if len(r.R_POBox) > 0 {
+			return []string{r.R_POBox}
+		}
+	case `currentAuthorityPostalCode`:
+		if len(r.R_PCode) > 0 {
+			return []string{r.R_PCode}
+		}
+	case `currentAuthorityPostalAddress`:
+		if len(r.R_PAddr) > 0 {
+			return []string{r.R_PAddr}
+		}
+	case `currentAuthorityStreet`:
+		if len(r.R_Street) > 0 {
+			return []string{r.R_Street}
+		}
+	case `currentAuthorityMobile`:
+		if len(r.R_Mobile) > 0 {
+			return []string{r.R_Mobile}
+		}
+	case `currentAuthorityStartTimestamp`:
+		if len(r.R_StartTime) > 0 {
+			return []string{r.R_StartTime}
+		}
+	case `currentAuthorityURI`:
+		return append([]string(nil), r.R_URI...)
+	}
+
+	return nil
+}
+
+func (r *CurrentAuthority) setAttr(at string, v []string) {
+	switch at {
+	case `registrantID`:
+		r.R_Id = ``
+		if len(v) > 0 {
+			r.R_Id = v[0]
+		}
+	case `currentAuthorityLocality`:
+		r.R_L = ``
+		if len(v) > 0 {
+			r.R_L = v[0]
+		}
+	case `currentAuthorityOrg`:
+		r.R_O = ``
+		if len(v) > 0 {
+			r.R_O = v[0]
+		}
+	case `currentAuthorityCountryCode`:
+		r.R_C = ``
+		if len(v) > 0 {
+			r.R_C = v[0]
+		}
+	case `currentAuthorityCountryName`:
+		r.R_CO = ``
+		if len(v) > 0 {
+			r.R_CO = v[0]
+		}
+	case `currentAuthorityState`:
+		r.R_ST = ``
+		if len(v) > 0 {
+			r.R_ST = v[0]
+		}
+	case `currentAuthorityCommonName`:
+		r.R_CN = ``
+		if len(v) > 0 {
+			r.R_CN = v[0]
+		}
+	case `currentAuthorityTelephone`:
+		r.R_Tel = ``
+		if len(v) > 0 {
+			r.R_Tel = v[0]
+		}
+	case `currentAuthorityFax`:
+		r.R_Fax = ``
+		if len(v) > 0 {
+			r.R_Fax = v[0]
+		}
+	case `currentAuthorityTitle`:
+		r.R_Title = ``
+		if len(v) > 0 {
+			r.R_Title = v[0]
+		}
+	case `currentAuthorityEmail`:
+		r.R_Email = ``
+		if len(v) > 0 {
+			r.R_Email = v[0]
+		}
+	case `currentAuthorityPOBox`:
+		r.R_POBox = ``
+		if len(v) > 0 {
+			r.R_POBox = v[0]
+		}
+	case `currentAuthorityPostalCode`:
+		r.R_PCode = ``
+		if len(v) > 0 {
+			r.R_PCode = v[0]
+		}
+	case `currentAuthorityPostalAddress`:
+		r.R_PAddr = ``
+		if len(v) > 0 {
+			r.R_PAddr = v[0]
+		}
+	case `currentAuthorityStreet`:
+		r.R_Street = ``
+		if len(v) > 0 {
+			r.R_Street = v[0]
+		}
+	case `currentAuthorityMobile`:
+		r.R_Mobile = ``
+		if len(v) > 0 {
+			r.R_Mobile = v[0]
+		}
+	case `currentAuthorityStartTimestamp`:
+		r.R_StartTime = ``
+		if len(v) > 0 {
+			r.R_StartTime = v[0]
+		}
+	case `currentAuthorityURI`:
+		r.R_URI = append([]string(nil), v...)
+	}
+}
+
+func (r Sponsor) unmarshal() map[string][]string {
+	m := make(map[string][]string, 21)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.R_Id) > 0 {
+		m[`registrantID`] = []string{r.R_Id}
+	}
+	if len(r.R_L) > 0 {
+		m[`sponsorLocality`] = []string{r.R_L}
+	}
+	if len(r.R_O) > 0 {
+		m[`sponsorOrg`] = []string{r.R_O}
+	}
+	if len(r.R_C) > 0 {
+		m[`sponsorCountryCode`] = []string{r.R_C}
+	}
+	if len(r.R_CO) > 0 {
+		m[`sponsorCountryName`] = []string{r.R_CO}
+	}
+	if len(r.R_ST) > 0 {
+		m[`sponsorState`] = []string{r.R_ST}
+	}
+	if len(r.R_CN) > 0 {
+		m[`sponsorCommonName`] = []string{r.R_CN}
+	}
+	if len(r.R_Tel) > 0 {
+		m[`sponsorTelephone`] = []string{r.R_Tel}
+	}
+	if len(r.R_Fax) > 0 {
+		m[`sponsorFax`] = []string{r.R_Fax}
+	}
+	if len(r.R_Title) > 0 {
+		m[`sponsorTitle`] = []string{r.R_Title}
+	}
+	if len(r.R_Email) > 0 {
+		m[`sponsorEmail`] = []string{r.R_Email}
+	}
+	if len(r.R_POBox) > 0 {
+		m[`sponsorPOBox`] = []string{r.R_POBox}
+	}
+	if len(r.R_PCode) > 0 {
+		m[`sponsorPostalCode`] = []string{r.R_PCode}
+	}
+	if len(r.R_PAddr) > 0 {
+		m[`sponsorPostalAddress`] = []string{r.R_PAddr}
+	}
+	if len(r.R_Street) > 0 {
+		m[`sponsorStreet`] = []string{r.R_Street}
+	}
+	if len(r.R_Mobile) > 0 {
+		m[`sponsorMobile`] = []string{r.R_Mobile}
+	}
+	if len(r.R_StartTime) > 0 {
+		m[`sponsorStartTimestamp`] = []string{r.R_StartTime}
+	}
+	if len(r.R_EndTime) > 0 {
+		m[`sponsorEndTimestamp`] = []string{r.R_EndTime}
+	}
+	if r.R_URI != nil {
+		m[`sponsorURI`] = r.R_URI
+	}
+
+	return m
+}
+
+func (r *Sponsor) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `dn`:
+			r.R_DN = v[0]
+		case `registrantID`:
+			r.R_Id = v[0]
+		case `sponsorLocality`:
+			r.R_L = v[0]
+		case `sponsorOrg`:
+			r.R_O = v[0]
+		case `sponsorCountryCode`:
+			r.R_C = v[0]
+		case `sponsorCountryName`:
+			r.R_CO = v[0]
+		case `sponsorState`:
+			r.R_ST = v[0]
+		case `sponsorCommonName`:
+			r.R_CN = v[0]
+		case `sponsorTelephone`:
+			r.R_Tel = v[0]
+		case `sponsorFax`:
+			r.R_Fax = v[0]
+		case `sponsorTitle`:
+			r.R_Title = v[0]
+		case `sponsorEmail`:
+			r.R_Email = v[0]
+		case `sponsorPOBox`:
+			r.R_POBox = v[0]
+		case `sponsorPostalCode`:
+			r.R_PCode = v[0]
+		case `sponsorPostalAddress`:
+			r.R_PAddr = v[0]
+		case `sponsorStreet`:
+			r.R_Street = v[0]
+		case `sponsorMobile`:
+			r.R_Mobile = v[0]
+		case `sponsorStartTimestamp`:
+			r.R_StartTime = v[0]
+		case `sponsorEndTimestamp`:
+			r.R_EndTime = v[0]
+		case `sponsorURI`:
+			r.R_URI = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `dn`:
+				r.R_DN = v[0]
+			case `registrantid`:
+				r.R_Id = v[0]
+			case `sponsorlocality`:
+				r.R_L = v[0]
+			case `sponsororg`:
+				r.R_O = v[0]
+			case `sponsorcountrycode`:
+				r.R_C = v[0]
+			case `sponsorcountryname`:
+				r.R_CO = v[0]
+			case `sponsorstate`:
+				r.R_ST = v[0]
+			case `sponsorcommonname`:
+				r.R_CN = v[0]
+			case `sponsortelephone`:
+				r.R_Tel = v[0]
+			case `sponsorfax`:
+				r.R_Fax = v[0]
+			case `sponsortitle`:
+				r.R_Title = v[0]
+			case `sponsoremail`:
+				r.R_Email = v[0]
+			case `sponsorpobox`:
+				r.R_POBox = v[0]
+			case `sponsorpostalcode`:
+				r.R_PCode = v[0]
+			case `sponsorpostaladdress`:
+				r.R_PAddr = v[0]
+			case `sponsorstreet`:
+				r.R_Street = v[0]
+			case `sponsormobile`:
+				r.R_Mobile = v[0]
+			case `sponsorstarttimestamp`:
+				r.R_StartTime = v[0]
+			case `sponsorendtimestamp`:
+				r.R_EndTime = v[0]
+			case `sponsoruri`:
+				r.R_URI = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r Sponsor) multiValued(at string) bool {
+	switch at {
+	case `sponsorURI`:
+		return true
+	}
+
+	return false
+}
+
+func (r Sponsor) attributeTypes() []string {
+	return []string{`registrantID`, `sponsorLocality`, `sponsorOrg`, `sponsorCountryCode`, `sponsorCountryName`, `sponsorState`, `sponsorCommonName`, `sponsorTelephone`, `sponsorFax`, `sponsorTitle`, `sponsorEmail`, `sponsorPOBox`, `sponsorPostalCode`, `sponsorPostalAddress`, `sponsorStreet`, `sponsorMobile`, `sponsorStartTimestamp`, `sponsorEndTimestamp`, `sponsorURI`}
+}
+
+func (r Sponsor) getAttr(at string) []string {
+	switch at {
+	case `registrantID`:
+		if len(r.R_Id) > 0 {
+			return []string{r.R_Id}
+		}
+	case `sponsorLocality`:
+		if len(r.R_L) > 0 {
+			return []string{r.R_L}
+		}
+	case `sponsorOrg`:
+		if len(r.R_O) > 0 {
+			return []string{r.R_O}
+		}
+	case `sponsorCountryCode`:
+		if len(r.R_C) > 0 {
+			return []string{r.R_C}
+		}
+	case `sponsorCountryName`:
+		if len(r.R_CO) > 0 {
+			return []string{r.R_CO}
+		}
+	case `sponsorState`:
+		if len(r.R_ST) > 0 {
+			return []string{r.R_ST}
+		}
+	case `sponsorCommonName`:
+		if len(r.R_CN) > 0 {
+			return []string{r.R_CN}
+		}
+	case `sponsorTelephone`:
+		if len(r.R_Tel) > 0 {
+			return []string{r.R_Tel}
+		}
+	case `sponsorFax`:
+		if len(r.R_Fax) > 0 {
+			return []string{r.R_Fax}
+		}
+	case `sponsorTitle`:
+		if len(r.R_Title) > 0 {
+			return []string{r.R_Title}
+		}
+	case `sponsorEmail`:
+		if len(r.R_Email) > 0 {
+			return []string{r.R_Email}
+		}
+	case `sponsorPOBox`:
+		if len(r.R_POBox) > 0 {
+			return []string{r.R_POBox}
+		}
+	case `sponsorPostalCode`:
+		if len(r.R_PCode) > 0 {
+			return []string{r.R_PCode}
+		}
+	case `sponsorPostalAddress`:
+		if len(r.R_PAddr) > 0 {
+			return []string{r.R_PAddr}
+		}
+	case `sponsorStreet`:
+		if len(r.R_Street) > 0 {
+			return []string{r.R_Street}
+		}
+	case `sponsorMobile`:
+		if len(r.R_Mobile) > 0 {
+			return []string{r.R_Mobile}
+		}
+	case `sponsorStartTimestamp`:
+		if len(r.R_StartTime) > 0 {
+			return []string{r.R_StartTime}
+		}
+	case `sponsorEndTimestamp`:
+		if len(r.R_EndTime) > 0 {
+			return []string{r.R_EndTime}
+		}
+	case `sponsorURI`:
+		return append([]string(nil), r.R_URI...)
+	}
+
+	return nil
+}
+
+func (r *Sponsor) setAttr(at string, v []string) {
+	switch at {
+	case `registrantID`:
+		r.R_Id = ``
+		if len(v) > 0 {
+			r.R_Id = v[0]
+		}
+	case `sponsorLocality`:
+		r.R_L = ``
+		if len(v) > 0 {
+			r.R_L = v[0]
+		}
+	case `sponsorOrg`:
+		r.R_O = ``
+		if len(v) > 0 {
+			r.R_O = v[0]
+		}
+	case `sponsorCountryCode`:
+		r.R_C = ``
+		if len(v) > 0 {
+			r.R_C = v[0]
+		}
+	case `sponsorCountryName`:
+		r.R_CO = ``
+		if len(v) > 0 {
+			r.R_CO = v[0]
+		}
+	case `sponsorState`:
+		r.R_ST = ``
+		if len(v) > 0 {
+			r.R_ST = v[0]
+		}
+	case `sponsorCommonName`:
+		r.R_CN = ``
+		if len(v) > 0 {
+			r.R_CN = v[0]
+		}
+	case `sponsorTelephone`:
+		r.R_Tel = ``
+		if len(v) > 0 {
+			r.R_Tel = v[0]
+		}
+	case `sponsorFax`:
+		r.R_Fax = ``
+		if len(v) > 0 {
+			r.R_Fax = v[0]
+		}
+	case `sponsorTitle`:
+		r.R_Title = ``
+		if len(v) > 0 {
+			r.R_Title = v[0]
+		}
+	case `sponsorEmail`:
+		r.R_Email = ``
+		if len(v) > 0 {
+			r.R_Email = v[0]
+		}
+	case `sponsorPOBox`:
+		r.R_POBox = ``
+		if len(v) > 0 {
+			r.R_POBox = v[0]
+		}
+	case `sponsorPostalCode`:
+		r.R_PCode = ``
+		if len(v) > 0 {
+			r.R_PCode = v[0]
+		}
+	case `sponsorPostalAddress`:
+		r.R_PAddr = ``
+		if len(v) > 0 {
+			r.R_PAddr = v[0]
+		}
+	case `sponsorStreet`:
+		r.R_Street = ``
+		if len(v) > 0 {
+			r.R_Street = v[0]
+		}
+	case `sponsorMobile`:
+		r.R_Mobile = ``
+		if len(v) > 0 {
+			r.R_Mobile = v[0]
+		}
+	case `sponsorStartTimestamp`:
+		r.R_StartTime = ``
+		if len(v) > 0 {
+			r.R_StartTime = v[0]
+		}
+	case `sponsorEndTimestamp`:
+		r.R_EndTime = ``
+		if len(v) > 0 {
+			r.R_EndTime = v[0]
+		}
+	case `sponsorURI`:
+		r.R_URI = append([]string(nil), v...)
+	}
+}
+
+func (r DUAConfig) unmarshal() map[string][]string {
+	m := make(map[string][]string, 6)
+	m[`objectClass`] = []string{`top`, r.ObjectClass()}
+	if len(r.DirectoryModel) > 0 {
+		m[`rADirectoryModel`] = []string{r.DirectoryModel}
+	}
+	if r.Registrations != nil {
+		m[`rARegistrationBase`] = r.Registrations
+	}
+	if r.Registrants != nil {
+		m[`rARegistrantBase`] = r.Registrants
+	}
+	if r.ServiceEmails != nil {
+		m[`rAServiceMail`] = r.ServiceEmails
+	}
+	if r.ServiceURIs != nil {
+		m[`rAServiceURI`] = r.ServiceURIs
+	}
+
+	return m
+}
+
+func (r *DUAConfig) marshal(m map[string][]string) {
+	for k, v := range m {
+		if len(v) == 0 {
+			continue
+		}
+
+		switch k {
+		case `rADirectoryModel`:
+			r.DirectoryModel = v[0]
+		case `rARegistrationBase`:
+			r.Registrations = append([]string{}, v...)
+		case `rARegistrantBase`:
+			r.Registrants = append([]string{}, v...)
+		case `rAServiceMail`:
+			r.ServiceEmails = append([]string{}, v...)
+		case `rAServiceURI`:
+			r.ServiceURIs = append([]string{}, v...)
+		default:
+			switch lc(k) {
+			case `radirectorymodel`:
+				r.DirectoryModel = v[0]
+			case `raregistrationbase`:
+				r.Registrations = append([]string{}, v...)
+			case `raregistrantbase`:
+				r.Registrants = append([]string{}, v...)
+			case `raservicemail`:
+				r.ServiceEmails = append([]string{}, v...)
+			case `raserviceuri`:
+				r.ServiceURIs = append([]string{}, v...)
+			}
+		}
+	}
+}
+
+func (r DUAConfig) multiValued(at string) bool {
+	switch at {
+	case `rARegistrationBase`, `rARegistrantBase`, `rAServiceMail`, `rAServiceURI`:
+		return true
+	}
+
+	return false
+}
+
+func (r DUAConfig) attributeTypes() []string {
+	return []string{`rADirectoryModel`, `rARegistrationBase`, `rARegistrantBase`, `rAServiceMail`, `rAServiceURI`}
+}
+
+func (r DUAConfig) getAttr(at string) []string {
+	switch at {
+	case `rADirectoryModel`:
+		if len(r.DirectoryModel) > 0 {
+			return []string{r.DirectoryModel}
+		}
+	case `rARegistrationBase`:
+		return append([]string(nil), r.Registrations...)
+	case `rARegistrantBase`:
+		return append([]string(nil), r.Registrants...)
+	case `rAServiceMail`:
+		return append([]string(nil), r.ServiceEmails...)
+	case `rAServiceURI`:
+		return append([]string(nil), r.ServiceURIs...)
+	}
+
+	return nil
+}
+
+func (r *DUAConfig) setAttr(at string, v []string) {
+	switch at {
+	case `rADirectoryModel`:
+		r.DirectoryModel = ``
+		if len(v) > 0 {
+			r.DirectoryModel = v[0]
+		}
+	case `rARegistrationBase`:
+		r.Registrations = append([]string(nil), v...)
+	case `rARegistrantBase`:
+		r.Registrants = append([]string(nil), v...)
+	case `rAServiceMail`:
+		r.ServiceEmails = append([]string(nil), v...)
+	case `rAServiceURI`:
+		r.ServiceURIs = append([]string(nil), v...)
+	}
+}
+
+const (
+	_                                   Field = iota
+	FieldN                                    // n
+	FieldDescription                          // description
+	FieldASN1Notation                         // asn1Notation
+	FieldIdentifier                           // identifier
+	FieldRegistrationCreated                  // registrationCreated
+	FieldNameAndNumberForm                    // nameAndNumberForm
+	FieldLeftArc                              // leftArc
+	FieldRightArc                             // rightArc
+	FieldRegistrationModified                 // registrationModified
+	FieldSubArc                               // subArc
+	FieldStdNameForm                          // stdNameForm
+	FieldIRI                                  // iRI
+	FieldUnicodeValue                         // unicodeValue
+	FieldAdditionalIdentifier                 // additionalIdentifier
+	FieldRegistrationInformation              // registrationInformation
+	FieldRegistrationURI                      // registrationURI
+	FieldFirstAuthority                       // firstAuthority
+	FieldCurrentAuthority                     // currentAuthority
+	FieldDotNotation                          // dotNotation
+	FieldRegistrationRange                    // registrationRange
+	FieldRegistrationStatus                   // registrationStatus
+	FieldIsLeafNode                           // isLeafNode
+	FieldIsFrozen                             // isFrozen
+	FieldSupArc                               // supArc
+	FieldTopArc                               // topArc
+	FieldFirstArc                             // firstArc
+	FieldFinalArc                             // finalArc
+	FieldLongArc                              // longArc
+	FieldDiscloseTo                           // discloseTo
+	FieldSponsor                              // sponsor
+	FieldRegistrantID                         // registrantID
+	FieldFirstAuthorityLocality               // firstAuthorityLocality
+	FieldFirstAuthorityOrg                    // firstAuthorityOrg
+	FieldFirstAuthorityCountryCode            // firstAuthorityCountryCode
+	FieldFirstAuthorityCountryName            // firstAuthorityCountryName
+	FieldFirstAuthorityState                  // firstAuthorityState
+	FieldFirstAuthorityCommonName             // firstAuthorityCommonName
+	FieldFirstAuthorityTelephone              // firstAuthorityTelephone
+	FieldFirstAuthorityFax                    // firstAuthorityFax
+	FieldFirstAuthorityTitle                  // firstAuthorityTitle
+	FieldFirstAuthorityEmail                  // firstAuthorityEmail
+	FieldFirstAuthorityPOBox                  // firstAuthorityPOBox
+	FieldFirstAuthorityPostalCode             // firstAuthorityPostalCode
+	FieldFirstAuthorityPostalAddress          // firstAuthorityPostalAddress
+	FieldFirstAuthorityStreet                 // firstAuthorityStreet
+	FieldFirstAuthorityMobile                 // firstAuthorityMobile
+	FieldFirstAuthorityStartTimestamp         // firstAuthorityStartTimestamp
+	FieldFirstAuthorityEndTimestamp           // firstAuthorityEndTimestamp
+	FieldFirstAuthorityURI                    // firstAuthorityURI
+	FieldCurrentAuthorityLocality             // currentAuthorityLocality
+	FieldCurrentAuthorityOrg                  // currentAuthorityOrg
+	FieldCurrentAuthorityCountryCode          // currentAuthorityCountryCode
+	FieldCurrentAuthorityCountryName          // currentAuthorityCountryName
+	FieldCurrentAuthorityState                // currentAuthorityState
+	FieldCurrentAuthorityCommonName           // currentAuthorityCommonName
+	FieldCurrentAuthorityTelephone            // currentAuthorityTelephone
+	FieldCurrentAuthorityFax                  // currentAuthorityFax
+	FieldCurrentAuthorityTitle                // currentAuthorityTitle
+	FieldCurrentAuthorityEmail                // currentAuthorityEmail
+	FieldCurrentAuthorityPOBox                // currentAuthorityPOBox
+	FieldCurrentAuthorityPostalCode           // currentAuthorityPostalCode
+	FieldCurrentAuthorityPostalAddress        // currentAuthorityPostalAddress
+	FieldCurrentAuthorityStreet               // currentAuthorityStreet
+	FieldCurrentAuthorityMobile               // currentAuthorityMobile
+	FieldCurrentAuthorityStartTimestamp       // currentAuthorityStartTimestamp
+	FieldCurrentAuthorityURI                  // currentAuthorityURI
+	FieldSponsorLocality                      // sponsorLocality
+	FieldSponsorOrg                           // sponsorOrg
+	FieldSponsorCountryCode                   // sponsorCountryCode
+	FieldSponsorCountryName                   // sponsorCountryName
+	FieldSponsorState                         // sponsorState
+	FieldSponsorCommonName                    // sponsorCommonName
+	FieldSponsorTelephone                     // sponsorTelephone
+	FieldSponsorFax                           // sponsorFax
+	FieldSponsorTitle                         // sponsorTitle
+	FieldSponsorEmail                         // sponsorEmail
+	FieldSponsorPOBox                         // sponsorPOBox
+	FieldSponsorPostalCode                    // sponsorPostalCode
+	FieldSponsorPostalAddress                 // sponsorPostalAddress
+	FieldSponsorStreet                        // sponsorStreet
+	FieldSponsorMobile                        // sponsorMobile
+	FieldSponsorStartTimestamp                // sponsorStartTimestamp
+	FieldSponsorEndTimestamp                  // sponsorEndTimestamp
+	FieldSponsorURI                           // sponsorURI
+	FieldRADirectoryModel                     // rADirectoryModel
+	FieldRARegistrationBase                   // rARegistrationBase
+	FieldRARegistrantBase                     // rARegistrantBase
+	FieldRAServiceMail                        // rAServiceMail
+	FieldRAServiceURI                         // rAServiceURI
+)
+
+var fieldNames = [...]string{
+	FieldN:                              `n`,
+	FieldDescription:                    `description`,
+	FieldASN1Notation:                   `asn1Notation`,
+	FieldIdentifier:                     `identifier`,
+	FieldRegistrationCreated:            `registrationCreated`,
+	FieldNameAndNumberForm:              `nameAndNumberForm`,
+	FieldLeftArc:                        `leftArc`,
+	FieldRightArc:                       `rightArc`,
+	FieldRegistrationModified:           `registrationModified`,
+	FieldSubArc:                         `subArc`,
+	FieldStdNameForm:                    `stdNameForm`,
+	FieldIRI:                            `iRI`,
+	FieldUnicodeValue:                   `unicodeValue`,
+	FieldAdditionalIdentifier:           `additionalIdentifier`,
+	FieldRegistrationInformation:        `registrationInformation`,
+	FieldRegistrationURI:                `registrationURI`,
+	FieldFirstAuthority:                 `firstAuthority`,
+	FieldCurrentAuthority:               `currentAuthority`,
+	FieldDotNotation:                    `dotNotation`,
+	FieldRegistrationRange:              `registrationRange`,
+	FieldRegistrationStatus:             `registrationStatus`,
+	FieldIsLeafNode:                     `isLeafNode`,
+	FieldIsFrozen:                       `isFrozen`,
+	FieldSupArc:                         `supArc`,
+	FieldTopArc:                         `topArc`,
+	FieldFirstArc:                       `firstArc`,
+	FieldFinalArc:                       `finalArc`,
+	FieldLongArc:                        `longArc`,
+	FieldDiscloseTo:                     `discloseTo`,
+	FieldSponsor:                        `sponsor`,
+	FieldRegistrantID:                   `registrantID`,
+	FieldFirstAuthorityLocality:         `firstAuthorityLocality`,
+	FieldFirstAuthorityOrg:              `firstAuthorityOrg`,
+	FieldFirstAuthorityCountryCode:      `firstAuthorityCountryCode`,
+	FieldFirstAuthorityCountryName:      `firstAuthorityCountryName`,
+	FieldFirstAuthorityState:            `firstAuthorityState`,
+	FieldFirstAuthorityCommonName:       `firstAuthorityCommonName`,
+	FieldFirstAuthorityTelephone:        `firstAuthorityTelephone`,
+	FieldFirstAuthorityFax:              `firstAuthorityFax`,
+	FieldFirstAuthorityTitle:            `firstAuthorityTitle`,
+	FieldFirstAuthorityEmail:            `firstAuthorityEmail`,
+	FieldFirstAuthorityPOBox:            `firstAuthorityPOBox`,
+	FieldFirstAuthorityPostalCode:       `firstAuthorityPostalCode`,
+	FieldFirstAuthorityPostalAddress:    `firstAuthorityPostalAddress`,
+	FieldFirstAuthorityStreet:           `firstAuthorityStreet`,
+	FieldFirstAuthorityMobile:           `firstAuthorityMobile`,
+	FieldFirstAuthorityStartTimestamp:   `firstAuthorityStartTimestamp`,
+	FieldFirstAuthorityEndTimestamp:     `firstAuthorityEndTimestamp`,
+	FieldFirstAuthorityURI:              `firstAuthorityURI`,
+	FieldCurrentAuthorityLocality:       `currentAuthorityLocality`,
+	FieldCurrentAuthorityOrg:            `currentAuthorityOrg`,
+	FieldCurrentAuthorityCountryCode:    `currentAuthorityCountryCode`,
+	FieldCurrentAuthorityCountryName:    `currentAuthorityCountryName`,
+	FieldCurrentAuthorityState:          `currentAuthorityState`,
+	FieldCurrentAuthorityCommonName:     `currentAuthorityCommonName`,
+	FieldCurrentAuthorityTelephone:      `currentAuthorityTelephone`,
+	FieldCurrentAuthorityFax:            `currentAuthorityFax`,
+	FieldCurrentAuthorityTitle:          `currentAuthorityTitle`,
+	FieldCurrentAuthorityEmail:          `currentAuthorityEmail`,
+	FieldCurrentAuthorityPOBox:          `currentAuthorityPOBox`,
+	FieldCurrentAuthorityPostalCode:     `currentAuthorityPostalCode`,
+	FieldCurrentAuthorityPostalAddress:  `currentAuthorityPostalAddress`,
+	FieldCurrentAuthorityStreet:         `currentAuthorityStreet`,
+	FieldCurrentAuthorityMobile:         `currentAuthorityMobile`,
+	FieldCurrentAuthorityStartTimestamp: `currentAuthorityStartTimestamp`,
+	FieldCurrentAuthorityURI:            `currentAuthorityURI`,
+	FieldSponsorLocality:                `sponsorLocality`,
+	FieldSponsorOrg:                     `sponsorOrg`,
+	FieldSponsorCountryCode:             `sponsorCountryCode`,
+	FieldSponsorCountryName:             `sponsorCountryName`,
+	FieldSponsorState:                   `sponsorState`,
+	FieldSponsorCommonName:              `sponsorCommonName`,
+	FieldSponsorTelephone:               `sponsorTelephone`,
+	FieldSponsorFax:                     `sponsorFax`,
+	FieldSponsorTitle:                   `sponsorTitle`,
+	FieldSponsorEmail:                   `sponsorEmail`,
+	FieldSponsorPOBox:                   `sponsorPOBox`,
+	FieldSponsorPostalCode:              `sponsorPostalCode`,
+	FieldSponsorPostalAddress:           `sponsorPostalAddress`,
+	FieldSponsorStreet:                  `sponsorStreet`,
+	FieldSponsorMobile:                  `sponsorMobile`,
+	FieldSponsorStartTimestamp:          `sponsorStartTimestamp`,
+	FieldSponsorEndTimestamp:            `sponsorEndTimestamp`,
+	FieldSponsorURI:                     `sponsorURI`,
+	FieldRADirectoryModel:               `rADirectoryModel`,
+	FieldRARegistrationBase:             `rARegistrationBase`,
+	FieldRARegistrantBase:               `rARegistrantBase`,
+	FieldRAServiceMail:                  `rAServiceMail`,
+	FieldRAServiceURI:                   `rAServiceURI`,
+}
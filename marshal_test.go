@@ -0,0 +1,101 @@
+package dcxl
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+marshalTypes returns a populated instance of each entry type, wherein
+every `ldap`-tagged field bears a distinct value.
+*/
+func marshalTypes() []any {
+	xs := []any{new(RootArc), new(SubArc), new(FirstAuthority),
+		new(CurrentAuthority), new(Sponsor), new(DUAConfig)}
+
+	for _, x := range xs {
+		v := reflect.ValueOf(x).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if _, found := v.Type().Field(i).Tag.Lookup(`ldap`); !found {
+				continue
+			}
+
+			val := v.Type().Name() + `.` + v.Type().Field(i).Name
+			switch f := v.Field(i); f.Kind() {
+			case reflect.String:
+				f.SetString(val)
+			case reflect.Slice:
+				f.Set(reflect.ValueOf([]string{val + `.0`, val + `.1`}))
+			}
+		}
+	}
+
+	return xs
+}
+
+/*
+TestGeneratedMarshal verifies the generated unmarshal and marshal methods
+against the reflective toMap and fromMap functions. A failure here
+usually means marshal_gen.go must be regenerated using go generate.
+*/
+func TestGeneratedMarshal(t *testing.T) {
+	for _, x := range marshalTypes() {
+		elem := reflect.ValueOf(x).Elem().Interface()
+		want := toMap(elem)
+		got := x.(interface{ Unmarshal() map[string][]string }).Unmarshal()
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%T.Unmarshal:\n got %v\nwant %v", x, got, want)
+		}
+
+		want[`dn`] = []string{`dn=x`}
+		viaReflect := reflect.New(reflect.TypeOf(elem))
+		viaMarshal := reflect.New(reflect.TypeOf(elem))
+		if err := fromMap(want, viaReflect.Interface()); err != nil {
+			t.Fatalf("%T: %v", x, err)
+		}
+		if err := viaMarshal.Interface().(interface {
+			Marshal(map[string][]string) error
+		}).Marshal(want); err != nil {
+			t.Fatalf("%T.Marshal: %v", x, err)
+		}
+		if !reflect.DeepEqual(viaMarshal.Interface(), viaReflect.Interface()) {
+			t.Errorf("%T.Marshal:\n got %+v\nwant %+v", x, viaMarshal.Interface(), viaReflect.Interface())
+		}
+	}
+}
+
+func BenchmarkUnmarshal(b *testing.B) {
+	sub := marshalTypes()[1].(*SubArc)
+
+	b.Run(`reflect`, func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			toMap(*sub)
+		}
+	})
+
+	b.Run(`generated`, func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sub.Unmarshal()
+		}
+	})
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	m := marshalTypes()[1].(*SubArc).Unmarshal()
+
+	b.Run(`reflect`, func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fromMap(m, new(SubArc))
+		}
+	})
+
+	b.Run(`generated`, func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			new(SubArc).Marshal(m)
+		}
+	})
+}
@@ -34,7 +34,7 @@ func (r *RootArc) SetDN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -76,7 +76,7 @@ func (r *SubArc) SetDN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -117,7 +117,7 @@ func (r *RootArc) SetDescription(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Description type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -158,7 +158,7 @@ func (r *SubArc) SetDescription(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Description type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -197,7 +197,7 @@ func (r *RootArc) SetN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported N type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -236,7 +236,7 @@ func (r *SubArc) SetN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported N type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -300,7 +300,7 @@ func (r *SubArc) SetDotNotation(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported N type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -329,7 +329,7 @@ func (r *RootArc) SetASN1Notation(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ASN.1 Notation type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -370,7 +370,7 @@ func (r *SubArc) SetASN1Notation(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ASN.1 Notation type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -411,7 +411,7 @@ func (r *RootArc) SetNameAndNumberForm(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported NameAndNumberForm type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -452,7 +452,7 @@ func (r *SubArc) SetNameAndNumberForm(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported NameAndNumberForm type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -493,7 +493,7 @@ func (r *RootArc) SetLeftArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported LeftArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -535,7 +535,7 @@ func (r *SubArc) SetLeftArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported LeftArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -600,7 +600,7 @@ func (r *SubArc) SetFirstArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported FirstArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -664,7 +664,7 @@ func (r *SubArc) SetFinalArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported FinalArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -704,7 +704,7 @@ func (r *RootArc) SetRightArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported RightArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -745,7 +745,7 @@ func (r *SubArc) SetRightArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported RightArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -810,7 +810,7 @@ func (r *SubArc) SetTopArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported TopArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -873,7 +873,7 @@ func (r *SubArc) SetSupArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported SupArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -915,7 +915,7 @@ func (r *RootArc) SetIdentifier(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Identifier type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -957,7 +957,7 @@ func (r *SubArc) SetIdentifier(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Identifier type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1004,7 +1004,7 @@ func (r *RootArc) SetAdditionalIdentifier(X any, setfunc ...GetOrSetFunc) error
 		return errorf("Unsupported AdditionalIdentifier type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1050,7 +1050,7 @@ func (r *SubArc) SetAdditionalIdentifier(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported AdditionalIdentifier type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1096,7 +1096,7 @@ func (r *RootArc) SetCurrentAuthority(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CurrentAuthority type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1161,7 +1161,7 @@ func (r *SubArc) SetCurrentAuthority(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CurrentAuthority type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1232,7 +1232,7 @@ func (r *RootArc) SetFirstAuthority(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CurrentAuthority type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1297,7 +1297,7 @@ func (r *SubArc) SetFirstAuthority(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CurrentAuthority type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1404,7 +1404,7 @@ func (r *SubArc) SetSponsor(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Sponsor type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1490,7 +1490,7 @@ func (r *SubArc) SetDiscloseTo(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DiscloseTo type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1536,7 +1536,7 @@ func (r *RootArc) SetIRI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported IRI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1583,7 +1583,7 @@ func (r *SubArc) SetIRI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported IRI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1630,7 +1630,7 @@ func (r *RootArc) SetUnicodeValue(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported UnicodeValue type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1677,7 +1677,7 @@ func (r *SubArc) SetUnicodeValue(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported UnicodeValue type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1724,7 +1724,7 @@ func (r *RootArc) SetInfo(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Information type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1771,7 +1771,7 @@ func (r *SubArc) SetInfo(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Information type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1818,7 +1818,7 @@ func (r *RootArc) SetStdNameForm(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported StdNameForm type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1865,7 +1865,7 @@ func (r *SubArc) SetStdNameForm(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported StdNameForm type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1912,7 +1912,7 @@ func (r *RootArc) SetURI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported URI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -1959,7 +1959,7 @@ func (r *SubArc) SetURI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported URI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2035,7 +2035,7 @@ func (r *SubArc) SetLongArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported LongArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2102,7 +2102,7 @@ func (r *SubArc) SetRange(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Range type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2179,7 +2179,7 @@ func (r *SubArc) SetLeafNode(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported LeafNode type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2223,8 +2223,18 @@ FrozenGetFunc performs no useful task. This method exists only to
 satisfy Go interface requirements, and does not apply to root
 registrations.
 */
+func (r RootArc) FrozenGetFunc(getfunc GetOrSetFunc) (any, error) {
+	return nil, errorf("Frozen not applicable to %T", r)
+}
+
+/*
+FrozenNodeGetFunc is the former name of the FrozenGetFunc method, and
+is retained for compatibility.
+
+Deprecated: use FrozenGetFunc.
+*/
 func (r RootArc) FrozenNodeGetFunc(getfunc GetOrSetFunc) (any, error) {
-	return nil, errorf("Leaf node not applicable to %T", r)
+	return r.FrozenGetFunc(getfunc)
 }
 
 /*
@@ -2256,7 +2266,7 @@ func (r *SubArc) SetFrozen(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Frozen type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2302,7 +2312,7 @@ func (r *RootArc) SetSubArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported SubArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2348,7 +2358,7 @@ func (r *SubArc) SetSubArc(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported SubArc type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2415,7 +2425,7 @@ func (r *SubArc) SetStatus(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Status type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2442,7 +2452,7 @@ of map[string][]string, which can subsequently be fed to go-ldap's
 NewEntry function.
 */
 func (r RootArc) Unmarshal() map[string][]string {
-	return toMap(r)
+	return r.unmarshal()
 }
 
 /*
@@ -2451,7 +2461,78 @@ of map[string][]string, which can subsequently be fed to go-ldap's
 NewEntry function.
 */
 func (r SubArc) Unmarshal() map[string][]string {
-	return toMap(r)
+	return r.unmarshal()
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from an LDAP entry, into the receiver. This is the
+inverse of the Unmarshal method.
+
+If the map contains first or current authority attribute types (e.g.:
+those of a COMBINED entry), the R_FAuthy and R_CAuthy fields are also
+populated.
+*/
+func (r *RootArc) Marshal(m map[string][]string) (err error) {
+	if r == nil {
+		err = NilRegistrationErr
+		return
+	}
+
+	r.marshal(m)
+
+	if hasPrefixedAttr(m, `firstAuthority`) {
+		r.R_FAuthy = new(FirstAuthority)
+		if err = r.R_FAuthy.Marshal(m); err != nil {
+			return
+		}
+	}
+
+	if hasPrefixedAttr(m, `currentAuthority`) {
+		r.R_CAuthy = new(CurrentAuthority)
+		err = r.R_CAuthy.Marshal(m)
+	}
+
+	return
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from an LDAP entry, into the receiver. This is the
+inverse of the Unmarshal method.
+
+If the map contains first authority, current authority or sponsor attribute
+types (e.g.: those of a COMBINED entry), the R_FAuthy, R_CAuthy and R_SAuthy
+fields are also populated.
+*/
+func (r *SubArc) Marshal(m map[string][]string) (err error) {
+	if r == nil {
+		err = NilRegistrationErr
+		return
+	}
+
+	r.marshal(m)
+
+	if hasPrefixedAttr(m, `firstAuthority`) {
+		r.R_FAuthy = new(FirstAuthority)
+		if err = r.R_FAuthy.Marshal(m); err != nil {
+			return
+		}
+	}
+
+	if hasPrefixedAttr(m, `currentAuthority`) {
+		r.R_CAuthy = new(CurrentAuthority)
+		if err = r.R_CAuthy.Marshal(m); err != nil {
+			return
+		}
+	}
+
+	if hasPrefixedAttr(m, `sponsor`) {
+		r.R_SAuthy = new(Sponsor)
+		err = r.R_SAuthy.Marshal(m)
+	}
+
+	return
 }
 
 /*
@@ -2542,7 +2623,7 @@ func (r *FirstAuthority) SetDN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2582,7 +2663,7 @@ func (r *CurrentAuthority) SetDN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2622,7 +2703,7 @@ func (r *Sponsor) SetDN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported DN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2736,7 +2817,7 @@ func (r *RootArc) SetCreateTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CreateTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2777,7 +2858,7 @@ func (r *SubArc) SetCreateTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CreateTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2824,7 +2905,7 @@ func (r *RootArc) SetModifyTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ModifyTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2870,7 +2951,7 @@ func (r *SubArc) SetModifyTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ModifyTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2898,7 +2979,7 @@ func (r *FirstAuthority) SetCN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2938,7 +3019,7 @@ func (r *CurrentAuthority) SetCN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -2978,7 +3059,7 @@ func (r *Sponsor) SetCN(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CN type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3018,7 +3099,7 @@ func (r *FirstAuthority) SetL(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported L type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3058,7 +3139,7 @@ func (r *CurrentAuthority) SetL(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported L type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3098,7 +3179,7 @@ func (r *Sponsor) SetL(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported L type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3138,7 +3219,7 @@ func (r *FirstAuthority) SetO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported O type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3178,7 +3259,7 @@ func (r *CurrentAuthority) SetO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported O type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3218,7 +3299,7 @@ func (r *Sponsor) SetO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported O type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3258,7 +3339,7 @@ func (r *FirstAuthority) SetC(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported C type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3298,7 +3379,7 @@ func (r *CurrentAuthority) SetC(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported C type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3338,7 +3419,7 @@ func (r *Sponsor) SetC(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported C type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3378,7 +3459,7 @@ func (r *FirstAuthority) SetCO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CO type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3418,7 +3499,7 @@ func (r *CurrentAuthority) SetCO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CO type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3458,7 +3539,7 @@ func (r *Sponsor) SetCO(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported CO type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3498,7 +3579,7 @@ func (r *FirstAuthority) SetST(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ST type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3538,7 +3619,7 @@ func (r *CurrentAuthority) SetST(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ST type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3578,7 +3659,7 @@ func (r *Sponsor) SetST(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported ST type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3618,7 +3699,7 @@ func (r *FirstAuthority) SetTel(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Tel type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3658,7 +3739,7 @@ func (r *CurrentAuthority) SetTel(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Tel type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3698,7 +3779,7 @@ func (r *Sponsor) SetTel(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Tel type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3738,7 +3819,7 @@ func (r *FirstAuthority) SetFax(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Fax type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3778,7 +3859,7 @@ func (r *CurrentAuthority) SetFax(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Fax type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3818,7 +3899,7 @@ func (r *Sponsor) SetFax(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Fax type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3858,7 +3939,7 @@ func (r *FirstAuthority) SetTitle(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Title type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3898,7 +3979,7 @@ func (r *CurrentAuthority) SetTitle(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Title type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3938,7 +4019,7 @@ func (r *Sponsor) SetTitle(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Title type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -3978,7 +4059,7 @@ func (r *FirstAuthority) SetEmail(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Email type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4018,7 +4099,7 @@ func (r *CurrentAuthority) SetEmail(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Email type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4058,7 +4139,7 @@ func (r *Sponsor) SetEmail(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Email type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4098,7 +4179,7 @@ func (r *FirstAuthority) SetPOBox(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported POBox type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4138,7 +4219,7 @@ func (r *CurrentAuthority) SetPOBox(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported POBox type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4178,7 +4259,7 @@ func (r *Sponsor) SetPOBox(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported POBox type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4218,7 +4299,7 @@ func (r *FirstAuthority) SetPostalAddress(X any, setfunc ...GetOrSetFunc) error
 		return errorf("Unsupported PostalAddress type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4258,7 +4339,7 @@ func (r *CurrentAuthority) SetPostalAddress(X any, setfunc ...GetOrSetFunc) erro
 		return errorf("Unsupported PostalAddress type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4298,7 +4379,7 @@ func (r *Sponsor) SetPostalAddress(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported PostalAddress type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4338,7 +4419,7 @@ func (r *FirstAuthority) SetPostalCode(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported PostalCode type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4378,7 +4459,7 @@ func (r *CurrentAuthority) SetPostalCode(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported PostalCode type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4418,7 +4499,7 @@ func (r *Sponsor) SetPostalCode(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported PostalCode type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4458,7 +4539,7 @@ func (r *FirstAuthority) SetMobile(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Mobile type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4498,7 +4579,7 @@ func (r *CurrentAuthority) SetMobile(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Mobile type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4538,7 +4619,7 @@ func (r *Sponsor) SetMobile(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Mobile type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4578,7 +4659,7 @@ func (r *FirstAuthority) SetStreet(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Street type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4618,7 +4699,7 @@ func (r *CurrentAuthority) SetStreet(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Street type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4658,7 +4739,7 @@ func (r *Sponsor) SetStreet(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported Street type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4702,7 +4783,7 @@ func (r *FirstAuthority) SetURI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported URI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4746,7 +4827,7 @@ func (r *CurrentAuthority) SetURI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported URI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4790,7 +4871,7 @@ func (r *Sponsor) SetURI(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported URI type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4831,7 +4912,7 @@ func (r *FirstAuthority) SetStartTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported StartTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4860,7 +4941,7 @@ func (r *CurrentAuthority) SetStartTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported StartTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4889,7 +4970,7 @@ func (r *Sponsor) SetStartTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported StartTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4918,7 +4999,7 @@ func (r *FirstAuthority) SetRegistrantID(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported RegistrantID type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4939,7 +5020,7 @@ func (r *FirstAuthority) SetEndTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported EndTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -4969,7 +5050,7 @@ func (r *CurrentAuthority) SetRegistrantID(X any, setfunc ...GetOrSetFunc) error
 		return errorf("Unsupported RegistrantID type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -5010,7 +5091,7 @@ func (r *Sponsor) SetRegistrantID(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported RegistrantID type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -5031,7 +5112,7 @@ func (r *Sponsor) SetEndTime(X any, setfunc ...GetOrSetFunc) error {
 		return errorf("Unsupported EndTime type %T provided without GetOrSetFunc instance", X)
 	}
 
-	v, err := setfunc[0](X, r)
+	v, err := Compose(setfunc...)(X, r)
 	if err != nil {
 		return err
 	}
@@ -5117,7 +5198,7 @@ of map[string][]string, which can subsequently be fed to go-ldap's
 NewEntry function.
 */
 func (r FirstAuthority) Unmarshal() map[string][]string {
-	return toMap(r)
+	return r.unmarshal()
 }
 
 /*
@@ -5126,7 +5207,7 @@ of map[string][]string, which can subsequently be fed to go-ldap's
 NewEntry function.
 */
 func (r CurrentAuthority) Unmarshal() map[string][]string {
-	return toMap(r)
+	return r.unmarshal()
 }
 
 /*
@@ -5135,7 +5216,93 @@ of map[string][]string, which can subsequently be fed to go-ldap's
 NewEntry function.
 */
 func (r Sponsor) Unmarshal() map[string][]string {
-	return toMap(r)
+	return r.unmarshal()
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from an LDAP entry, into the receiver. This is the
+inverse of the Unmarshal method.
+*/
+func (r *FirstAuthority) Marshal(m map[string][]string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	r.marshal(m)
+
+	return nil
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from an LDAP entry, into the receiver. This is the
+inverse of the Unmarshal method.
+*/
+func (r *CurrentAuthority) Marshal(m map[string][]string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	r.marshal(m)
+
+	return nil
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from an LDAP entry, into the receiver. This is the
+inverse of the Unmarshal method.
+*/
+func (r *Sponsor) Marshal(m map[string][]string) error {
+	if r == nil {
+		return NilRegistrantErr
+	}
+
+	r.marshal(m)
+
+	return nil
+}
+
+/*
+ObjectClass returns the string name of the objectClass associated
+with the receiver, x660DUAConfig, defined in s. 2.2.3 of the ID.
+*/
+func (r DUAConfig) ObjectClass() string {
+	return `x660DUAConfig`
+}
+
+/*
+Kind returns the static string value `AUXILIARY` merely as a convenient
+reminder that this type is based upon an AUXILIARY objectClass definition.
+*/
+func (r DUAConfig) Kind() string {
+	return `AUXILIARY`
+}
+
+/*
+Unmarshal transports values from the receiver into an instance
+of map[string][]string, such as one suitable for publication by
+way of a Root DSE (see s. 3.5.1 of the ID). The Settings field
+is not included.
+*/
+func (r DUAConfig) Unmarshal() map[string][]string {
+	return r.unmarshal()
+}
+
+/*
+Marshal transports values from the input map[string][]string instance,
+such as one derived from a Root DSE, into the receiver. This is the
+inverse of the Unmarshal method.
+*/
+func (r *DUAConfig) Marshal(m map[string][]string) error {
+	if r == nil {
+		return DUAConfigValidityErr
+	}
+
+	r.marshal(m)
+
+	return nil
 }
 
 /*
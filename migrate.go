@@ -0,0 +1,529 @@
+package dcxl
+
+/*
+migrate.go contains the draft revision registry, revision detection and
+the conversion layer used to migrate entries between revisions of the ID.
+*/
+
+import (
+	"sort"
+	"sync"
+)
+
+/*
+DraftVersion describes a revision of the ID, e.g.: 8 for -08.
+*/
+type DraftVersion uint8
+
+const (
+	UnknownDraft DraftVersion = 0 // unknown or undetected revision
+	Draft08      DraftVersion = 8 // draft-coretta-x660-ldap-08
+)
+
+/*
+LatestDraft is the revision implemented by the types of this package.
+*/
+const LatestDraft DraftVersion = Draft08
+
+/*
+String returns the full name of the receiver revision, e.g.:
+draft-coretta-x660-ldap-08.
+*/
+func (r DraftVersion) String() string {
+	if r == UnknownDraft {
+		return `unknown`
+	}
+
+	return sprintf("draft-coretta-x660-ldap-%02d", uint8(r))
+}
+
+/*
+Schema returns the *Schema instance registered for the receiver revision,
+or nil if the revision is not registered.
+*/
+func (r DraftVersion) Schema() *Schema {
+	drafts.RLock()
+	defer drafts.RUnlock()
+
+	if fn, found := drafts.schemas[r]; found {
+		return fn()
+	}
+
+	return nil
+}
+
+/*
+Migration describes the renaming of attribute types and objectClasses
+between two (2) revisions of the ID. Each map is keyed by the name used
+in the From revision, and valued with the name used in the To revision.
+A zero length value indicates the definition was removed in the To
+revision.
+
+Definitions that are absent from these maps are assumed to retain their
+names, though any that are not defined within the schema of the To
+revision are dropped.
+*/
+type Migration struct {
+	From           DraftVersion
+	To             DraftVersion
+	AttributeTypes map[string]string
+	ObjectClasses  map[string]string
+}
+
+/*
+invert returns the inverse of the receiver, for use when migrating in
+the opposite direction.
+*/
+func (r Migration) invert() (inv Migration) {
+	inv.From, inv.To = r.To, r.From
+	flip := func(src map[string]string) (dst map[string]string) {
+		dst = make(map[string]string, len(src))
+		for k, v := range src {
+			if len(v) > 0 {
+				dst[v] = k
+			}
+		}
+		return
+	}
+	inv.AttributeTypes = flip(r.AttributeTypes)
+	inv.ObjectClasses = flip(r.ObjectClasses)
+
+	return
+}
+
+/*
+rename returns the name to which the input name is mapped, alongside a
+boolean value indicative of whether a mapping was found.
+*/
+func rename(m map[string]string, name string) (string, bool) {
+	for k, v := range m {
+		if eq(k, name) {
+			return v, true
+		}
+	}
+
+	return name, false
+}
+
+/*
+drafts is the registry of known revisions and the migrations between
+them.
+*/
+var drafts = struct {
+	sync.RWMutex
+	schemas    map[DraftVersion]func() *Schema
+	migrations map[[2]DraftVersion]Migration
+}{
+	schemas: map[DraftVersion]func() *Schema{
+		Draft08: DraftSchema,
+	},
+	migrations: make(map[[2]DraftVersion]Migration),
+}
+
+/*
+RegisterDraft registers the input revision alongside a function that
+returns its schema, allowing entries to be detected as conforming to,
+and migrated to or from, said revision. An error is returned if the
+revision is zero, already registered or if schema is nil.
+*/
+func RegisterDraft(v DraftVersion, schema func() *Schema) error {
+	if v == UnknownDraft || schema == nil {
+		return errorf("Invalid %T registration '%d'", v, v)
+	}
+
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	if _, found := drafts.schemas[v]; found {
+		return errorf("%s is already registered", v)
+	}
+	drafts.schemas[v] = schema
+
+	return nil
+}
+
+/*
+RegisterMigration registers the input Migration, which shall be used
+when migrating entries from its From revision to its To revision. The
+inverse is used when migrating in the opposite direction, unless one is
+registered explicitly. An error is returned if either revision is not
+registered, or if the two are equal.
+*/
+func RegisterMigration(m Migration) error {
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	for _, v := range []DraftVersion{m.From, m.To} {
+		if _, found := drafts.schemas[v]; !found {
+			return errorw(UnknownDraftErr, "%s", v)
+		}
+	}
+
+	if m.From == m.To {
+		return errorf("Migration from %s to itself", m.From)
+	}
+	drafts.migrations[[2]DraftVersion{m.From, m.To}] = m
+
+	return nil
+}
+
+/*
+Drafts returns the registered revisions in ascending order.
+*/
+func Drafts() (vs []DraftVersion) {
+	drafts.RLock()
+	defer drafts.RUnlock()
+
+	for v := range drafts.schemas {
+		vs = append(vs, v)
+	}
+	sort.Slice(vs, func(i, j int) bool { return vs[i] < vs[j] })
+
+	return
+}
+
+/*
+migrationPath returns the sequence of Migration instances required to
+migrate from the first revision to the second, by way of each registered
+revision in between. Adjacent revisions lacking a registered Migration
+are related by an empty Migration.
+*/
+func migrationPath(from, to DraftVersion) (path []Migration, err error) {
+	vs := Drafts()
+	fi, ti := -1, -1
+	for i := 0; i < len(vs); i++ {
+		if vs[i] == from {
+			fi = i
+		}
+		if vs[i] == to {
+			ti = i
+		}
+	}
+
+	if fi == -1 {
+		err = errorw(UnknownDraftErr, "%s", from)
+		return
+	} else if ti == -1 {
+		err = errorw(UnknownDraftErr, "%s", to)
+		return
+	}
+
+	drafts.RLock()
+	defer drafts.RUnlock()
+
+	step := 1
+	if ti < fi {
+		step = -1
+	}
+
+	for i := fi; i != ti; i += step {
+		a, b := vs[i], vs[i+step]
+		if m, found := drafts.migrations[[2]DraftVersion{a, b}]; found {
+			path = append(path, m)
+		} else if m, found = drafts.migrations[[2]DraftVersion{b, a}]; found {
+			path = append(path, m.invert())
+		} else {
+			path = append(path, Migration{From: a, To: b})
+		}
+	}
+
+	return
+}
+
+/*
+LossyField describes attribute values which could not be carried over
+during a migration.
+*/
+type LossyField struct {
+	DN     string
+	Attr   string
+	Values []string
+	Reason string
+}
+
+/*
+String returns a human-readable representation of the receiver.
+*/
+func (r LossyField) String() string {
+	return sprintf("%s: %s %v: %s", r.DN, r.Attr, r.Values, r.Reason)
+}
+
+/*
+MigrationReport describes the outcome of a migration.
+*/
+type MigrationReport struct {
+	From  DraftVersion
+	To    DraftVersion
+	Lossy []LossyField
+}
+
+/*
+Lossless returns a boolean value indicative of whether all attribute
+values were carried over during the migration.
+*/
+func (r MigrationReport) Lossless() bool {
+	return len(r.Lossy) == 0
+}
+
+/*
+DetectDraft returns the registered revision to which the input entries
+(e.g.: the return values of Unmarshal methods) conform, alongside an
+error if no revision defines every attribute type and objectClass used.
+
+Only names defined by at least one (1) registered revision are considered,
+thus attribute types and objectClasses foreign to the ID (e.g.: 'cn' or
+'description') do not influence detection. Where more than one revision
+qualifies, the revision with the fewest missing MUST attribute types is
+favored, followed by the highest revision.
+*/
+func DetectDraft(entries ...map[string][]string) (v DraftVersion, err error) {
+	vs := Drafts()
+	schemas := make([]*Schema, len(vs))
+	for i := 0; i < len(vs); i++ {
+		schemas[i] = vs[i].Schema()
+	}
+
+	// relevant returns true if any registered revision
+	// defines the input name using the input function.
+	relevant := func(name string, defined func(*Schema, string) bool) bool {
+		for i := 0; i < len(schemas); i++ {
+			if defined(schemas[i], name) {
+				return true
+			}
+		}
+		return false
+	}
+	atDefined := func(s *Schema, name string) (ok bool) {
+		_, ok = s.AttributeType(attrBase(name))
+		return
+	}
+	ocDefined := func(s *Schema, name string) (ok bool) {
+		_, ok = s.ObjectClass(name)
+		return
+	}
+
+	var seen bool
+	bestUndef, bestMissing := -1, -1
+	for i := len(vs) - 1; i >= 0; i-- {
+		var undef, missing int
+		for _, m := range entries {
+			for at, vals := range m {
+				if eq(at, `objectClass`) {
+					for j := 0; j < len(vals); j++ {
+						if relevant(vals[j], ocDefined) {
+							seen = true
+							if !ocDefined(schemas[i], vals[j]) {
+								undef++
+							}
+						}
+					}
+				} else if relevant(at, atDefined) {
+					seen = true
+					if !atDefined(schemas[i], at) {
+						undef++
+					}
+				}
+			}
+
+			missing += countMissing(schemas[i], m)
+		}
+
+		if bestUndef == -1 || undef < bestUndef ||
+			(undef == bestUndef && missing < bestMissing) {
+			v, bestUndef, bestMissing = vs[i], undef, missing
+		}
+	}
+
+	if !seen {
+		v, err = UnknownDraft, errorw(UnknownDraftErr, `no attribute types or objectClasses defined by any registered revision`)
+	} else if bestUndef > 0 {
+		v, err = UnknownDraft, errorw(UnknownDraftErr, "closest match %s lacks %d definitions", v, bestUndef)
+	}
+
+	return
+}
+
+/*
+countMissing returns the number of MUST attribute types that are absent
+from the input entry, per the objectClasses of the input *Schema.
+*/
+func countMissing(s *Schema, m map[string][]string) (n int) {
+	for _, v := range s.validate(m) {
+		if v.Kind == MissingAttributeViolation {
+			n++
+		}
+	}
+
+	return
+}
+
+/*
+attrBase returns the input attribute description without any options,
+e.g.: 'description' for 'description;lang-en'.
+*/
+func attrBase(at string) string {
+	if idx := idxRune(at, ';'); idx != -1 {
+		return at[:idx]
+	}
+
+	return at
+}
+
+/*
+MigrateEntries returns the input Entry instances migrated from one
+registered revision to another, alongside a MigrationReport describing
+any lossy fields and an error. If from is UnknownDraft, the source
+revision is determined using DetectDraft. The input instances are not
+modified.
+
+Attribute types and objectClasses are renamed per any registered
+Migration instances between the two revisions. Values are dropped, and
+reported as lossy, if their attribute type (or objectClass) is defined
+within the source revision but not the target revision, or if they
+exceed the SINGLE-VALUE constraint of the target revision. Attribute
+types and objectClasses foreign to the ID are carried over unchanged.
+
+Entries migrated to LatestDraft may be converted into Registration and
+Registrant instances using the MarshalRegistration and
+MarshalRegistrants functions.
+*/
+func MigrateEntries(entries []Entry, from, to DraftVersion) (out []Entry, rpt MigrationReport, err error) {
+	if from == UnknownDraft {
+		ms := make([]map[string][]string, len(entries))
+		for i := 0; i < len(entries); i++ {
+			ms[i] = entries[i].Attributes
+		}
+		if from, err = DetectDraft(ms...); err != nil {
+			return
+		}
+	}
+	rpt.From, rpt.To = from, to
+
+	var path []Migration
+	if path, err = migrationPath(from, to); err != nil {
+		return
+	}
+
+	out = make([]Entry, len(entries))
+	for i := 0; i < len(entries); i++ {
+		out[i] = Entry{DN: entries[i].DN, Attributes: copyAttrs(entries[i].Attributes)}
+	}
+
+	for _, m := range path {
+		src, dst := m.From.Schema(), m.To.Schema()
+		for i := 0; i < len(out); i++ {
+			var lossy []LossyField
+			out[i].Attributes, lossy = m.apply(out[i].DN, out[i].Attributes, src, dst)
+			rpt.Lossy = append(rpt.Lossy, lossy...)
+		}
+	}
+
+	return
+}
+
+/*
+MigrateRegistrations returns the input Registrations, which conform to
+LatestDraft, as Entry instances migrated to the input revision. See the
+MigrateEntries function for details.
+*/
+func MigrateRegistrations(regs Registrations, to DraftVersion) ([]Entry, MigrationReport, error) {
+	entries := make([]Entry, 0, len(regs))
+	for i := 0; i < len(regs); i++ {
+		if regs[i] != nil {
+			entries = append(entries, Entry{DN: regs[i].DN(), Attributes: regs[i].Unmarshal()})
+		}
+	}
+
+	return MigrateEntries(entries, LatestDraft, to)
+}
+
+/*
+MigrateRegistrants returns the input Registrants, which conform to
+LatestDraft, as Entry instances migrated to the input revision. See the
+MigrateEntries function for details.
+*/
+func MigrateRegistrants(rants Registrants, to DraftVersion) ([]Entry, MigrationReport, error) {
+	entries := make([]Entry, 0, len(rants))
+	for i := 0; i < len(rants); i++ {
+		if rants[i] != nil {
+			entries = append(entries, Entry{DN: rants[i].DN(), Attributes: rants[i].Unmarshal()})
+		}
+	}
+
+	return MigrateEntries(entries, LatestDraft, to)
+}
+
+/*
+apply returns the input attributes migrated per the receiver, alongside
+any lossy fields. Attribute types are processed in sorted order, such
+that lossy fields are reported deterministically.
+*/
+func (r Migration) apply(dn string, m map[string][]string, src, dst *Schema) (out map[string][]string, lossy []LossyField) {
+	out = make(map[string][]string, len(m))
+	lose := func(at string, vals []string, reason string) {
+		lossy = append(lossy, LossyField{DN: dn, Attr: at, Values: vals, Reason: reason})
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, at := range keys {
+		vals := m[at]
+		if eq(at, `objectClass`) {
+			var dropped []string
+			for _, oc := range vals {
+				if name, found := rename(r.ObjectClasses, oc); found && len(name) == 0 {
+					dropped = append(dropped, oc)
+				} else if _, inSrc := src.ObjectClass(oc); !found && inSrc && !defines(dst, oc) {
+					dropped = append(dropped, oc)
+				} else {
+					out[at] = append(out[at], name)
+				}
+			}
+			if len(dropped) > 0 {
+				lose(at, dropped, sprintf("objectClass not defined in %s", r.To))
+			}
+			continue
+		}
+
+		base, opts := attrBase(at), at[len(attrBase(at)):]
+		name, found := rename(r.AttributeTypes, base)
+		if found && len(name) == 0 {
+			lose(at, vals, sprintf("attribute type removed in %s", r.To))
+			continue
+		} else if _, inSrc := src.AttributeType(base); !found && inSrc {
+			if _, inDst := dst.AttributeType(base); !inDst {
+				lose(at, vals, sprintf("attribute type not defined in %s", r.To))
+				continue
+			}
+		}
+
+		key := name + opts
+		if def, ok := dst.AttributeType(name); ok && def.SingleValue {
+			if room := 1 - len(out[key]); len(vals) > room {
+				lose(at, vals[room:], sprintf("attribute type is SINGLE-VALUE in %s", r.To))
+				vals = vals[:room]
+			}
+		}
+		out[key] = append(out[key], vals...)
+	}
+
+	return
+}
+
+/*
+defines returns a boolean value indicative of whether the input
+objectClass is defined within the input *Schema. The 'top' objectClass
+is always considered defined.
+*/
+func defines(s *Schema, oc string) bool {
+	if eq(oc, `top`) {
+		return true
+	}
+	_, found := s.ObjectClass(oc)
+
+	return found
+}
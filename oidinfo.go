@@ -0,0 +1,545 @@
+package dcxl
+
+/*
+oidinfo.go contains the converter between Registrations (and Registrants)
+and the XML interchange format of the OID Repository (oid-info.com).
+*/
+
+import (
+	"encoding/xml"
+	"hash/fnv"
+	"io"
+	"sort"
+	"time"
+)
+
+/*
+OIDInfoSchemaLocation is the xsi:schemaLocation value written upon the
+root element of OID Repository XML documents.
+*/
+const OIDInfoSchemaLocation = `http://oid-info.com/ http://oid-info.com/oid.xsd`
+
+/*
+OIDInfoDatabase describes an OID Repository XML document, which contains
+the submitter of the document and a flat list of OIDs. The hierarchy of
+the OIDs is carried by their dot-notation values.
+*/
+type OIDInfoDatabase struct {
+	XMLName        xml.Name       `xml:"oid-database"`
+	XSI            string         `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string         `xml:"xsi:schemaLocation,attr,omitempty"`
+	Submitter      *OIDInfoPerson `xml:"submitter,omitempty"`
+	OIDs           []OIDInfoOID   `xml:"oid"`
+}
+
+/*
+OIDInfoOID describes a single OID within an OID Repository XML document.
+*/
+type OIDInfoOID struct {
+	DotNotation       string         `xml:"dot-notation,omitempty"`
+	ASN1Notation      string         `xml:"asn1-notation,omitempty"`
+	IRINotation       []string       `xml:"iri-notation,omitempty"`
+	UnicodeLabel      []string       `xml:"unicode-label,omitempty"`
+	Synonyms          []string       `xml:"synonymous-identifier,omitempty"`
+	Description       string         `xml:"description,omitempty"`
+	Information       string         `xml:"information,omitempty"`
+	FirstRegistrant   *OIDInfoPerson `xml:"first-registrant,omitempty"`
+	CurrentRegistrant *OIDInfoPerson `xml:"current-registrant,omitempty"`
+}
+
+/*
+OIDInfoPerson describes the submitter of an OID Repository XML document,
+or a registrant of an OID within same.
+*/
+type OIDInfoPerson struct {
+	FirstName        string `xml:"first-name,omitempty"`
+	LastName         string `xml:"last-name,omitempty"`
+	Address          string `xml:"address,omitempty"`
+	Email            string `xml:"email,omitempty"`
+	Phone            string `xml:"phone,omitempty"`
+	Fax              string `xml:"fax,omitempty"`
+	WebSite          string `xml:"web-site,omitempty"`
+	CreationDate     string `xml:"creation-date,omitempty"`
+	ModificationDate string `xml:"modification-date,omitempty"`
+}
+
+/*
+OIDInfoCodec converts Registrations and Registrants to and from the XML
+interchange format of the OID Repository. The zero value is ready for
+use.
+
+The fields of each OID map to those of a Registration as follows:
+
+  - dot-notation: dotNotation (n, for a RootArc)
+  - asn1-notation: asn1Notation
+  - iri-notation: iRI
+  - unicode-label: unicodeValue
+  - synonymous-identifier: additionalIdentifier
+  - description: description
+  - information: registrationInformation
+  - first-registrant: firstAuthority
+  - current-registrant: currentAuthority
+
+The creation-date of the first registrant maps to registrationCreated,
+and the modification-date of the current registrant maps to the latest
+registrationModified value. Multiple description values are separated
+by a line break, and multiple registrationInformation values by a blank
+line; each is read back as a single value.
+
+The first-name and last-name of each registrant map to the common name,
+and the address maps to the postal address. When writing, the address is
+composed of the organization, followed by either the postal address or
+its components (street, post office box, postal code, locality, state
+and country name), one per line; this composition cannot be reversed,
+and is read back whole as a postal address. The web-site maps to the
+first URI.
+
+The DUAConfig field, if non-nil, is used to assign the DNs of the
+Registrations and Registrants read, and to infer the dot-notation of
+Registrations lacking one when writing. Read Registrations
+bear the supArc and subArc values describing their hierarchy, and refer
+to dedicated Registrants by DN if the DUAConfig bears a registrant base;
+otherwise COMBINED registrants are used.
+
+The Submitter field, if non-nil, is written as the submitter of each
+document.
+*/
+type OIDInfoCodec struct {
+	DUAConfig *DUAConfig
+	Submitter *OIDInfoPerson
+}
+
+/*
+Write writes the input Registrations as an OID Repository XML document
+upon the input io.Writer, returning an error if writing fails. OIDs are
+written in hierarchical order, superiors first.
+
+Registrants are written using the COMBINED registrant values of each
+Registration where present, else using those of the input Registrants
+whose DNs are referenced by the Registration.
+*/
+func (r *OIDInfoCodec) Write(w io.Writer, regs Registrations, rants Registrants) (err error) {
+	byDN := make(map[string]Registrant, len(rants))
+	for i := 0; i < len(rants); i++ {
+		if !isNilRegistrant(rants[i]) {
+			byDN[normalizeDN(rants[i].DN())] = rants[i]
+		}
+	}
+
+	doc := OIDInfoDatabase{
+		XSI:            `http://www.w3.org/2001/XMLSchema-instance`,
+		SchemaLocation: OIDInfoSchemaLocation,
+		Submitter:      r.Submitter,
+	}
+
+	for i := 0; i < len(regs); i++ {
+		if regs[i] != nil {
+			doc.OIDs = append(doc.OIDs, r.oid(regs[i], byDN))
+		}
+	}
+
+	sort.SliceStable(doc.OIDs, func(i, j int) bool {
+		return oidLess(doc.OIDs[i].DotNotation, doc.OIDs[j].DotNotation)
+	})
+
+	if _, err = io.WriteString(w, xml.Header); err == nil {
+		enc := xml.NewEncoder(w)
+		enc.Indent(``, "\t")
+		if err = enc.Encode(doc); err == nil {
+			_, err = io.WriteString(w, "\n")
+		}
+	}
+
+	return
+}
+
+/*
+oid returns the OIDInfoOID describing the input Registration.
+*/
+func (r *OIDInfoCodec) oid(reg Registration, byDN map[string]Registrant) (o OIDInfoOID) {
+	m := reg.Unmarshal()
+	o.DotNotation = reg.DotNotation()
+	if _, isRoot := reg.(*RootArc); isRoot || len(o.DotNotation) == 0 {
+		if isRoot {
+			o.DotNotation = reg.N()
+		} else if d := r.config(reg); d != nil {
+			o.DotNotation, _ = d.RegistrationOID(reg.DN())
+		}
+	}
+
+	o.ASN1Notation = reg.ASN1Notation()
+	o.IRINotation = mapValues(m, `iRI`)
+	o.UnicodeLabel = mapValues(m, `unicodeValue`)
+	o.Synonyms = mapValues(m, `additionalIdentifier`)
+	o.Description = join(mapValues(m, `description`), "\n")
+	o.Information = join(mapValues(m, `registrationInformation`), "\n\n")
+
+	for _, typ := range []string{`firstAuthority`, `currentAuthority`} {
+		var rant Registrant
+		if typ == `firstAuthority` {
+			if c := reg.CombinedFirstAuthority(); c != nil {
+				rant = c
+			}
+		} else if c := reg.CombinedCurrentAuthority(); c != nil {
+			rant = c
+		}
+
+		for _, dn := range mapValues(m, typ) {
+			if rant != nil {
+				break
+			} else if x, found := byDN[normalizeDN(dn)]; found && x.Type() == typ {
+				rant = x
+			}
+		}
+
+		if rant == nil {
+			continue
+		}
+
+		p := oidInfoPerson(typ, rant.Unmarshal())
+		if typ == `firstAuthority` {
+			p.CreationDate = oidInfoDate(mapValues(m, `registrationCreated`))
+			o.FirstRegistrant = p
+		} else {
+			p.ModificationDate = oidInfoDate(mapValues(m, `registrationModified`))
+			o.CurrentRegistrant = p
+		}
+	}
+
+	return
+}
+
+func (r *OIDInfoCodec) config(reg Registration) *DUAConfig {
+	if r.DUAConfig != nil {
+		return r.DUAConfig
+	}
+
+	return reg.DUAConfig()
+}
+
+/*
+oidInfoPerson returns the *OIDInfoPerson describing the registrant values,
+prefixed with the input registrant type, within the input map.
+*/
+func oidInfoPerson(typ string, m map[string][]string) *OIDInfoPerson {
+	val := func(at string) string {
+		return join(mapValues(m, typ+at), ` `)
+	}
+
+	p := new(OIDInfoPerson)
+	if cn := val(`CommonName`); len(cn) > 0 {
+		if idx := lastIndex(cn, ` `); idx > 0 {
+			p.FirstName, p.LastName = cn[:idx], cn[idx+1:]
+		} else {
+			p.LastName = cn
+		}
+	}
+
+	var addr []string
+	if o := val(`Org`); len(o) > 0 {
+		addr = append(addr, o)
+	}
+	if pa := val(`PostalAddress`); len(pa) > 0 {
+		addr = append(addr, pa)
+	} else {
+		for _, at := range []string{`Street`, `POBox`} {
+			if v := val(at); len(v) > 0 {
+				addr = append(addr, v)
+			}
+		}
+		if v := trimS(val(`PostalCode`) + ` ` + val(`Locality`)); len(v) > 0 {
+			addr = append(addr, v)
+		}
+		for _, at := range []string{`State`, `CountryName`} {
+			if v := val(at); len(v) > 0 {
+				addr = append(addr, v)
+			}
+		}
+	}
+	p.Address = join(addr, "\n")
+
+	p.Email = val(`Email`)
+	p.Phone = val(`Telephone`)
+	p.Fax = val(`Fax`)
+	if uris := mapValues(m, typ+`URI`); len(uris) > 0 {
+		p.WebSite = uris[0]
+	}
+
+	return p
+}
+
+/*
+Read returns the Registrations and Registrants described by the OID
+Repository XML document read from the input io.Reader, alongside an
+error. OIDs of a single arc are returned as RootArc instances, and all
+others as SubArc instances.
+
+Registrants bearing identical values are returned once, and shared by
+the Registrations referring to them. Each is assigned a registrantID
+derived from its values, such that repeated reads of the same document
+produce the same Registrants.
+
+An error is returned if the input is not a valid document, if an OID
+lacks a valid dot-notation value, or if the DUAConfig field is set
+but cannot be used to assign DNs.
+*/
+func (r *OIDInfoCodec) Read(rd io.Reader) (regs Registrations, rants Registrants, err error) {
+	var doc OIDInfoDatabase
+	if err = xml.NewDecoder(rd).Decode(&doc); err != nil {
+		return
+	}
+
+	d := r.DUAConfig
+	byOID := make(map[string]Registration, len(doc.OIDs))
+	seen := make(map[string]bool)
+	for _, o := range doc.OIDs {
+		dot := trimS(o.DotNotation)
+		var arcs []string
+		if arcs, err = splitDotNot(dot); err != nil {
+			err = errorw(err, "oid-info dot-notation '%s'", o.DotNotation)
+			return
+		} else if _, dup := byOID[dot]; dup {
+			err = errorw(EntryExistsErr, "oid-info dot-notation '%s'", dot)
+			return
+		}
+
+		m := map[string][]string{
+			`n`:                       {arcs[len(arcs)-1]},
+			`asn1Notation`:            oidInfoValues(o.ASN1Notation),
+			`iRI`:                     o.IRINotation,
+			`unicodeValue`:            o.UnicodeLabel,
+			`additionalIdentifier`:    o.Synonyms,
+			`description`:             oidInfoValues(o.Description),
+			`registrationInformation`: oidInfoValues(o.Information),
+		}
+		if len(arcs) == 1 {
+			m[`objectClass`] = []string{`top`, RootArc{}.ObjectClass()}
+		} else {
+			m[`objectClass`] = []string{`top`, SubArc{}.ObjectClass()}
+			m[`dotNotation`] = []string{dot}
+		}
+		if p := o.FirstRegistrant; p != nil {
+			m[`registrationCreated`] = genTimeValues(p.CreationDate)
+		}
+		if p := o.CurrentRegistrant; p != nil {
+			m[`registrationModified`] = genTimeValues(p.ModificationDate)
+		}
+		if d != nil {
+			var dn string
+			if dn, err = d.RegistrationDN(dot); err != nil {
+				return
+			}
+			m[`dn`] = []string{dn}
+		}
+
+		var reg Registration
+		if reg, err = MarshalRegistration(m); err != nil {
+			return
+		}
+		if d != nil {
+			reg.SetDUAConfig(d)
+		}
+
+		for _, typ := range []string{`firstAuthority`, `currentAuthority`} {
+			p := o.FirstRegistrant
+			if typ == `currentAuthority` {
+				p = o.CurrentRegistrant
+			}
+			if rant := r.registrant(typ, p); rant != nil {
+				r.link(reg, rant)
+				if dn := rant.DN(); len(dn) > 0 && !seen[dn] {
+					seen[dn] = true
+					rants = append(rants, rant)
+				}
+			}
+		}
+
+		byOID[dot] = reg
+		regs = append(regs, reg)
+	}
+
+	if d != nil {
+		oidInfoHierarchy(byOID)
+	}
+
+	return
+}
+
+/*
+registrant returns the Registrant of the input type described by the
+input *OIDInfoPerson, or nil if it bears no registrant values.
+*/
+func (r *OIDInfoCodec) registrant(typ string, p *OIDInfoPerson) Registrant {
+	if p == nil {
+		return nil
+	}
+
+	m := make(map[string][]string)
+	set := func(at, v string) {
+		if v = trimS(v); len(v) > 0 {
+			m[typ+at] = []string{v}
+		}
+	}
+	set(`CommonName`, trimS(p.FirstName+` `+p.LastName))
+	set(`PostalAddress`, p.Address)
+	set(`Email`, p.Email)
+	set(`Telephone`, p.Phone)
+	set(`Fax`, p.Fax)
+	set(`URI`, p.WebSite)
+	if len(m) == 0 {
+		return nil
+	}
+
+	// Derive a stable registrantID from the values.
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := fnv.New64a()
+	for _, k := range keys {
+		h.Write([]byte(k + "\x00" + m[k][0] + "\x00"))
+	}
+	m[`registrantID`] = []string{sprintf("oidinfo-%016x", h.Sum64())}
+
+	var rant Registrant
+	if typ == `firstAuthority` {
+		f := new(FirstAuthority)
+		f.marshal(m)
+		rant = f
+	} else {
+		c := new(CurrentAuthority)
+		c.marshal(m)
+		rant = c
+	}
+
+	if d := r.DUAConfig; d != nil {
+		rant.SetDUAConfig(d)
+		if len(d.Registrants) > 0 {
+			rant.SetDN(`registrantID=` + mapValues(m, `registrantID`)[0] + `,` + d.Registrants[0])
+		}
+	}
+
+	return rant
+}
+
+/*
+link associates the input Registrant with the input Registration, by DN
+if the Registrant bears one, else as a COMBINED registrant.
+*/
+func (r *OIDInfoCodec) link(reg Registration, rant Registrant) {
+	switch tv := rant.(type) {
+	case *FirstAuthority:
+		if len(tv.R_DN) > 0 {
+			reg.SetFirstAuthority(tv.R_DN)
+		} else {
+			reg.SetCombinedFirstAuthority(tv)
+		}
+	case *CurrentAuthority:
+		if len(tv.R_DN) > 0 {
+			reg.SetCurrentAuthority(tv.R_DN)
+		} else {
+			reg.SetCombinedCurrentAuthority(tv)
+		}
+	}
+}
+
+/*
+oidInfoHierarchy assigns the supArc and subArc values of the input
+Registrations, each of which must bear a DN. Registrations are visited
+in hierarchical order, such that subArc values are ordered likewise.
+*/
+func oidInfoHierarchy(byOID map[string]Registration) {
+	dots := make([]string, 0, len(byOID))
+	for dot := range byOID {
+		dots = append(dots, dot)
+	}
+	sort.Slice(dots, func(i, j int) bool { return oidLess(dots[i], dots[j]) })
+
+	for _, dot := range dots {
+		idx := lastIndex(dot, `.`)
+		if idx < 0 {
+			continue
+		}
+
+		sup, found := byOID[dot[:idx]]
+		if !found {
+			continue
+		}
+
+		reg := byOID[dot]
+		if sub, ok := reg.(*SubArc); ok {
+			sub.R_SupArc = sup.DN()
+		}
+		switch tv := sup.(type) {
+		case *RootArc:
+			tv.R_SubArc = append(tv.R_SubArc, reg.DN())
+		case *SubArc:
+			tv.R_SubArc = append(tv.R_SubArc, reg.DN())
+		}
+	}
+}
+
+/*
+oidLess returns a boolean value indicative of whether dotNotation value
+a precedes b in hierarchical order, such that superiors precede their
+subordinates, and siblings are ordered numerically.
+*/
+func oidLess(a, b string) bool {
+	x, y := split(a, `.`), split(b, `.`)
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if len(x[i]) != len(y[i]) {
+			return len(x[i]) < len(y[i])
+		} else if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+
+	return len(x) < len(y)
+}
+
+/*
+oidInfoDate returns the latest of the input generalizedTime values as an
+OID Repository date (YYYY-MM-DD), or a zero string if none are valid.
+*/
+func oidInfoDate(vals []string) (date string) {
+	var latest time.Time
+	for _, v := range vals {
+		if t, ok := genTimeToTime(v); ok && t.After(latest) {
+			latest = t
+		}
+	}
+
+	if !latest.IsZero() {
+		date = latest.Format(`2006-01-02`)
+	}
+
+	return
+}
+
+/*
+genTimeValues returns the input OID Repository date as a generalizedTime
+value, or nil if it is not a valid date.
+*/
+func genTimeValues(date string) []string {
+	t, err := time.Parse(`2006-01-02`, trimS(date))
+	if err == nil {
+		if gt, ok := timeToGenTime(t); ok {
+			return []string{gt}
+		}
+	}
+
+	return nil
+}
+
+/*
+oidInfoValues returns the input value as a single-valued slice, or nil
+if it is zero (or whitespace) in length.
+*/
+func oidInfoValues(v string) []string {
+	if v = trimS(v); len(v) > 0 {
+		return []string{v}
+	}
+
+	return nil
+}
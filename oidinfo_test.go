@@ -0,0 +1,107 @@
+package dcxl
+
+import (
+	"bytes"
+	"testing"
+)
+
+/*
+TestOIDInfoCodec_roundTrip verifies that Registrations and dedicated
+Registrants written by the OIDInfoCodec are read back intact, within both
+directory models.
+*/
+func TestOIDInfoCodec_roundTrip(t *testing.T) {
+	for _, d := range []*DUAConfig{
+		{
+			DirectoryModel: TwoDimensional,
+			Registrations:  []string{`ou=Registrations,o=rA`},
+			Registrants:    []string{`ou=Registrants,o=rA`},
+		},
+		{
+			DirectoryModel: ThreeDimensional,
+			Registrations:  []string{`ou=Registrations,o=rA`},
+			Registrants:    []string{`ou=Registrants,o=rA`},
+		},
+	} {
+		fa := &FirstAuthority{
+			R_DN:    `registrantID=X,ou=Registrants,o=rA`,
+			R_Id:    `X`,
+			R_CN:    `Jesse Coretta`,
+			R_Email: `jesse@example.com`,
+		}
+		ca := &CurrentAuthority{
+			R_DN: `registrantID=Y,ou=Registrants,o=rA`,
+			R_Id: `Y`,
+			R_CN: `Example Maintainer`,
+		}
+
+		sdn, _ := d.RegistrationDN(`1.3.6`)
+		regs := Registrations{
+			&RootArc{R_N: `1`, R_Desc: `ISO`},
+			&SubArc{ // dotNotation inferred from the DN
+				R_DN:       sdn,
+				R_N:        `6`,
+				R_Desc:     `US Department of Defense`,
+				R_UVal:     []string{`DoD`},
+				R_Created:  `20200102030405Z`,
+				R_Modified: []string{`20210101000000Z`, `20230405060708Z`},
+				R_FAuthyDN: []string{fa.R_DN},
+				R_CAuthyDN: []string{ca.R_DN},
+			},
+		}
+
+		codec := &OIDInfoCodec{DUAConfig: d}
+		var buf bytes.Buffer
+		if err := codec.Write(&buf, regs, Registrants{fa, ca}); err != nil {
+			t.Fatalf("%s: %v", d.DirectoryModel, err)
+		}
+		doc := buf.String()
+
+		read, rants, err := codec.Read(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("%s: %v", d.DirectoryModel, err)
+		} else if len(read) != 2 || len(rants) != 2 {
+			t.Fatalf("%s: expected 2 registrations and 2 registrants, got %d and %d",
+				d.DirectoryModel, len(read), len(rants))
+		}
+
+		sub, ok := read.Lookup(OIDKey, `1.3.6`).(*SubArc)
+		switch {
+		case !ok:
+			t.Fatalf("%s: 1.3.6 not read", d.DirectoryModel)
+		case sub.DN() != sdn:
+			t.Errorf("%s: expected DN '%s', got '%s'", d.DirectoryModel, sdn, sub.DN())
+		case sub.R_Desc != regs[1].(*SubArc).R_Desc || len(sub.R_UVal) != 1 || sub.R_UVal[0] != `DoD`:
+			t.Errorf("%s: unexpected values %#v", d.DirectoryModel, sub)
+		case sub.R_Created != `20200102000000Z`:
+			t.Errorf("%s: expected creation date 20200102000000Z, got '%s'", d.DirectoryModel, sub.R_Created)
+		case len(sub.R_Modified) != 1 || sub.R_Modified[0] != `20230405000000Z`:
+			t.Errorf("%s: expected modification date 20230405000000Z, got %v", d.DirectoryModel, sub.R_Modified)
+		}
+
+		for _, want := range []Registrant{fa, ca} {
+			rant := rants.Filter(func(r Registrant) bool { return r.Type() == want.Type() })
+			if len(rant) != 1 {
+				t.Errorf("%s: expected one %s, got %d", d.DirectoryModel, want.Type(), len(rant))
+				continue
+			}
+
+			dns := sub.Unmarshal()[want.Type()]
+			if rant[0].CN() != want.CN() || rant[0].Email() != want.Email() {
+				t.Errorf("%s: unexpected %s values %#v", d.DirectoryModel, want.Type(), rant[0])
+			} else if !hasPrefix(rant[0].DN(), `registrantID=`) || !hasSuffix(rant[0].DN(), `,ou=Registrants,o=rA`) {
+				t.Errorf("%s: unexpected %s DN '%s'", d.DirectoryModel, want.Type(), rant[0].DN())
+			} else if len(dns) != 1 || dns[0] != rant[0].DN() {
+				t.Errorf("%s: expected %s '%s', got %v", d.DirectoryModel, want.Type(), rant[0].DN(), dns)
+			}
+		}
+
+		// Writing what was read reproduces the document.
+		buf.Reset()
+		if err = codec.Write(&buf, read, rants); err != nil {
+			t.Fatalf("%s: %v", d.DirectoryModel, err)
+		} else if buf.String() != doc {
+			t.Errorf("%s: round trip mismatch:\n%s\n---\n%s", d.DirectoryModel, doc, buf.String())
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package dcxl
+
+/*
+pen.go contains the importer for the IANA Private Enterprise Numbers
+registry, as published in the enterprise-numbers text format.
+*/
+
+import (
+	"bufio"
+	"io"
+)
+
+/*
+PENPrefix is the dotNotation value of the IANA Private Enterprise Numbers
+arc, {iso(1) identified-organization(3) dod(6) internet(1) private(4)
+enterprise(1)}.
+*/
+const PENPrefix = `1.3.6.1.4.1`
+
+const penASN1Prefix = `{iso(1) identified-organization(3) dod(6) internet(1) private(4) enterprise(1) `
+
+/*
+PENRecord describes a single assignment within the IANA Private Enterprise
+Numbers registry. Email addresses are stored in their conventional form,
+with the '&' used within the registry replaced by '@'. Fields the registry
+marks as '---none---' are zero.
+*/
+type PENRecord struct {
+	Number       string
+	Organization string
+	Contact      string
+	Email        string
+}
+
+/*
+ParsePENs returns slices of PENRecord alongside an error following an
+attempt to parse the input IANA enterprise-numbers text, e.g.:
+
+	0
+	  Reserved
+	    Internet Assigned Numbers Authority
+	      iana&iana.org
+
+Each record begins with an unindented decimal number, followed by the
+organization, contact and email lines, indented by two (2), four (4)
+and six (6) spaces respectively. Lines preceding the first record, such
+as the preamble and column legend, are ignored, as is any trailing text.
+The input is read line by line, allowing the full registry to be parsed
+without buffering it.
+*/
+func ParsePENs(rd io.Reader) (recs []PENRecord, err error) {
+	sc := bufio.NewScanner(rd)
+	var cur *PENRecord
+	var line int
+	for sc.Scan() {
+		line++
+		text := trimR(sc.Text(), " \t\r")
+		if len(trimS(text)) == 0 {
+			continue
+		}
+
+		indent := len(text) - len(trimL(text, ` `))
+		val := trimS(text)
+		if val == `---none---` {
+			val = ``
+		}
+
+		switch {
+		case indent == 0 && isNumber(val):
+			if cur != nil {
+				recs = append(recs, *cur)
+			}
+			cur = &PENRecord{Number: val}
+		case indent == 0:
+			// Preamble or trailing text, e.g.: "End of Document"
+			if cur != nil {
+				recs = append(recs, *cur)
+				cur = nil
+			}
+		case cur == nil:
+			// Indented text prior to the first record
+		case indent == 2:
+			cur.Organization = val
+		case indent == 4:
+			cur.Contact = val
+		case indent == 6:
+			cur.Email = replaceAll(val, `&`, `@`)
+		default:
+			err = errorf("line %d: unexpected indentation for PEN %s", line, cur.Number)
+			return
+		}
+	}
+
+	if cur != nil {
+		recs = append(recs, *cur)
+	}
+	err = sc.Err()
+
+	return
+}
+
+/*
+PENImporter produces SubArc registrations and CurrentAuthority registrants
+from PENRecord instances. The DUAConfig field must be set, and is used to
+assign DNs per the directory model in effect. Registrants are placed
+beneath the first registrant base of the DUAConfig.
+
+Each SubArc bears the n, dotNotation, asn1Notation, description (the
+organization), supArc and currentAuthority values. Each CurrentAuthority
+bears the registrantID, currentAuthorityOrg, currentAuthorityCommonName
+(the contact) and currentAuthorityEmail values. Records lacking any
+organization, contact and email produce no registrant.
+
+The entry describing PENPrefix itself is not produced, and is expected
+to exist within the target directory.
+*/
+type PENImporter struct {
+	DUAConfig *DUAConfig
+}
+
+/*
+RegistrantID returns the registrantID value assigned to the registrant of
+the input PENRecord, e.g.: pen-56521.
+*/
+func (r PENRecord) RegistrantID() string {
+	return `pen-` + r.Number
+}
+
+/*
+Import returns the Registrations and Registrants described by the input
+PENRecord instances, alongside an error.
+*/
+func (r *PENImporter) Import(recs []PENRecord) (regs Registrations, rants Registrants, err error) {
+	d := r.DUAConfig
+	if !d.Valid() || len(d.Registrations) == 0 {
+		err = errorw(DUAConfigValidityErr, `registration base required`)
+		return
+	}
+
+	var sup string
+	if sup, err = d.RegistrationDN(PENPrefix); err != nil {
+		return
+	}
+
+	for i := 0; i < len(recs); i++ {
+		rec := recs[i]
+		if !isNumber(rec.Number) {
+			err = errorw(IllegalNumberFormErr, "PEN '%s'", rec.Number)
+			return
+		}
+
+		sub := new(SubArc)
+		sub.R_N = rec.Number
+		sub.R_DotNot = PENPrefix + `.` + rec.Number
+		sub.R_ASN1Not = penASN1Prefix + rec.Number + `}`
+		sub.R_Desc = rec.Organization
+		sub.R_SupArc = sup
+		sub.R_DUAConfig = d
+		if sub.R_DN, err = d.RegistrationDN(sub.R_DotNot); err != nil {
+			return
+		}
+
+		if len(rec.Organization)+len(rec.Contact)+len(rec.Email) > 0 {
+			if len(d.Registrants) == 0 {
+				err = errorw(DUAConfigValidityErr, `registrant base required`)
+				return
+			}
+
+			ca := new(CurrentAuthority)
+			ca.R_Id = rec.RegistrantID()
+			ca.R_DN = `registrantID=` + ca.R_Id + `,` + d.Registrants[0]
+			ca.R_O = rec.Organization
+			ca.R_CN = rec.Contact
+			ca.R_Email = rec.Email
+			ca.R_DUAConfig = d
+
+			sub.R_CAuthyDN = []string{ca.R_DN}
+			rants = append(rants, ca)
+		}
+
+		regs = append(regs, sub)
+	}
+
+	return
+}
+
+/*
+Changes returns the EntryChange instances required to bring a directory
+populated from the first set of records (prev) in line with the second
+(cur), such that only additions, changes and removals are applied. See
+the DiffEntries and ApplyChanges functions for details.
+*/
+func (r *PENImporter) Changes(prev, cur []PENRecord) (changes []EntryChange, err error) {
+	var before, after []Entry
+	if before, err = r.entries(prev); err == nil {
+		if after, err = r.entries(cur); err == nil {
+			changes = DiffEntries(before, after)
+		}
+	}
+
+	return
+}
+
+/*
+entries returns the input records as Entry instances, registrants first.
+*/
+func (r *PENImporter) entries(recs []PENRecord) (entries []Entry, err error) {
+	var regs Registrations
+	var rants Registrants
+	if regs, rants, err = r.Import(recs); err != nil {
+		return
+	}
+
+	for i := 0; i < len(rants); i++ {
+		entries = append(entries, Entry{DN: rants[i].DN(), Attributes: rants[i].Unmarshal()})
+	}
+	for i := 0; i < len(regs); i++ {
+		entries = append(entries, Entry{DN: regs[i].DN(), Attributes: regs[i].Unmarshal()})
+	}
+
+	return
+}
@@ -0,0 +1,402 @@
+package dcxl
+
+/*
+plan.go contains the search request planner, which translates abstract
+lookup intents into concrete LDAP search request descriptors based upon
+the directory model and bases described by a *DUAConfig instance, per
+s. 3.6.1 and s. 3.7.2 of the ID.
+*/
+
+/*
+Scope describes the scope of an LDAP Search Request, per section 4.5.1.2
+of RFC 4511.
+*/
+type Scope uint8
+
+const (
+	BaseObject   Scope = iota // baseObject (0)
+	SingleLevel               // singleLevel (1)
+	WholeSubtree              // wholeSubtree (2)
+)
+
+/*
+String returns the RFC 4511 name of the receiver.
+*/
+func (r Scope) String() string {
+	switch r {
+	case BaseObject:
+		return `baseObject`
+	case SingleLevel:
+		return `singleLevel`
+	case WholeSubtree:
+		return `wholeSubtree`
+	}
+
+	return `unknown`
+}
+
+/*
+SearchRequest describes a concrete LDAP Search Request, suitable for
+translation into the request type of whatever LDAP client package is
+in use (e.g.: go-ldap's ldap.NewSearchRequest).
+
+A nil Attributes value indicates all user attributes are requested. A
+SizeLimit of zero (0) indicates no client-imposed limit.
+*/
+type SearchRequest struct {
+	BaseDN     string
+	Scope      Scope
+	Filter     Filter
+	Attributes []string
+	SizeLimit  int
+}
+
+/*
+String returns a human-readable representation of the receiver.
+*/
+func (r SearchRequest) String() string {
+	var f string
+	if r.Filter != nil {
+		f = r.Filter.String()
+	}
+
+	return sprintf("base=%q scope=%s filter=%s attrs=%v sizelimit=%d",
+		r.BaseDN, r.Scope, f, r.Attributes, r.SizeLimit)
+}
+
+/*
+LookupIntent describes the abstract purpose of a Lookup.
+*/
+type LookupIntent uint8
+
+const (
+	_                  LookupIntent = iota
+	LookupByOID                     // locate a registration by dotNotation
+	LookupByIdentifier              // locate registrations by nameForm
+	LookupChildren                  // locate the immediate subordinates of a registration
+	LookupAncestors                 // locate the superiors of a registration
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r LookupIntent) String() string {
+	switch r {
+	case LookupByOID:
+		return `byOID`
+	case LookupByIdentifier:
+		return `byIdentifier`
+	case LookupChildren:
+		return `children`
+	case LookupAncestors:
+		return `ancestors`
+	}
+
+	return `unknown`
+}
+
+/*
+Lookup describes a registration lookup to be planned by the Plan method
+extended by *DUAConfig instances.
+
+The Value field shall contain a dotNotation value for all intents except
+LookupByIdentifier, in which case an identifier (nameForm) is expected.
+
+The Attributes and SizeLimit fields are transported as-is into all of
+the resultant SearchRequest instances whose scope is not baseObject. See
+the SearchRequest type for details.
+
+If the Broad field is true, registrations are located using filter-based
+searches rather than by inferred DNs. This is useful for directories that
+do not observe the DN conventions described in s. 3.2.2 and s. 3.3.2 of
+the ID.
+*/
+type Lookup struct {
+	Intent     LookupIntent
+	Value      string
+	Attributes []string
+	SizeLimit  int
+	Broad      bool
+}
+
+/*
+Plan returns slices of SearchRequest alongside an error following an
+attempt to plan the input Lookup against the receiver's configuration.
+All of the returned requests must be submitted, and their results
+combined, in order to fulfill the Lookup.
+
+Per s. 3.6.1.1 of the ID, ThreeDimensional models locate registrations
+using a baseObject search upon a DN inferred from the dotNotation value,
+while TwoDimensional models use a baseObject search upon a DN bearing
+the dotNotation value itself (s. 3.2.2). In either case, use of the Broad
+Lookup field will produce filter-based searches beneath each registration
+base instead.
+
+Identifier lookups are always filter-based, per s. 3.7.2 of the ID, and
+use a singleLevel scope in TwoDimensional models (as all registrations are
+siblings) and a wholeSubtree scope in ThreeDimensional models.
+
+Children lookups use a singleLevel search beneath the inferred DN of the
+parent registration in ThreeDimensional models. In TwoDimensional models
+(or when Broad is true), the 'supArc' value of each child is asserted, as
+described for the ChildrenOf function.
+
+Ancestor lookups produce one baseObject search per superior registration
+in ThreeDimensional models, ordered from the root downward. In TwoDimensional
+models, all superiors are requested using a single search.
+*/
+func (r *DUAConfig) Plan(l Lookup) (reqs []SearchRequest, err error) {
+	if !r.Valid() || len(r.Registrations) == 0 {
+		err = DUAConfigValidityErr
+		return
+	}
+
+	switch l.Intent {
+	case LookupByOID:
+		reqs, err = r.planByOID(l)
+	case LookupByIdentifier:
+		reqs, err = r.planByIdentifier(l)
+	case LookupChildren:
+		reqs, err = r.planChildren(l)
+	case LookupAncestors:
+		reqs, err = r.planAncestors(l)
+	default:
+		err = errorf("Unknown %T '%d'", l.Intent, l.Intent)
+	}
+
+	return
+}
+
+func (r *DUAConfig) planByOID(l Lookup) (reqs []SearchRequest, err error) {
+	var f Filter
+	if f, err = ByDotNotation(r, l.Value); err != nil {
+		return
+	}
+
+	if !l.Broad {
+		var dn string
+		if dn, err = r.RegistrationDN(l.Value); err == nil {
+			reqs = append(reqs, SearchRequest{
+				BaseDN:     dn,
+				Scope:      BaseObject,
+				Filter:     f,
+				Attributes: l.Attributes,
+				SizeLimit:  1,
+			})
+		}
+		return
+	}
+
+	// A broad search cannot rely upon the leaf numberForm
+	// assertion produced for ThreeDimensional models, so
+	// the dotNotation value itself is asserted instead.
+	if arcs, _ := splitDotNot(l.Value); len(arcs) > 1 {
+		f = And(Equal(`objectClass`, SubArc{}.ObjectClass()), Equal(`dotNotation`, l.Value))
+	}
+
+	reqs = r.eachRegistrationBase(f, l)
+	return
+}
+
+func (r *DUAConfig) planByIdentifier(l Lookup) (reqs []SearchRequest, err error) {
+	var f Filter
+	if f, err = ByIdentifier(r, l.Value); err == nil {
+		reqs = r.eachRegistrationBase(f, l)
+	}
+
+	return
+}
+
+func (r *DUAConfig) planChildren(l Lookup) (reqs []SearchRequest, err error) {
+	var f Filter
+	if f, err = ChildrenOf(r, l.Value); err != nil {
+		return
+	}
+
+	if r.DirectoryModel == TwoDimensional || l.Broad {
+		if r.DirectoryModel == ThreeDimensional {
+			var dn string
+			if dn, err = r.RegistrationDN(l.Value); err != nil {
+				return
+			}
+			f = And(f, Equal(`supArc`, dn))
+		}
+		reqs = r.eachRegistrationBase(f, l)
+		return
+	}
+
+	var dn string
+	if dn, err = r.RegistrationDN(l.Value); err == nil {
+		reqs = append(reqs, SearchRequest{
+			BaseDN:     dn,
+			Scope:      SingleLevel,
+			Filter:     f,
+			Attributes: l.Attributes,
+			SizeLimit:  l.SizeLimit,
+		})
+	}
+
+	return
+}
+
+func (r *DUAConfig) planAncestors(l Lookup) (reqs []SearchRequest, err error) {
+	var arcs []string
+	if arcs, err = splitDotNot(l.Value); err != nil {
+		return
+	} else if len(arcs) == 1 {
+		err = errorf("Root registration '%s' has no ancestors", l.Value)
+		return
+	}
+
+	var sups []Filter
+	for i := 1; i < len(arcs); i++ {
+		sup := join(arcs[:i], `.`)
+		if r.DirectoryModel == ThreeDimensional && !l.Broad {
+			var sr []SearchRequest
+			if sr, err = r.planByOID(Lookup{Value: sup, Attributes: l.Attributes}); err != nil {
+				return
+			}
+			reqs = append(reqs, sr...)
+			continue
+		}
+
+		var f Filter
+		if i == 1 {
+			f, _ = ByDotNotation(r, sup)
+		} else {
+			f = Equal(`dotNotation`, sup)
+		}
+		sups = append(sups, f)
+	}
+
+	if len(sups) > 0 {
+		l.SizeLimit = len(sups)
+		reqs = r.eachRegistrationBase(Or(sups...), l)
+	}
+
+	return
+}
+
+/*
+eachRegistrationBase returns one SearchRequest per registration base
+defined within the receiver, each bearing the input Filter. The scope
+is singleLevel for TwoDimensional models, else wholeSubtree.
+*/
+func (r *DUAConfig) eachRegistrationBase(f Filter, l Lookup) (reqs []SearchRequest) {
+	var scope Scope = WholeSubtree
+	if r.DirectoryModel == TwoDimensional {
+		scope = SingleLevel
+	}
+
+	for i := 0; i < len(r.Registrations); i++ {
+		reqs = append(reqs, SearchRequest{
+			BaseDN:     r.Registrations[i],
+			Scope:      scope,
+			Filter:     f,
+			Attributes: l.Attributes,
+			SizeLimit:  l.SizeLimit,
+		})
+	}
+
+	return
+}
+
+/*
+RegistrationDN returns the string DN inferred for the input dotNotation
+value alongside an error, based upon the directory model and first
+registration base defined within the receiver.
+
+ThreeDimensional models produce DNs per s. 3.3.2 of the ID, e.g.:
+
+	n=1,n=6,n=3,n=1,ou=OID,ou=X660,dc=example,dc=com
+
+TwoDimensional models produce DNs per s. 3.2.2 of the ID, e.g.:
+
+	dotNotation=1.3.6.1,ou=OID,ou=X660,dc=example,dc=com
+
+Single-arc (root) values produce DNs per s. 3.2.3 and s. 3.3.3, e.g.:
+
+	n=1,ou=OID,ou=X660,dc=example,dc=com
+*/
+func (r *DUAConfig) RegistrationDN(oid string) (dn string, err error) {
+	if !r.Valid() || len(r.Registrations) == 0 {
+		err = DUAConfigValidityErr
+		return
+	}
+
+	var arcs []string
+	if arcs, err = splitDotNot(oid); err != nil {
+		return
+	}
+
+	if len(arcs) == 1 {
+		dn = `n=` + arcs[0] + `,` + r.Registrations[0]
+		return
+	} else if r.DirectoryModel == TwoDimensional {
+		dn = `dotNotation=` + oid + `,` + r.Registrations[0]
+		return
+	}
+
+	var rdns []string = make([]string, 0, len(arcs)+1)
+	for i := len(arcs) - 1; i >= 0; i-- {
+		rdns = append(rdns, `n=`+arcs[i])
+	}
+	dn = join(append(rdns, r.Registrations[0]), `,`)
+
+	return
+}
+
+/*
+RegistrationOID returns the string dotNotation value inferred from the
+input DN alongside an error. This is the inverse of the RegistrationDN
+method. The DN must reside beneath one of the registration bases defined
+within the receiver.
+*/
+func (r *DUAConfig) RegistrationOID(dn string) (oid string, err error) {
+	if !r.Valid() || len(r.Registrations) == 0 {
+		err = DUAConfigValidityErr
+		return
+	}
+
+	rdns := splitDN(dn)
+	var rel []string
+	for i := 0; i < len(r.Registrations) && rel == nil; i++ {
+		base := splitDN(r.Registrations[i])
+		if len(base) >= len(rdns) {
+			continue
+		}
+		if normalizeDN(join(rdns[len(rdns)-len(base):], `,`)) == normalizeDN(r.Registrations[i]) {
+			rel = rdns[:len(rdns)-len(base)]
+		}
+	}
+
+	if len(rel) == 0 {
+		err = errorw(InvalidDNErr, "'%s' does not reside beneath a registration base", dn)
+		return
+	}
+
+	var arcs []string
+	for i := len(rel) - 1; i >= 0; i-- {
+		var at, val string
+		if at, val, err = leafRDN(rel[i]); err != nil {
+			return
+		}
+
+		switch lc(canonicalAttr(at)) {
+		case `n`:
+			arcs = append(arcs, val)
+		case `dotnotation`:
+			// A dotNotation RDN (s. 3.2.2, or s. 3.3.3.1)
+			// supersedes anything above it.
+			arcs = split(val, `.`)
+		default:
+			err = errorw(InvalidDNErr, "unsupported RDN '%s' in '%s'", rel[i], dn)
+			return
+		}
+	}
+
+	oid = join(arcs, `.`)
+	_, err = splitDotNot(oid)
+
+	return
+}
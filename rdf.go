@@ -0,0 +1,387 @@
+package dcxl
+
+/*
+rdf.go contains the RDF exporter, which renders Registration and
+Registrant instances as Turtle or N-Triples.
+*/
+
+import (
+	"io"
+	"sort"
+)
+
+/*
+RDFFormat describes an RDF serialization supported by the RDFWriter type.
+*/
+type RDFFormat uint8
+
+const (
+	TurtleFormat   RDFFormat = iota // Turtle (text/turtle)
+	NTriplesFormat                  // N-Triples (application/n-triples)
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r RDFFormat) String() string {
+	switch r {
+	case TurtleFormat:
+		return `turtle`
+	case NTriplesFormat:
+		return `n-triples`
+	}
+
+	return `unknown`
+}
+
+/*
+RDFVocabulary is the default namespace IRI of the vocabulary used by the
+RDFWriter type. Each attribute type defined in the ID maps to a predicate
+bearing its name (e.g.: <...#dotNotation>), and each objectClass maps to
+an RDF class bearing its name (e.g.: <...#x660SubArc>).
+*/
+const RDFVocabulary = `https://github.com/JesseCoretta/draft-coretta-x660-ldap#`
+
+const rdfType = `http://www.w3.org/1999/02/22-rdf-syntax-ns#type`
+
+/*
+RDFWriter renders Registration and Registrant instances as RDF triples
+upon an io.Writer, one entry at a time, allowing large trees to be
+streamed.
+
+Registrations are identified using urn:oid IRIs (RFC 3061). Registrants
+are identified using LDAP URLs (RFC 4516) of their DNs. Entries lacking
+such information are identified using blank nodes.
+
+Values of DN-syntax attribute types (e.g.: supArc, currentAuthority and
+sponsor) are rendered as resource links rather than literals. Where the
+DN resides beneath a registration base of the DUAConfig field (or that of
+the Registration being written), a urn:oid IRI is produced; otherwise,
+an LDAP URL is produced. All other values are rendered as plain literals.
+
+COMBINED registrant values are rendered upon the subject of the
+Registration that bears them.
+
+The zero value is not usable; see the NewRDFWriter function.
+*/
+type RDFWriter struct {
+	Vocabulary string
+	DUAConfig  *DUAConfig
+
+	w       *errWriter
+	f       RDFFormat
+	dnAttrs map[string]bool
+	started bool
+	blanks  int
+}
+
+/*
+NewRDFWriter returns a freshly initialized instance of *RDFWriter, which
+writes the specified RDFFormat upon the input io.Writer using the default
+RDFVocabulary.
+*/
+func NewRDFWriter(w io.Writer, f RDFFormat) *RDFWriter {
+	dnAttrs := make(map[string]bool)
+	s := DraftSchema()
+	for i := 0; i < len(s.AttributeTypes); i++ {
+		if a := s.AttributeTypes[i]; a.Sup == `distinguishedName` ||
+			a.Syntax == `1.3.6.1.4.1.1466.115.121.1.12` {
+			for j := 0; j < len(a.Names); j++ {
+				dnAttrs[lc(a.Names[j])] = true
+			}
+		}
+	}
+
+	return &RDFWriter{
+		Vocabulary: RDFVocabulary,
+		w:          &errWriter{w: w},
+		f:          f,
+		dnAttrs:    dnAttrs,
+	}
+}
+
+/*
+WriteRegistration writes the triples of the input Registration, returning
+an error if the format is unknown, or if writing fails.
+*/
+func (r *RDFWriter) WriteRegistration(reg Registration) error {
+	if reg == nil {
+		return NilRegistrationErr
+	}
+
+	subj := r.registrationIRI(reg)
+	m := reg.Unmarshal()
+	for _, rant := range []Registrant{
+		reg.CombinedFirstAuthority(),
+		reg.CombinedCurrentAuthority(),
+		reg.CombinedSponsor(),
+	} {
+		if !isNilRegistrant(rant) {
+			mergeAttrs(m, rant.Unmarshal())
+		}
+	}
+
+	return r.write(subj, m, reg.DUAConfig())
+}
+
+/*
+WriteRegistrant writes the triples of the input Registrant, returning an
+error if the format is unknown, or if writing fails.
+*/
+func (r *RDFWriter) WriteRegistrant(rant Registrant) error {
+	if isNilRegistrant(rant) {
+		return NilRegistrantErr
+	}
+
+	var subj string
+	if dn := rant.DN(); len(dn) > 0 {
+		subj = `<` + ldapIRI(dn) + `>`
+	}
+
+	return r.write(subj, rant.Unmarshal(), rant.DUAConfig())
+}
+
+/*
+WriteRegistrations calls the WriteRegistration method for each non-nil
+Registration within the input instance.
+*/
+func (r *RDFWriter) WriteRegistrations(regs Registrations) (err error) {
+	for i := 0; i < len(regs) && err == nil; i++ {
+		if regs[i] != nil {
+			err = r.WriteRegistration(regs[i])
+		}
+	}
+
+	return
+}
+
+/*
+WriteRegistrants calls the WriteRegistrant method for each non-nil
+Registrant within the input instance.
+*/
+func (r *RDFWriter) WriteRegistrants(rants Registrants) (err error) {
+	for i := 0; i < len(rants) && err == nil; i++ {
+		if !isNilRegistrant(rants[i]) {
+			err = r.WriteRegistrant(rants[i])
+		}
+	}
+
+	return
+}
+
+/*
+registrationIRI returns the bracketed urn:oid IRI of the input Registration,
+or a zero string if one cannot be determined.
+*/
+func (r *RDFWriter) registrationIRI(reg Registration) string {
+	oid := reg.DotNotation()
+	if len(oid) == 0 {
+		if _, isRoot := reg.(*RootArc); isRoot {
+			oid = reg.N()
+		}
+	}
+	if len(oid) == 0 && len(reg.DN()) > 0 {
+		oid = r.dnOID(reg.DN(), reg.DUAConfig())
+	}
+
+	if len(oid) == 0 {
+		return ``
+	}
+
+	return `<urn:oid:` + oid + `>`
+}
+
+/*
+dnOID returns the dotNotation value inferred from the input DN using the
+DUAConfig field, or the input *DUAConfig, or a zero string if neither
+applies.
+*/
+func (r *RDFWriter) dnOID(dn string, d *DUAConfig) string {
+	for _, cfg := range []*DUAConfig{r.DUAConfig, d} {
+		if cfg == nil {
+			continue
+		}
+		if oid, err := cfg.RegistrationOID(dn); err == nil {
+			return oid
+		}
+	}
+
+	return ``
+}
+
+/*
+write writes the triples of a single subject, which is replaced with a
+blank node if zero.
+*/
+func (r *RDFWriter) write(subj string, m map[string][]string, d *DUAConfig) error {
+	if r.f != TurtleFormat && r.f != NTriplesFormat {
+		return errorf("Unknown %T '%d'", r.f, r.f)
+	}
+
+	if len(subj) == 0 {
+		r.blanks++
+		subj = sprintf("_:b%d", r.blanks)
+	}
+
+	if !r.started {
+		r.started = true
+		if r.f == TurtleFormat {
+			r.w.line(`@prefix x660: <` + r.Vocabulary + `> .`)
+		}
+	}
+
+	type pred struct {
+		name string
+		objs []string
+	}
+
+	var classes []string
+	for _, oc := range mapValues(m, `objectClass`) {
+		if !eq(oc, `top`) && !strInSlice(oc, classes) {
+			classes = append(classes, oc)
+		}
+	}
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !eq(k, `objectClass`) && len(m[k]) > 0 {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var preds []pred
+	for _, k := range keys {
+		p := pred{name: attrBase(k)}
+		for _, v := range m[k] {
+			if r.dnAttrs[lc(attrBase(k))] {
+				if oid := r.dnOID(v, d); len(oid) > 0 {
+					p.objs = append(p.objs, `<urn:oid:`+oid+`>`)
+				} else {
+					p.objs = append(p.objs, `<`+ldapIRI(v)+`>`)
+				}
+			} else {
+				p.objs = append(p.objs, rdfLiteral(v))
+			}
+		}
+		preds = append(preds, p)
+	}
+
+	if r.f == NTriplesFormat {
+		for _, c := range classes {
+			r.w.line(subj + ` <` + rdfType + `> <` + r.Vocabulary + c + `> .`)
+		}
+		for _, p := range preds {
+			for _, o := range p.objs {
+				r.w.line(subj + ` <` + r.Vocabulary + p.name + `> ` + o + ` .`)
+			}
+		}
+		return r.w.err
+	}
+
+	// Turtle: group predicates and objects by subject.
+	var stmts []string
+	if len(classes) > 0 {
+		for i := 0; i < len(classes); i++ {
+			classes[i] = `x660:` + classes[i]
+		}
+		stmts = append(stmts, `a `+join(classes, `, `))
+	}
+	for _, p := range preds {
+		stmts = append(stmts, `x660:`+p.name+` `+join(p.objs, `, `))
+	}
+
+	r.w.line(``)
+	if len(stmts) == 0 {
+		// A subject with no predicates cannot
+		// be expressed; emit nothing further.
+		return r.w.err
+	}
+	r.w.line(subj + ` ` + join(stmts, " ;\n\t") + ` .`)
+
+	return r.w.err
+}
+
+/*
+rdfLiteral returns the input value as a quoted RDF literal, escaped per
+the ECHAR production shared by Turtle and N-Triples.
+*/
+func rdfLiteral(v string) string {
+	b := make([]byte, 0, len(v)+2)
+	b = append(b, '"')
+	for i := 0; i < len(v); i++ {
+		switch c := v[i]; c {
+		case '"', '\\':
+			b = append(b, '\\', c)
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		default:
+			b = append(b, c)
+		}
+	}
+
+	return string(append(b, '"'))
+}
+
+/*
+ldapIRI returns an LDAP URL (RFC 4516) identifying the input DN, with any
+characters not permitted within an IRI, or bearing special meaning within
+an LDAP URL, percent-encoded.
+*/
+func ldapIRI(dn string) string {
+	const hex = `0123456789ABCDEF`
+
+	b := []byte(`ldap:///`)
+	for i := 0; i < len(dn); i++ {
+		switch c := dn[i]; {
+		case c <= ' ' || c == 0x7f || contains("\"%<>?#\\^`{|}", string(c)):
+			b = append(b, '%', hex[c>>4], hex[c&15])
+		default:
+			b = append(b, c)
+		}
+	}
+
+	return string(b)
+}
+
+/*
+mergeAttrs adds the attribute values of src to dst, avoiding duplicate
+objectClass values.
+*/
+func mergeAttrs(dst, src map[string][]string) {
+	for k, v := range src {
+		if eq(k, `objectClass`) {
+			for i := 0; i < len(v); i++ {
+				if !strInSlice(v[i], dst[k]) {
+					dst[k] = append(dst[k], v[i])
+				}
+			}
+			continue
+		}
+		n := len(dst[k])
+		dst[k] = append(dst[k][:n:n], v...) // never write to a shared array
+	}
+}
+
+/*
+isNilRegistrant returns a boolean value indicative of whether the input
+Registrant is nil, or wraps a nil pointer.
+*/
+func isNilRegistrant(r Registrant) bool {
+	switch tv := r.(type) {
+	case nil:
+		return true
+	case *FirstAuthority:
+		return tv == nil
+	case *CurrentAuthority:
+		return tv == nil
+	case *Sponsor:
+		return tv == nil
+	}
+
+	return false
+}
@@ -0,0 +1,974 @@
+package dcxl
+
+/*
+schema.go contains the attributeType and objectClass definitions of the
+ID, embedded as structured data, as well as the types used to describe
+them.
+*/
+
+import "sort"
+
+/*
+AttributeType describes a single LDAP attributeType definition, per s.
+4.1.2 of RFC 4512.
+
+The SyntaxLen field, if non-zero, describes the suggested minimum upper
+bound of values of the attribute type (e.g.: the '4096' within '...40{4096}').
+
+The Extensions field contains any 'X-' extensions (e.g.: X-ORIGIN), keyed
+by name.
+*/
+type AttributeType struct {
+	OID                string
+	Names              []string
+	Desc               string
+	Obsolete           bool
+	Sup                string
+	Equality           string
+	Ordering           string
+	Substr             string
+	Syntax             string
+	SyntaxLen          uint
+	SingleValue        bool
+	Collective         bool
+	NoUserModification bool
+	Usage              string
+	Extensions         map[string][]string
+}
+
+/*
+ObjectClass describes a single LDAP objectClass definition, per s. 4.1.1
+of RFC 4512. The Kind field shall contain one of `ABSTRACT`, `STRUCTURAL`
+or `AUXILIARY`.
+
+The Extensions field contains any 'X-' extensions (e.g.: X-ORIGIN), keyed
+by name.
+*/
+type ObjectClass struct {
+	OID        string
+	Names      []string
+	Desc       string
+	Obsolete   bool
+	Sup        []string
+	Kind       string
+	Must       []string
+	May        []string
+	Extensions map[string][]string
+}
+
+/*
+Schema contains slices of AttributeType and ObjectClass instances.
+*/
+type Schema struct {
+	AttributeTypes []AttributeType
+	ObjectClasses  []ObjectClass
+}
+
+/*
+DraftSchema returns a freshly allocated *Schema instance containing all
+of the attributeType and objectClass definitions found within s. 2.1 and
+s. 2.2 of the ID, in order of appearance.
+*/
+func DraftSchema() *Schema {
+	s := &Schema{
+		AttributeTypes: make([]AttributeType, len(draftAttributeTypes)),
+		ObjectClasses:  make([]ObjectClass, len(draftObjectClasses)),
+	}
+
+	for i := 0; i < len(draftAttributeTypes); i++ {
+		s.AttributeTypes[i] = draftAttributeTypes[i].clone()
+	}
+	for i := 0; i < len(draftObjectClasses); i++ {
+		s.ObjectClasses[i] = draftObjectClasses[i].clone()
+	}
+
+	return s
+}
+
+/*
+AttributeType returns the AttributeType bearing the input name or OID
+alongside a presence-indicative boolean value. Names are matched in
+case-insensitive fashion.
+*/
+func (r *Schema) AttributeType(id string) (AttributeType, bool) {
+	if r != nil {
+		for i := 0; i < len(r.AttributeTypes); i++ {
+			if r.AttributeTypes[i].OID == id || strInSlice(id, r.AttributeTypes[i].Names) {
+				return r.AttributeTypes[i], true
+			}
+		}
+	}
+
+	return AttributeType{}, false
+}
+
+/*
+ObjectClass returns the ObjectClass bearing the input name or OID
+alongside a presence-indicative boolean value. Names are matched in
+case-insensitive fashion.
+*/
+func (r *Schema) ObjectClass(id string) (ObjectClass, bool) {
+	if r != nil {
+		for i := 0; i < len(r.ObjectClasses); i++ {
+			if r.ObjectClasses[i].OID == id || strInSlice(id, r.ObjectClasses[i].Names) {
+				return r.ObjectClasses[i], true
+			}
+		}
+	}
+
+	return ObjectClass{}, false
+}
+
+/*
+Name returns the first (principal) name of the receiver, or its OID if
+no names are defined.
+*/
+func (r AttributeType) Name() string {
+	if len(r.Names) > 0 {
+		return r.Names[0]
+	}
+
+	return r.OID
+}
+
+/*
+Name returns the first (principal) name of the receiver, or its OID if
+no names are defined.
+*/
+func (r ObjectClass) Name() string {
+	if len(r.Names) > 0 {
+		return r.Names[0]
+	}
+
+	return r.OID
+}
+
+/*
+String returns the RFC 4512 AttributeTypeDescription of the receiver on
+a single line.
+*/
+func (r AttributeType) String() string {
+	return join(r.clauses(), ` `)
+}
+
+/*
+String returns the RFC 4512 ObjectClassDescription of the receiver on a
+single line.
+*/
+func (r ObjectClass) String() string {
+	return join(r.clauses(), ` `)
+}
+
+/*
+clauses returns the receiver's RFC 4512 definition as a sequence of
+clauses, beginning with the opening parenthesis and OID and ending with
+the closing parenthesis. This allows writers to choose their own clause
+delimiter (e.g.: a space, or a newline and indent).
+*/
+func (r AttributeType) clauses() (c []string) {
+	c = append(c, `( `+r.OID)
+	if len(r.Names) > 0 {
+		c = append(c, `NAME `+qdescrs(r.Names))
+	}
+	if len(r.Desc) > 0 {
+		c = append(c, `DESC '`+escapeQDString(r.Desc)+`'`)
+	}
+	if r.Obsolete {
+		c = append(c, `OBSOLETE`)
+	}
+	for _, kv := range [][2]string{
+		{`SUP`, r.Sup},
+		{`EQUALITY`, r.Equality},
+		{`ORDERING`, r.Ordering},
+		{`SUBSTR`, r.Substr},
+	} {
+		if len(kv[1]) > 0 {
+			c = append(c, kv[0]+` `+kv[1])
+		}
+	}
+	if len(r.Syntax) > 0 {
+		syn := r.Syntax
+		if r.SyntaxLen > 0 {
+			syn += `{` + itoa(int(r.SyntaxLen)) + `}`
+		}
+		c = append(c, `SYNTAX `+syn)
+	}
+	if r.SingleValue {
+		c = append(c, `SINGLE-VALUE`)
+	}
+	if r.Collective {
+		c = append(c, `COLLECTIVE`)
+	}
+	if r.NoUserModification {
+		c = append(c, `NO-USER-MODIFICATION`)
+	}
+	if len(r.Usage) > 0 {
+		c = append(c, `USAGE `+r.Usage)
+	}
+
+	return append(append(c, extensionClauses(r.Extensions)...), `)`)
+}
+
+/*
+clauses returns the receiver's RFC 4512 definition as a sequence of
+clauses. See the AttributeType.clauses method for details.
+*/
+func (r ObjectClass) clauses() (c []string) {
+	c = append(c, `( `+r.OID)
+	if len(r.Names) > 0 {
+		c = append(c, `NAME `+qdescrs(r.Names))
+	}
+	if len(r.Desc) > 0 {
+		c = append(c, `DESC '`+escapeQDString(r.Desc)+`'`)
+	}
+	if r.Obsolete {
+		c = append(c, `OBSOLETE`)
+	}
+	if len(r.Sup) > 0 {
+		c = append(c, `SUP `+oids(r.Sup))
+	}
+	if len(r.Kind) > 0 {
+		c = append(c, r.Kind)
+	}
+	if len(r.Must) > 0 {
+		c = append(c, `MUST `+oids(r.Must))
+	}
+	if len(r.May) > 0 {
+		c = append(c, `MAY `+oids(r.May))
+	}
+
+	return append(append(c, extensionClauses(r.Extensions)...), `)`)
+}
+
+func (r AttributeType) clone() AttributeType {
+	r.Names = append([]string{}, r.Names...)
+	r.Extensions = cloneExtensions(r.Extensions)
+	return r
+}
+
+func (r ObjectClass) clone() ObjectClass {
+	r.Names = append([]string{}, r.Names...)
+	r.Sup = append([]string{}, r.Sup...)
+	r.Must = append([]string{}, r.Must...)
+	r.May = append([]string{}, r.May...)
+	r.Extensions = cloneExtensions(r.Extensions)
+	return r
+}
+
+func cloneExtensions(x map[string][]string) map[string][]string {
+	if x == nil {
+		return nil
+	}
+
+	return copyAttrs(x)
+}
+
+/*
+extensionClauses returns the RFC 4512 extensions clauses of the input
+map, ordered by name.
+*/
+func extensionClauses(x map[string][]string) (c []string) {
+	var names []string
+	for k := range x {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for i := 0; i < len(names); i++ {
+		var vals []string
+		for _, v := range x[names[i]] {
+			vals = append(vals, escapeQDString(v))
+		}
+		if len(vals) > 0 {
+			c = append(c, names[i]+` `+qdescrs(vals))
+		}
+	}
+
+	return
+}
+
+/*
+qdescrs returns the RFC 4512 qdescrs form of the input names.
+*/
+func qdescrs(names []string) string {
+	if len(names) == 1 {
+		return `'` + names[0] + `'`
+	}
+
+	return `( '` + join(names, `' '`) + `' )`
+}
+
+/*
+oids returns the RFC 4512 oids form of the input names.
+*/
+func oids(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+
+	return `( ` + join(names, ` $ `) + ` )`
+}
+
+/*
+escapeQDString escapes the input string for use within an RFC 4512
+qdstring, in which single quotes and backslashes are escaped as `\27`
+and `\5C` respectively.
+*/
+func escapeQDString(s string) string {
+	return replaceAll(replaceAll(s, `\`, `\5C`), `'`, `\27`)
+}
+
+/*
+draftAttributeTypes contains the attributeType definitions found in s. 2.1
+of the ID. Use the DraftSchema function to obtain a copy.
+*/
+var draftAttributeTypes []AttributeType = []AttributeType{
+	{ // s. 2.1.1
+		OID:         `1.3.6.1.4.1.56521.101.2.1.1`,
+		Names:       []string{`n`, `numberForm`},
+		Desc:        `A single unsigned integer value assigned to a registration to represent its primary integer identifier`,
+		Equality:    `integerMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.27`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.2
+		OID:         `1.3.6.1.4.1.56521.101.2.1.2`,
+		Names:       []string{`dotNotation`},
+		Desc:        `Dotted ASN.1 Object Identifier for a sub arc`,
+		Equality:    `objectIdentifierMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.38`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.3
+		OID:      `1.3.6.1.4.1.56521.101.2.1.3`,
+		Names:    []string{`iRI`},
+		Desc:     `Internationalized Resource Identifiers for a registration entry`,
+		Equality: `octetStringMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.40`,
+	},
+	{ // s. 2.1.4
+		OID:         `1.3.6.1.4.1.56521.101.2.1.4`,
+		Names:       []string{`asn1Notation`},
+		Desc:        `An ordered sequence of NameAndNumberForm or NumberForm values, enclosed within curly braces, that identify an OID`,
+		Equality:    `caseIgnoreMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.15`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.5
+		OID:      `1.3.6.1.4.1.56521.101.2.1.5`,
+		Names:    []string{`unicodeValue`},
+		Desc:     `Primary non-numeric Unicode identifiers for a registration`,
+		Equality: `octetStringMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.40`,
+	},
+	{ // s. 2.1.6
+		OID:         `1.3.6.1.4.1.56521.101.2.1.6`,
+		Names:       []string{`identifier`, `nameForm`},
+		Desc:        `The non-Unicode secondary identifier for a registration`,
+		Sup:         `name`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.7
+		OID:   `1.3.6.1.4.1.56521.101.2.1.7`,
+		Names: []string{`additionalIdentifier`},
+		Desc:  `The non-Unicode additional identifiers or nameForms for a registration`,
+		Sup:   `name`,
+	},
+	{ // s. 2.1.8
+		OID:       `1.3.6.1.4.1.56521.101.2.1.8`,
+		Names:     []string{`registrationInformation`},
+		Desc:      `Extended octet-based data for a registration`,
+		Equality:  `octetStringMatch`,
+		Syntax:    `1.3.6.1.4.1.1466.115.121.1.40`,
+		SyntaxLen: 4096,
+	},
+	{ // s. 2.1.9
+		OID:   `1.3.6.1.4.1.56521.101.2.1.9`,
+		Names: []string{`registrationURI`},
+		Desc:  `URI, with an optional label, leading to further related subject matter information`,
+		Sup:   `labeledURI`,
+	},
+	{ // s. 2.1.10
+		OID:         `1.3.6.1.4.1.56521.101.2.1.10`,
+		Names:       []string{`registrationCreated`},
+		Desc:        `Generalized timestamp for a registration creation`,
+		Equality:    `generalizedTimeMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.24`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.11
+		OID:      `1.3.6.1.4.1.56521.101.2.1.11`,
+		Names:    []string{`registrationModified`},
+		Desc:     `Generalized timestamps for registration modifications`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.12
+		OID:         `1.3.6.1.4.1.56521.101.2.1.12`,
+		Names:       []string{`registrationRange`},
+		Desc:        `Numerical registration range expression`,
+		Equality:    `integerMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.27`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.13
+		OID:         `1.3.6.1.4.1.56521.101.2.1.13`,
+		Names:       []string{`registrationStatus`},
+		Desc:        `Current status of a registration`,
+		Sup:         `description`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.14
+		OID:      `1.3.6.1.4.1.56521.101.2.1.14`,
+		Names:    []string{`isLeafNode`},
+		Desc:     `Whether a registration may allocate, or allow the enumeration of, subordinate registrations`,
+		Equality: `booleanMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.7`,
+	},
+	{ // s. 2.1.15
+		OID:      `1.3.6.1.4.1.56521.101.2.1.15`,
+		Names:    []string{`isFrozen`},
+		Desc:     `Whether a registration may allocate any additional subordinate registrations`,
+		Equality: `booleanMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.7`,
+	},
+	{ // s. 2.1.16
+		OID:      `1.3.6.1.4.1.56521.101.2.1.16`,
+		Names:    []string{`stdNameForm`},
+		Desc:     `Standardized NameForm per X.680`,
+		Equality: `caseExactMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.15`,
+	},
+	{ // s. 2.1.17
+		OID:         `1.3.6.1.4.1.56521.101.2.1.17`,
+		Names:       []string{`nameAndNumberForm`},
+		Desc:        `NameAndNumberForm value, per X.680`,
+		Equality:    `caseExactMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.15`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.18
+		OID:      `1.3.6.1.4.1.56521.101.2.1.18`,
+		Names:    []string{`longArc`},
+		Desc:     `The well-known Long Arc names associated with, and registered to, a Joint-ISO-ITU-T subordinate registration`,
+		Equality: `octetStringMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.40`,
+	},
+	{ // s. 2.1.19
+		OID:   `1.3.6.1.4.1.56521.101.2.1.19`,
+		Names: []string{`supArc`},
+		Desc:  `LDAP Distinguished Name of the logically superior immediate registration`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.20
+		OID:   `1.3.6.1.4.1.56521.101.2.1.20`,
+		Names: []string{`topArc`},
+		Desc:  `LDAP Distinguished Name of the absolute superior root registration`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.21
+		OID:   `1.3.6.1.4.1.56521.101.2.1.21`,
+		Names: []string{`subArc`},
+		Desc:  `LDAP Distinguished Name of a subordinate registration residing below a given entry`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.22
+		OID:         `1.3.6.1.4.1.56521.101.2.1.22`,
+		Names:       []string{`leftArc`},
+		Desc:        `LDAP Distinguished Name of the nearest lexically antecedent sibling registration`,
+		Sup:         `distinguishedName`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.23
+		OID:   `1.3.6.1.4.1.56521.101.2.1.23`,
+		Names: []string{`firstArc`},
+		Desc:  `LDAP Distinguished Name of the farthest lexically antecedent sibling registration`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.24
+		OID:         `1.3.6.1.4.1.56521.101.2.1.24`,
+		Names:       []string{`rightArc`},
+		Desc:        `LDAP Distinguished Name of the nearest lexically subsequent sibling registration`,
+		Sup:         `distinguishedName`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.25
+		OID:   `1.3.6.1.4.1.56521.101.2.1.25`,
+		Names: []string{`finalArc`},
+		Desc:  `LDAP Distinguished Name of the farthest lexically subsequent sibling registration`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.26
+		OID:   `1.3.6.1.4.1.56521.101.2.1.26`,
+		Names: []string{`discloseTo`},
+		Desc:  `LDAP Distinguished Names of entries which are granted access to a given registration and its immediate children`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.27
+		OID:         `1.3.6.1.4.1.56521.101.2.1.27`,
+		Names:       []string{`registrantID`},
+		Desc:        `GUID or UUID assigned to a past or present registration authority or sponsor entry`,
+		Equality:    `octetStringMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.40`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.28
+		OID:   `1.3.6.1.4.1.56521.101.2.1.28`,
+		Names: []string{`currentAuthority`},
+		Desc:  `LDAP Distinguished Name of an entry bearing current registration authority information`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.29
+		OID:      `1.3.6.1.4.1.56521.101.2.1.29`,
+		Names:    []string{`currentAuthorityStartTimestamp`},
+		Desc:     `Generalized time stamp indicating the date and time at which current authority commenced`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.30
+		OID:         `1.3.6.1.4.1.56521.101.2.1.30`,
+		Names:       []string{`currentAuthorityCommonName`},
+		Desc:        `Common Name assigned to a current registration authority entry`,
+		Sup:         `cn`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.31
+		OID:         `1.3.6.1.4.1.56521.101.2.1.31`,
+		Names:       []string{`currentAuthorityCountryCode`},
+		Desc:        `Country Code assigned to a current registration authority entry`,
+		Sup:         `c`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.32
+		OID:         `1.3.6.1.4.1.56521.101.2.1.32`,
+		Names:       []string{`currentAuthorityCountryName`},
+		Desc:        `Country name assigned to a current registration authority entry`,
+		Sup:         `co`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.33
+		OID:         `1.3.6.1.4.1.56521.101.2.1.33`,
+		Names:       []string{`currentAuthorityEmail`},
+		Desc:        `Email address assigned to a current registration authority entry`,
+		Sup:         `mail`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.34
+		OID:         `1.3.6.1.4.1.56521.101.2.1.34`,
+		Names:       []string{`currentAuthorityFax`},
+		Desc:        `Facsimile telephone number assigned to a current registration authority entry`,
+		Sup:         `facsimileTelephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.35
+		OID:         `1.3.6.1.4.1.56521.101.2.1.35`,
+		Names:       []string{`currentAuthorityLocality`},
+		Desc:        `Locality name assigned to a current registration authority entry`,
+		Sup:         `l`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.36
+		OID:         `1.3.6.1.4.1.56521.101.2.1.36`,
+		Names:       []string{`currentAuthorityMobile`},
+		Desc:        `Mobile telephone number assigned to a current registration authority entry`,
+		Sup:         `mobile`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.37
+		OID:         `1.3.6.1.4.1.56521.101.2.1.37`,
+		Names:       []string{`currentAuthorityOrg`},
+		Desc:        `Organization name assigned to a current registration authority entry`,
+		Sup:         `o`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.38
+		OID:         `1.3.6.1.4.1.56521.101.2.1.38`,
+		Names:       []string{`currentAuthorityPOBox`},
+		Desc:        `Post office box number assigned to a current registration authority entry`,
+		Sup:         `postOfficeBox`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.39
+		OID:         `1.3.6.1.4.1.56521.101.2.1.39`,
+		Names:       []string{`currentAuthorityPostalAddress`},
+		Desc:        `Full postal address assigned to a current registration authority entry`,
+		Sup:         `postalAddress`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.40
+		OID:         `1.3.6.1.4.1.56521.101.2.1.40`,
+		Names:       []string{`currentAuthorityPostalCode`},
+		Desc:        `Postal code assigned to a current registration authority entry`,
+		Sup:         `postalCode`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.41
+		OID:         `1.3.6.1.4.1.56521.101.2.1.41`,
+		Names:       []string{`currentAuthorityState`},
+		Desc:        `State or province name assigned to a current registration authority entry`,
+		Sup:         `st`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.42
+		OID:         `1.3.6.1.4.1.56521.101.2.1.42`,
+		Names:       []string{`currentAuthorityStreet`},
+		Desc:        `Street name and number assigned to a current registration authority entry`,
+		Sup:         `street`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.43
+		OID:         `1.3.6.1.4.1.56521.101.2.1.43`,
+		Names:       []string{`currentAuthorityTelephone`},
+		Desc:        `Telephone number assigned to a current registration authority entry`,
+		Sup:         `telephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.44
+		OID:         `1.3.6.1.4.1.56521.101.2.1.44`,
+		Names:       []string{`currentAuthorityTitle`},
+		Desc:        `Title assigned to a current registration authority entry`,
+		Sup:         `title`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.45
+		OID:   `1.3.6.1.4.1.56521.101.2.1.45`,
+		Names: []string{`currentAuthorityURI`},
+		Desc:  `URI, with an optional label, leading to a resource related to a current registration authority`,
+		Sup:   `labeledURI`,
+	},
+	{ // s. 2.1.46
+		OID:   `1.3.6.1.4.1.56521.101.2.1.46`,
+		Names: []string{`firstAuthority`},
+		Desc:  `LDAP Distinguished Name of an entry bearing previous registration authority information`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.47
+		OID:      `1.3.6.1.4.1.56521.101.2.1.47`,
+		Names:    []string{`firstAuthorityStartTimestamp`},
+		Desc:     `Generalized timestamp indicating the date and time at which a previous registration authority commenced`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.48
+		OID:      `1.3.6.1.4.1.56521.101.2.1.48`,
+		Names:    []string{`firstAuthorityEndTimestamp`},
+		Desc:     `Generalized timestamp indicating the date and time at which a previous registration authority terminated`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.49
+		OID:         `1.3.6.1.4.1.56521.101.2.1.49`,
+		Names:       []string{`firstAuthorityCommonName`},
+		Desc:        `Common Name assigned to a previous registration authority entry`,
+		Sup:         `cn`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.50
+		OID:         `1.3.6.1.4.1.56521.101.2.1.50`,
+		Names:       []string{`firstAuthorityCountryCode`},
+		Desc:        `Country Code assigned to a previous registration authority entry`,
+		Sup:         `c`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.51
+		OID:         `1.3.6.1.4.1.56521.101.2.1.51`,
+		Names:       []string{`firstAuthorityCountryName`},
+		Desc:        `Country name assigned to a previous registration authority entry`,
+		Sup:         `co`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.52
+		OID:         `1.3.6.1.4.1.56521.101.2.1.52`,
+		Names:       []string{`firstAuthorityEmail`},
+		Desc:        `Email address assigned to a previous registration authority entry`,
+		Sup:         `mail`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.53
+		OID:         `1.3.6.1.4.1.56521.101.2.1.53`,
+		Names:       []string{`firstAuthorityFax`},
+		Desc:        `Facsimile telephone number assigned to a previous registration authority entry`,
+		Sup:         `facsimileTelephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.54
+		OID:         `1.3.6.1.4.1.56521.101.2.1.54`,
+		Names:       []string{`firstAuthorityLocality`},
+		Desc:        `Locality name assigned to a previous registration authority entry`,
+		Sup:         `l`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.55
+		OID:         `1.3.6.1.4.1.56521.101.2.1.55`,
+		Names:       []string{`firstAuthorityMobile`},
+		Desc:        `Mobile telephone number assigned to a previous registration authority entry`,
+		Sup:         `mobile`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.56
+		OID:         `1.3.6.1.4.1.56521.101.2.1.56`,
+		Names:       []string{`firstAuthorityOrg`},
+		Desc:        `Organization name assigned to a previous registration authority entry`,
+		Sup:         `o`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.57
+		OID:         `1.3.6.1.4.1.56521.101.2.1.57`,
+		Names:       []string{`firstAuthorityPOBox`},
+		Desc:        `Post office box number assigned to a previous registration authority entry`,
+		Sup:         `postOfficeBox`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.58
+		OID:         `1.3.6.1.4.1.56521.101.2.1.58`,
+		Names:       []string{`firstAuthorityPostalAddress`},
+		Desc:        `Full postal address assigned to a previous registration authority entry`,
+		Sup:         `postalAddress`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.59
+		OID:         `1.3.6.1.4.1.56521.101.2.1.59`,
+		Names:       []string{`firstAuthorityPostalCode`},
+		Desc:        `Postal code assigned to a previous registration authority entry`,
+		Sup:         `postalCode`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.60
+		OID:         `1.3.6.1.4.1.56521.101.2.1.60`,
+		Names:       []string{`firstAuthorityState`},
+		Desc:        `State or province name assigned to a previous registration authority entry`,
+		Sup:         `st`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.61
+		OID:         `1.3.6.1.4.1.56521.101.2.1.61`,
+		Names:       []string{`firstAuthorityStreet`},
+		Desc:        `Street name and number assigned to a previous registration authority entry`,
+		Sup:         `street`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.62
+		OID:         `1.3.6.1.4.1.56521.101.2.1.62`,
+		Names:       []string{`firstAuthorityTelephone`},
+		Desc:        `Telephone number assigned to a previous registration authority entry`,
+		Sup:         `telephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.63
+		OID:         `1.3.6.1.4.1.56521.101.2.1.63`,
+		Names:       []string{`firstAuthorityTitle`},
+		Desc:        `Title assigned to a previous registration authority entry`,
+		Sup:         `title`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.64
+		OID:   `1.3.6.1.4.1.56521.101.2.1.64`,
+		Names: []string{`firstAuthorityURI`},
+		Desc:  `URI, with an optional label, leading to a resource related to a previous registration authority`,
+		Sup:   `labeledURI`,
+	},
+	{ // s. 2.1.65
+		OID:   `1.3.6.1.4.1.56521.101.2.1.65`,
+		Names: []string{`sponsor`},
+		Desc:  `LDAP Distinguished Name of an entry bearing sponsorship information`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.66
+		OID:      `1.3.6.1.4.1.56521.101.2.1.66`,
+		Names:    []string{`sponsorStartTimestamp`},
+		Desc:     `Generalized timestamp indicating the date and time sponsorship commenced`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.67
+		OID:      `1.3.6.1.4.1.56521.101.2.1.67`,
+		Names:    []string{`sponsorEndTimestamp`},
+		Desc:     `Generalized timestamp indicating the date and time sponsorship terminated`,
+		Equality: `generalizedTimeMatch`,
+		Syntax:   `1.3.6.1.4.1.1466.115.121.1.24`,
+	},
+	{ // s. 2.1.68
+		OID:         `1.3.6.1.4.1.56521.101.2.1.68`,
+		Names:       []string{`sponsorCommonName`},
+		Desc:        `Common Name of a sponsor entry`,
+		Sup:         `cn`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.69
+		OID:         `1.3.6.1.4.1.56521.101.2.1.69`,
+		Names:       []string{`sponsorCountryCode`},
+		Desc:        `Country code for a sponsor entry`,
+		Sup:         `c`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.70
+		OID:         `1.3.6.1.4.1.56521.101.2.1.70`,
+		Names:       []string{`sponsorCountryName`},
+		Desc:        `Country name for a sponsor entry`,
+		Sup:         `co`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.71
+		OID:         `1.3.6.1.4.1.56521.101.2.1.71`,
+		Names:       []string{`sponsorEmail`},
+		Desc:        `Email address for a sponsor entry`,
+		Sup:         `mail`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.72
+		OID:         `1.3.6.1.4.1.56521.101.2.1.72`,
+		Names:       []string{`sponsorFax`},
+		Desc:        `Facsimile telephone number for a sponsor entry`,
+		Sup:         `facsimileTelephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.73
+		OID:         `1.3.6.1.4.1.56521.101.2.1.73`,
+		Names:       []string{`sponsorLocality`},
+		Desc:        `Locality name for a sponsor entry`,
+		Sup:         `l`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.74
+		OID:         `1.3.6.1.4.1.56521.101.2.1.74`,
+		Names:       []string{`sponsorMobile`},
+		Desc:        `Mobile telephone number for a sponsor entry`,
+		Sup:         `mobile`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.75
+		OID:         `1.3.6.1.4.1.56521.101.2.1.75`,
+		Names:       []string{`sponsorOrg`},
+		Desc:        `Organization name for a sponsor entry`,
+		Sup:         `o`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.76
+		OID:         `1.3.6.1.4.1.56521.101.2.1.76`,
+		Names:       []string{`sponsorPOBox`},
+		Desc:        `Post office box number for a sponsor entry`,
+		Sup:         `postOfficeBox`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.77
+		OID:         `1.3.6.1.4.1.56521.101.2.1.77`,
+		Names:       []string{`sponsorPostalAddress`},
+		Desc:        `Full postal address for a sponsor entry`,
+		Sup:         `postalAddress`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.78
+		OID:         `1.3.6.1.4.1.56521.101.2.1.78`,
+		Names:       []string{`sponsorPostalCode`},
+		Desc:        `Postal code for a sponsor entry`,
+		Sup:         `postalCode`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.79
+		OID:         `1.3.6.1.4.1.56521.101.2.1.79`,
+		Names:       []string{`sponsorState`},
+		Desc:        `State or province name for a sponsor entry`,
+		Sup:         `st`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.80
+		OID:         `1.3.6.1.4.1.56521.101.2.1.80`,
+		Names:       []string{`sponsorStreet`},
+		Desc:        `Street name and number for a sponsor entry`,
+		Sup:         `street`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.81
+		OID:         `1.3.6.1.4.1.56521.101.2.1.81`,
+		Names:       []string{`sponsorTelephone`},
+		Desc:        `Telephone number for a sponsor entry`,
+		Sup:         `telephoneNumber`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.82
+		OID:         `1.3.6.1.4.1.56521.101.2.1.82`,
+		Names:       []string{`sponsorTitle`},
+		Desc:        `Title for a sponsor entry`,
+		Sup:         `title`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.83
+		OID:   `1.3.6.1.4.1.56521.101.2.1.83`,
+		Names: []string{`sponsorURI`},
+		Desc:  `URI, with an optional label, for a sponsor entry`,
+		Sup:   `labeledURI`,
+	},
+	{ // s. 2.1.84
+		OID:   `1.3.6.1.4.1.56521.101.2.1.84`,
+		Names: []string{`rARegistrationBase`},
+		Desc:  `LDAP Distinguished Name of the root X.660 registration entry storage location in a DIT`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.85
+		OID:   `1.3.6.1.4.1.56521.101.2.1.85`,
+		Names: []string{`rARegistrantBase`},
+		Desc:  `LDAP Distinguished Name of the root X.660 registrant entry storage location in a DIT`,
+		Sup:   `distinguishedName`,
+	},
+	{ // s. 2.1.86
+		OID:         `1.3.6.1.4.1.56521.101.2.1.86`,
+		Names:       []string{`rADirectoryModel`},
+		Desc:        `Object Identifier meant to advertise the directory model governing the storage of X.660 LDAP entries within the DIT`,
+		Equality:    `objectIdentifierMatch`,
+		Syntax:      `1.3.6.1.4.1.1466.115.121.1.38`,
+		SingleValue: true,
+	},
+	{ // s. 2.1.87
+		OID:   `1.3.6.1.4.1.56521.101.2.1.87`,
+		Names: []string{`rAServiceMail`},
+		Desc:  `Email address used for RA-level registration requests, inquiries or problem reports`,
+		Sup:   `mail`,
+	},
+	{ // s. 2.1.88
+		OID:   `1.3.6.1.4.1.56521.101.2.1.88`,
+		Names: []string{`rAServiceURI`},
+		Desc:  `URI, with an optional label, defining an appropriate RA endpoint`,
+		Sup:   `labeledURI`,
+	},
+}
+
+/*
+draftObjectClasses contains the objectClass definitions found in s. 2.2
+of the ID. Use the DraftSchema function to obtain a copy.
+*/
+var draftObjectClasses []ObjectClass = []ObjectClass{
+	{ // s. 2.2.1
+		OID:   `1.3.6.1.4.1.56521.101.2.2.1`,
+		Names: []string{`x660RootArc`},
+		Desc:  `Top-level class for entries meant to represent ITU-T, ISO or Joint-ISO-ITU-T root arcs as defined in Section A.2 of the X.660 specification`,
+		Sup:   []string{`top`},
+		Kind:  `STRUCTURAL`,
+		Must:  []string{`n`, `unicodeValue`, `identifier`},
+		May:   []string{`registrationInformation`, `additionalIdentifier`, `asn1Notation`, `iRI`, `currentAuthority`, `subArc`, `stdNameForm`, `description`, `registrationURI`, `registrationCreated`, `registrationModified`, `leftArc`, `rightArc`, `nameAndNumberForm`, `firstAuthority`},
+	},
+	{ // s. 2.2.2
+		OID:   `1.3.6.1.4.1.56521.101.2.2.2`,
+		Names: []string{`x660SubArc`},
+		Desc:  `A generalized class meant to represent sub arcs beneath any root, as defined in X.660 Sections A.3-A.5`,
+		Sup:   []string{`top`},
+		Kind:  `STRUCTURAL`,
+		Must:  []string{`n`},
+		May:   []string{`nameAndNumberForm`, `firstAuthority`, `finalArc`, `currentAuthority`, `supArc`, `subArc`, `firstArc`, `topArc`, `description`, `registrationURI`, `iRI`, `isFrozen`, `discloseTo`, `sponsor`, `isLeafNode`, `leftArc`, `rightArc`, `registrationInformation`, `additionalIdentifier`, `registrationCreated`, `unicodeValue`, `longArc`, `registrationRange`, `registrationModified`, `registrationStatus`, `identifier`, `stdNameForm`, `asn1Notation`, `dotNotation`},
+	},
+	{ // s. 2.2.3
+		OID:   `1.3.6.1.4.1.56521.101.2.2.3`,
+		Names: []string{`x660Registrant`},
+		Desc:  `A generalized auxiliary class for registrant contact information`,
+		Sup:   []string{`top`},
+		Kind:  `AUXILIARY`,
+		May:   []string{`currentAuthorityURI`, `sponsorCommonName`, `sponsorOrg`, `firstAuthorityPOBox`, `sponsorPOBox`, `sponsorLocality`, `sponsorCountryCode`, `firstAuthorityURI`, `description`, `firstAuthorityStartTimestamp`, `sponsorStartTimestamp`, `firstAuthorityFax`, `sponsorCountryName`, `sponsorURI`, `sponsorTelephone`, `currentAuthorityOrg`, `sponsorFax`, `currentAuthorityCommonName`, `firstAuthorityMobile`, `currentAuthorityTitle`, `firstAuthorityCountryCode`, `currentAuthorityPostalAddress`, `firstAuthorityOrg`, `currentAuthorityFax`, `firstAuthorityPostalAddress`, `currentAuthorityCountryCode`, `firstAuthorityState`, `currentAuthorityCountryName`, `firstAuthorityEmail`, `currentAuthorityMobile`, `firstAuthorityCommonName`, `firstAuthorityCountryName`, `currentAuthorityState`, `currentAuthorityPostalCode`, `firstAuthorityStreet`, `currentAuthorityTelephone`, `currentAuthorityEmail`, `currentAuthorityLocality`, `firstAuthorityLocality`, `currentAuthorityStreet`, `firstAuthorityPostalCode`, `firstAuthorityTitle`, `registrantID`, `sponsorEmail`, `firstAuthorityEndTimestamp`, `sponsorEndTimestamp`, `firstAuthorityTelephone`, `currentAuthorityPOBox`, `currentAuthorityStartTimestamp`, `sponsorTitle`, `sponsorStreet`, `sponsorMobile`, `sponsorState`, `sponsorPostalCode`, `sponsorPostalAddress`},
+	},
+	{ // s. 2.2.4
+		OID:   `1.3.6.1.4.1.56521.101.2.2.4`,
+		Names: []string{`x660DUAConfig`},
+		Desc:  `Entry class to facilitate advertisement of optimal X.660 DUA configuration values`,
+		Sup:   []string{`top`},
+		Kind:  `AUXILIARY`,
+		May:   []string{`rADirectoryModel`, `rARegistrantBase`, `rAServiceMail`, `rAServiceURI`, `rARegistrationBase`},
+	},
+}
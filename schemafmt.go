@@ -0,0 +1,313 @@
+package dcxl
+
+/*
+schemafmt.go contains writers that render a *Schema instance in formats
+suitable for provisioning various directory products.
+*/
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+/*
+SchemaFormat describes a directory schema file format supported by the
+Write method extended by *Schema instances.
+*/
+type SchemaFormat uint8
+
+const (
+	OpenLDAPConfigFormat SchemaFormat = iota // OpenLDAP cn=config (olcSchemaConfig) LDIF
+	OpenLDAPSchemaFormat                     // OpenLDAP slapd.conf schema include file
+	ApacheDSFormat                           // ApacheDS ou=schema LDIF
+	NetscapeDSFormat                         // Netscape/389-DS 99user.ldif
+	SubschemaFormat                          // RFC 4512 subschema subentry LDIF
+)
+
+/*
+SchemaName is the name used for the schema within formats that require
+one, such as OpenLDAP's cn=config and ApacheDS.
+*/
+const SchemaName = `x660`
+
+/*
+schemaOrigin is used as the X-ORIGIN extension value in formats that
+conventionally bear one.
+*/
+const schemaOrigin = `draft-coretta-x660-ldap-08`
+
+/*
+String returns the string name of the receiver.
+*/
+func (r SchemaFormat) String() string {
+	switch r {
+	case OpenLDAPConfigFormat:
+		return `openldap-config`
+	case OpenLDAPSchemaFormat:
+		return `openldap-schema`
+	case ApacheDSFormat:
+		return `apacheds`
+	case NetscapeDSFormat:
+		return `netscape`
+	case SubschemaFormat:
+		return `subschema`
+	}
+
+	return `unknown`
+}
+
+/*
+Write writes the receiver to the input io.Writer in the specified format,
+returning an error if the format is unknown, or if writing fails.
+
+  - OpenLDAPConfigFormat produces an olcSchemaConfig entry named SchemaName
+    beneath cn=schema,cn=config, suitable for use with ldapadd or slapadd
+  - OpenLDAPSchemaFormat produces a file suitable for inclusion within
+    slapd.conf (see slapd.conf(5))
+  - ApacheDSFormat produces the entries of a schema named SchemaName beneath
+    ou=schema, per the ApacheDS meta schema
+  - NetscapeDSFormat produces a 99user.ldif file, suitable for Netscape DS,
+    Fedora DS and 389-DS
+  - SubschemaFormat produces an RFC 4512 subschema subentry named cn=Subschema
+*/
+func (r *Schema) Write(w io.Writer, f SchemaFormat) error {
+	if r == nil {
+		return errorf("%T is nil", r)
+	}
+
+	ew := &errWriter{w: w}
+	switch f {
+	case OpenLDAPConfigFormat:
+		r.writeOpenLDAPConfig(ew)
+	case OpenLDAPSchemaFormat:
+		r.writeOpenLDAPSchema(ew)
+	case ApacheDSFormat:
+		r.writeApacheDS(ew)
+	case NetscapeDSFormat:
+		r.writeSubentry(ew, `cn=schema`, true)
+	case SubschemaFormat:
+		r.writeSubentry(ew, `cn=Subschema`, false)
+	default:
+		return errorf("Unknown %T '%d'", f, f)
+	}
+
+	return ew.err
+}
+
+func (r *Schema) writeOpenLDAPConfig(w *errWriter) {
+	w.line(`# ` + SchemaName + ` schema, per ` + schemaOrigin)
+	w.attr(`dn`, `cn=`+SchemaName+`,cn=schema,cn=config`)
+	w.attr(`objectClass`, `olcSchemaConfig`)
+	w.attr(`cn`, SchemaName)
+	for i := 0; i < len(r.AttributeTypes); i++ {
+		w.attr(`olcAttributeTypes`, r.AttributeTypes[i].String())
+	}
+	for i := 0; i < len(r.ObjectClasses); i++ {
+		w.attr(`olcObjectClasses`, r.ObjectClasses[i].String())
+	}
+}
+
+func (r *Schema) writeOpenLDAPSchema(w *errWriter) {
+	w.line(`# ` + SchemaName + ` schema, per ` + schemaOrigin)
+	for i := 0; i < len(r.AttributeTypes); i++ {
+		w.line(``)
+		w.line(`attributetype ` + indentClauses(r.AttributeTypes[i].clauses()))
+	}
+	for i := 0; i < len(r.ObjectClasses); i++ {
+		w.line(``)
+		w.line(`objectclass ` + indentClauses(r.ObjectClasses[i].clauses()))
+	}
+}
+
+func (r *Schema) writeApacheDS(w *errWriter) {
+	base := `cn=` + SchemaName + `,ou=schema`
+
+	w.line(`# ` + SchemaName + ` schema, per ` + schemaOrigin)
+	w.attr(`dn`, base)
+	w.attr(`objectClass`, `metaSchema`)
+	w.attr(`objectClass`, `top`)
+	w.attr(`cn`, SchemaName)
+	for _, dep := range []string{`system`, `core`, `cosine`} {
+		w.attr(`m-dependencies`, dep)
+	}
+
+	w.line(``)
+	w.attr(`dn`, `ou=attributeTypes,`+base)
+	w.attr(`objectClass`, `organizationalUnit`)
+	w.attr(`objectClass`, `top`)
+	w.attr(`ou`, `attributeTypes`)
+
+	for i := 0; i < len(r.AttributeTypes); i++ {
+		a := r.AttributeTypes[i]
+		w.line(``)
+		w.attr(`dn`, `m-oid=`+a.OID+`,ou=attributeTypes,`+base)
+		w.attr(`objectClass`, `metaAttributeType`)
+		w.attr(`objectClass`, `metaTop`)
+		w.attr(`objectClass`, `top`)
+		w.attr(`m-oid`, a.OID)
+		for j := 0; j < len(a.Names); j++ {
+			w.attr(`m-name`, a.Names[j])
+		}
+		w.optAttr(`m-description`, a.Desc)
+		w.optAttr(`m-supAttributeType`, a.Sup)
+		w.optAttr(`m-equality`, a.Equality)
+		w.optAttr(`m-ordering`, a.Ordering)
+		w.optAttr(`m-substr`, a.Substr)
+		w.optAttr(`m-syntax`, a.Syntax)
+		if a.SyntaxLen > 0 {
+			w.attr(`m-length`, itoa(int(a.SyntaxLen)))
+		}
+		if a.SingleValue {
+			w.attr(`m-singleValue`, `TRUE`)
+		}
+		if a.Obsolete {
+			w.attr(`m-obsolete`, `TRUE`)
+		}
+		if a.Collective {
+			w.attr(`m-collective`, `TRUE`)
+		}
+		if a.NoUserModification {
+			w.attr(`m-noUserModification`, `TRUE`)
+		}
+		w.optAttr(`m-usage`, a.Usage)
+	}
+
+	w.line(``)
+	w.attr(`dn`, `ou=objectClasses,`+base)
+	w.attr(`objectClass`, `organizationalUnit`)
+	w.attr(`objectClass`, `top`)
+	w.attr(`ou`, `objectClasses`)
+
+	for i := 0; i < len(r.ObjectClasses); i++ {
+		o := r.ObjectClasses[i]
+		w.line(``)
+		w.attr(`dn`, `m-oid=`+o.OID+`,ou=objectClasses,`+base)
+		w.attr(`objectClass`, `metaObjectClass`)
+		w.attr(`objectClass`, `metaTop`)
+		w.attr(`objectClass`, `top`)
+		w.attr(`m-oid`, o.OID)
+		for j := 0; j < len(o.Names); j++ {
+			w.attr(`m-name`, o.Names[j])
+		}
+		w.optAttr(`m-description`, o.Desc)
+		if o.Obsolete {
+			w.attr(`m-obsolete`, `TRUE`)
+		}
+		for j := 0; j < len(o.Sup); j++ {
+			w.attr(`m-supObjectClass`, o.Sup[j])
+		}
+		w.optAttr(`m-typeObjectClass`, o.Kind)
+		for j := 0; j < len(o.Must); j++ {
+			w.attr(`m-must`, o.Must[j])
+		}
+		for j := 0; j < len(o.May); j++ {
+			w.attr(`m-may`, o.May[j])
+		}
+	}
+}
+
+/*
+writeSubentry writes the receiver as a subschema subentry bearing the
+input DN. If origin is true, the X-ORIGIN extension is added to each
+definition, and the ldapSubentry objectClass is used, per 389-DS.
+*/
+func (r *Schema) writeSubentry(w *errWriter, dn string, origin bool) {
+	w.line(`# ` + SchemaName + ` schema, per ` + schemaOrigin)
+	w.attr(`dn`, dn)
+	w.attr(`objectClass`, `top`)
+	if origin {
+		w.attr(`objectClass`, `ldapSubentry`)
+	}
+	w.attr(`objectClass`, `subschema`)
+	w.attr(`cn`, split(split(dn, `,`)[0], `=`)[1])
+
+	ext := func(c []string) string {
+		if origin && !contains(join(c, ` `), ` X-ORIGIN `) {
+			c = append(c[:len(c)-1], `X-ORIGIN '`+schemaOrigin+`'`, `)`)
+		}
+		return join(c, ` `)
+	}
+
+	for i := 0; i < len(r.AttributeTypes); i++ {
+		w.attr(`attributeTypes`, ext(r.AttributeTypes[i].clauses()))
+	}
+	for i := 0; i < len(r.ObjectClasses); i++ {
+		w.attr(`objectClasses`, ext(r.ObjectClasses[i].clauses()))
+	}
+}
+
+/*
+indentClauses joins the input definition clauses using a newline and tab,
+per the conventional presentation of definitions within slapd.conf schema
+files. The closing parenthesis remains upon the final line.
+*/
+func indentClauses(c []string) string {
+	if len(c) < 2 {
+		return join(c, ` `)
+	}
+
+	return join(c[:len(c)-1], "\n\t") + ` ` + c[len(c)-1]
+}
+
+/*
+errWriter wraps an io.Writer, retaining the first error encountered such
+that subsequent writes are no-ops.
+*/
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (r *errWriter) line(s string) {
+	if r.err == nil {
+		_, r.err = io.WriteString(r.w, s+"\n")
+	}
+}
+
+/*
+attr writes an LDIF attrval-spec, per RFC 2849, folding long lines at
+seventy-six (76) columns and base64-encoding unsafe values.
+*/
+func (r *errWriter) attr(at, val string) {
+	line := at + `: ` + val
+	if !ldifSafe(val) {
+		line = at + `:: ` + base64.StdEncoding.EncodeToString([]byte(val))
+	}
+
+	for len(line) > 76 {
+		r.line(line[:76])
+		line = ` ` + line[76:]
+	}
+	r.line(line)
+}
+
+/*
+optAttr writes an LDIF attrval-spec only if the input value is non-zero.
+*/
+func (r *errWriter) optAttr(at, val string) {
+	if len(val) > 0 {
+		r.attr(at, val)
+	}
+}
+
+/*
+ldifSafe returns a boolean value indicative of whether the input value is
+a SAFE-STRING, per RFC 2849.
+*/
+func ldifSafe(val string) bool {
+	if len(val) == 0 {
+		return true
+	} else if c := val[0]; c == ' ' || c == ':' || c == '<' {
+		return false
+	} else if val[len(val)-1] == ' ' {
+		return false
+	}
+
+	for i := 0; i < len(val); i++ {
+		if c := val[i]; c == 0 || c == '\n' || c == '\r' || c > 127 {
+			return false
+		}
+	}
+
+	return true
+}
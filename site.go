@@ -0,0 +1,575 @@
+package dcxl
+
+/*
+site.go contains the static site generator, which publishes Registration
+and Registrant instances as a read-only HTML OID browser.
+*/
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+/*
+PrivacyProfile describes the registrant information published by the
+SiteGenerator type.
+
+Fields contains the names of the registrant fields to be published, each
+of which is an attribute type name defined in the ID less its role prefix
+(e.g.: "CommonName" describes firstAuthorityCommonName, as well as its
+currentAuthority and sponsor counterparts). See the SiteFields variable
+for the supported names.
+
+Registrations whose registrationStatus is "private", or which bear
+discloseTo values, are withheld entirely, alongside their subordinate
+registrations, unless PublishRestricted is true.
+*/
+type PrivacyProfile struct {
+	Fields            []string
+	PublishRestricted bool
+}
+
+/*
+SiteFields contains the names of all registrant fields supported by the
+PrivacyProfile type, in the order in which they are published.
+*/
+var SiteFields = []string{
+	`CommonName`, `Org`, `Title`, `Email`, `Telephone`, `Mobile`, `Fax`,
+	`Street`, `POBox`, `Locality`, `State`, `PostalCode`, `PostalAddress`,
+	`CountryName`, `CountryCode`, `URI`, `StartTimestamp`, `EndTimestamp`,
+}
+
+/*
+Predefined PrivacyProfile instances. NamesOnlyProfile publishes only the
+names and organizations of registrants, ContactProfile adds the public
+contact details, and FullProfile publishes all registrant fields.
+*/
+var (
+	NamesOnlyProfile = PrivacyProfile{Fields: []string{`CommonName`, `Org`}}
+	ContactProfile   = PrivacyProfile{Fields: []string{
+		`CommonName`, `Org`, `Title`, `Email`, `Telephone`, `Locality`,
+		`State`, `CountryName`, `URI`,
+	}}
+	FullProfile = PrivacyProfile{Fields: SiteFields}
+)
+
+/*
+siteLabels contains the display labels of the registrant fields and
+registrant types.
+*/
+var siteLabels = map[string]string{
+	`CommonName`:       `Name`,
+	`Org`:              `Organization`,
+	`Title`:            `Title`,
+	`Email`:            `Email`,
+	`Telephone`:        `Telephone`,
+	`Mobile`:           `Mobile`,
+	`Fax`:              `Fax`,
+	`Street`:           `Street`,
+	`POBox`:            `PO Box`,
+	`Locality`:         `Locality`,
+	`State`:            `State`,
+	`PostalCode`:       `Postal Code`,
+	`PostalAddress`:    `Postal Address`,
+	`CountryName`:      `Country`,
+	`CountryCode`:      `Country Code`,
+	`URI`:              `URI`,
+	`StartTimestamp`:   `Since`,
+	`EndTimestamp`:     `Until`,
+	`firstAuthority`:   `First Authority`,
+	`currentAuthority`: `Current Authority`,
+	`sponsor`:          `Sponsor`,
+}
+
+/*
+siteDetails contains the registration attribute types published upon
+each registration page, in order.
+*/
+var siteDetails = []string{
+	`asn1Notation`, `iRI`, `identifier`, `n`, `additionalIdentifier`,
+	`stdNameForm`, `unicodeValue`, `longArc`, `description`,
+	`registrationInformation`, `registrationURI`, `registrationStatus`,
+	`registrationRange`, `isLeafNode`, `isFrozen`, `registrationCreated`,
+	`registrationModified`,
+}
+
+/*
+SiteGenerator writes a static HTML OID browser describing Registration and
+Registrant instances. The zero value is ready for use, and publishes
+registrants per the NamesOnlyProfile.
+
+The Templates field, if non-nil, must define templates named "index.html"
+and "registration.html", which are executed using *SiteIndex and *SitePage
+instances respectively. See the SiteTemplates function for a means of
+overriding individual parts of the default templates.
+*/
+type SiteGenerator struct {
+	Title     string
+	Privacy   *PrivacyProfile
+	Templates *template.Template
+}
+
+/*
+SiteLink describes a link to a registration page.
+*/
+type SiteLink struct {
+	Label       string
+	DotNotation string
+	Href        string
+}
+
+/*
+SiteField describes a single labeled value.
+*/
+type SiteField struct {
+	Name  string
+	Value string
+}
+
+/*
+SiteContact describes the published information of a Registrant.
+*/
+type SiteContact struct {
+	Role   string
+	Fields []SiteField
+}
+
+/*
+SitePage contains the data used to execute the "registration.html"
+template for a single Registration.
+
+Breadcrumbs leads from the top-most published superior to the page
+itself, inclusive. Left and Right are nil if the registration has no
+published sibling in the respective direction.
+*/
+type SitePage struct {
+	SiteTitle    string
+	Title        string
+	DotNotation  string
+	Registration Registration
+	Breadcrumbs  []SiteLink
+	Children     []SiteLink
+	Left, Right  *SiteLink
+	Details      []SiteField
+	Contacts     []SiteContact
+}
+
+/*
+SiteIndex contains the data used to execute the "index.html" template.
+*/
+type SiteIndex struct {
+	SiteTitle string
+	Roots     []SiteLink
+	Count     int
+}
+
+/*
+SiteSearchEntry describes a single registration within the search index
+(search.json) written by the SiteGenerator type.
+*/
+type SiteSearchEntry struct {
+	DotNotation  string `json:"dotNotation"`
+	Label        string `json:"label"`
+	Identifier   string `json:"identifier,omitempty"`
+	ASN1Notation string `json:"asn1Notation,omitempty"`
+	Description  string `json:"description,omitempty"`
+	Href         string `json:"href"`
+}
+
+/*
+Generate writes the site describing the input Registrations and Registrants
+within the input directory, which is created if necessary. One page is
+written per registration, named by its dotNotation (e.g.: "1.3.6.html"),
+alongside "index.html" and "search.json".
+
+The position of each registration is determined as described for the
+TreeRenderer type. Registrations whose dotNotation is malformed (e.g.:
+"1.3.x") are not published. The registrants of each registration are resolved
+using its COMBINED registrant values, and by matching its dedicated
+firstAuthority, currentAuthority and sponsor DN values with those of the
+input Registrants.
+
+An error is returned if a template cannot be executed, or if writing
+fails.
+*/
+func (r SiteGenerator) Generate(dir string, regs Registrations, rants Registrants) (err error) {
+	tmpl := r.Templates
+	if tmpl == nil {
+		tmpl = SiteTemplates()
+	}
+
+	privacy := NamesOnlyProfile
+	if r.Privacy != nil {
+		privacy = *r.Privacy
+	}
+
+	title := r.Title
+	if len(title) == 0 {
+		title = `OID Registry`
+	}
+
+	var roots []*treeNode
+	if roots, err = (TreeRenderer{}).hierarchy(siteRegistrations(regs, privacy)); err != nil {
+		return
+	} else if err = os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+
+	// Index the published registrations by DN, the superior of
+	// each, and all registrants by DN.
+	byDN := make(map[string]*treeNode)
+	sups := make(map[*treeNode]*treeNode)
+	walkTree(roots, func(sup, n *treeNode) {
+		sups[n] = sup
+		if dn := n.reg.DN(); len(dn) > 0 {
+			byDN[normalizeDN(dn)] = n
+		}
+	})
+	rantsByDN := make(map[string]Registrant)
+	for i := 0; i < len(rants); i++ {
+		if !isNilRegistrant(rants[i]) && len(rants[i].DN()) > 0 {
+			rantsByDN[normalizeDN(rants[i].DN())] = rants[i]
+		}
+	}
+
+	var search []SiteSearchEntry
+	walkTree(roots, func(sup, n *treeNode) {
+		if err != nil {
+			return
+		}
+
+		page := &SitePage{
+			SiteTitle:    title,
+			Title:        treeLabel(n),
+			DotNotation:  n.oid,
+			Registration: n.reg,
+			Details:      siteDetailFields(n.reg),
+			Contacts:     siteContacts(n.reg, rantsByDN, privacy),
+		}
+		for anc := n; anc != nil; anc = sups[anc] {
+			page.Breadcrumbs = append([]SiteLink{siteLink(anc)}, page.Breadcrumbs...)
+		}
+		for _, kid := range n.kids {
+			page.Children = append(page.Children, siteLink(kid))
+		}
+		sibs := roots
+		if sup != nil {
+			sibs = sup.kids
+		}
+		page.Left = siteSibling(n, n.reg.LeftArc(), -1, sibs, byDN)
+		page.Right = siteSibling(n, n.reg.RightArc(), 1, sibs, byDN)
+
+		err = writeSiteFile(filepath.Join(dir, siteHref(n.oid)), func(w io.Writer) error {
+			return tmpl.ExecuteTemplate(w, `registration.html`, page)
+		})
+
+		search = append(search, SiteSearchEntry{
+			DotNotation:  n.oid,
+			Label:        page.Title,
+			Identifier:   n.reg.Identifier(),
+			ASN1Notation: n.reg.ASN1Notation(),
+			Description:  n.reg.Description(),
+			Href:         siteHref(n.oid),
+		})
+	})
+	if err != nil {
+		return
+	}
+
+	index := &SiteIndex{SiteTitle: title, Count: len(search)}
+	for _, n := range roots {
+		index.Roots = append(index.Roots, siteLink(n))
+	}
+	if err = writeSiteFile(filepath.Join(dir, `index.html`), func(w io.Writer) error {
+		return tmpl.ExecuteTemplate(w, `index.html`, index)
+	}); err != nil {
+		return
+	}
+
+	if search == nil {
+		search = []SiteSearchEntry{}
+	}
+
+	return writeSiteFile(filepath.Join(dir, `search.json`), func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(search)
+	})
+}
+
+/*
+siteRegistrations returns the input Registrations, less those withheld
+per the input PrivacyProfile, and those whose dotNotation is malformed.
+The latter are omitted lest their page names escape the site directory.
+*/
+func siteRegistrations(regs Registrations, privacy PrivacyProfile) (pub Registrations) {
+	var withheld []string
+	for i := 0; i < len(regs); i++ {
+		if oid := treeOID(regs[i]); len(oid) > 0 && !privacy.PublishRestricted &&
+			(eq(regs[i].Status(), `private`) || len(regs[i].DiscloseTo()) > 0) {
+			withheld = append(withheld, oid)
+		}
+	}
+
+	for i := 0; i < len(regs); i++ {
+		oid := treeOID(regs[i])
+		if len(oid) == 0 {
+			continue
+		} else if _, err := splitDotNot(oid); err != nil {
+			continue
+		}
+
+		var skip bool
+		for j := 0; j < len(withheld) && !skip; j++ {
+			skip = oid == withheld[j] || hasPrefix(oid, withheld[j]+`.`)
+		}
+		if !skip {
+			pub = append(pub, regs[i])
+		}
+	}
+
+	return
+}
+
+/*
+siteSibling returns a link to the sibling of the input node described by
+the input leftArc or rightArc DN. If the DN is unset, or does not describe
+a published sibling, the adjacent sibling in the input direction (-1 for
+left, 1 for right) is used instead.
+*/
+func siteSibling(n *treeNode, dn string, dir int, sibs []*treeNode, byDN map[string]*treeNode) *SiteLink {
+	if len(dn) > 0 {
+		if sib, found := byDN[normalizeDN(dn)]; found {
+			for _, s := range sibs {
+				if s == sib && s != n {
+					link := siteLink(sib)
+					return &link
+				}
+			}
+		}
+	}
+
+	for i, s := range sibs {
+		if s == n && i+dir >= 0 && i+dir < len(sibs) {
+			link := siteLink(sibs[i+dir])
+			return &link
+		}
+	}
+
+	return nil
+}
+
+/*
+siteDetailFields returns the published attribute values of the input
+Registration.
+*/
+func siteDetailFields(reg Registration) (fields []SiteField) {
+	m := reg.Unmarshal()
+	for _, at := range siteDetails {
+		for _, v := range m[at] {
+			fields = append(fields, SiteField{Name: at, Value: v})
+		}
+	}
+
+	return
+}
+
+/*
+siteContacts returns the published contact blocks of the registrants of
+the input Registration.
+*/
+func siteContacts(reg Registration, rantsByDN map[string]Registrant, privacy PrivacyProfile) (contacts []SiteContact) {
+	var rants []Registrant
+	for _, rant := range []Registrant{
+		reg.CombinedFirstAuthority(),
+		reg.CombinedCurrentAuthority(),
+		reg.CombinedSponsor(),
+	} {
+		if !isNilRegistrant(rant) {
+			rants = append(rants, rant)
+		}
+	}
+	for _, dns := range [][]string{reg.FirstAuthority(), reg.CurrentAuthority(), reg.Sponsor()} {
+		for _, dn := range dns {
+			if rant, found := rantsByDN[normalizeDN(dn)]; found {
+				rants = append(rants, rant)
+			}
+		}
+	}
+
+	for _, rant := range rants {
+		c := SiteContact{Role: siteLabels[rant.Type()]}
+		m := rant.Unmarshal()
+		for _, f := range SiteFields {
+			if !strInSlice(f, privacy.Fields) {
+				continue
+			}
+			for _, v := range m[rant.Type()+f] {
+				c.Fields = append(c.Fields, SiteField{Name: siteLabels[f], Value: v})
+			}
+		}
+		if len(c.Fields) > 0 {
+			contacts = append(contacts, c)
+		}
+	}
+
+	return
+}
+
+/*
+siteLink returns a link to the page of the input node.
+*/
+func siteLink(n *treeNode) SiteLink {
+	return SiteLink{Label: treeLabel(n), DotNotation: n.oid, Href: siteHref(n.oid)}
+}
+
+/*
+siteHref returns the file name of the page of the input dotNotation.
+*/
+func siteHref(oid string) string {
+	return oid + `.html`
+}
+
+/*
+writeSiteFile creates the named file, and writes its content using the
+input function.
+*/
+func writeSiteFile(name string, fn func(io.Writer) error) (err error) {
+	var f *os.File
+	if f, err = os.Create(name); err != nil {
+		return
+	}
+
+	err = fn(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	return
+}
+
+/*
+SiteTemplates returns a freshly parsed instance of the default templates
+used by the SiteGenerator type. Individual parts may be overridden by
+parsing replacement definitions into the return value, e.g.:
+
+	t := template.Must(SiteTemplates().Parse(`{{define "footer"}}...{{end}}`))
+
+The defined templates are "index.html", "registration.html", "head",
+"footer", "contact" and "style".
+*/
+func SiteTemplates() *template.Template {
+	return template.Must(template.New(`site`).Parse(siteTemplates))
+}
+
+const siteTemplates = `
+{{- define "style"}}
+body { font-family: sans-serif; max-width: 60em; margin: 2em auto; padding: 0 1em; color: #222; }
+nav.crumbs a, nav.siblings a { text-decoration: none; }
+table { border-collapse: collapse; }
+th, td { text-align: left; padding: .2em .8em .2em 0; vertical-align: top; }
+th { font-weight: normal; color: #666; }
+code { font-size: 1.1em; }
+.contact { border: 1px solid #ddd; padding: .5em 1em; margin: .5em 0; }
+nav.siblings { display: flex; justify-content: space-between; margin-top: 2em; }
+{{- end}}
+
+{{- define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<style>{{template "style"}}</style>
+</head>
+<body>
+{{- end}}
+
+{{- define "footer"}}
+<footer><p><a href="index.html">Index</a></p></footer>
+</body>
+</html>
+{{- end}}
+
+{{- define "contact"}}
+<div class="contact">
+<h3>{{.Role}}</h3>
+<table>
+{{- range .Fields}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+</div>
+{{- end}}
+
+{{- define "index.html"}}{{template "head" .SiteTitle}}
+<h1>{{.SiteTitle}}</h1>
+<p>{{.Count}} registrations.</p>
+<p><input id="q" type="search" placeholder="Search by OID, name or description" size="50"></p>
+<ul id="results"></ul>
+<h2>Registrations</h2>
+<ul>
+{{- range .Roots}}
+<li><a href="{{.Href}}">{{.Label}}</a> <code>{{.DotNotation}}</code></li>
+{{- end}}
+</ul>
+<script>
+(function() {
+	var index = [];
+	fetch("search.json").then(function(r) { return r.json(); }).then(function(d) { index = d; });
+	var q = document.getElementById("q"), out = document.getElementById("results");
+	q.addEventListener("input", function() {
+		var s = q.value.toLowerCase();
+		out.textContent = "";
+		if (s.length < 2) { return; }
+		index.filter(function(e) {
+			return [e.dotNotation, e.label, e.identifier || "", e.asn1Notation || "",
+				e.description || ""].join(" ").toLowerCase().indexOf(s) >= 0;
+		}).slice(0, 50).forEach(function(e) {
+			var li = document.createElement("li"), a = document.createElement("a");
+			a.href = e.href;
+			a.textContent = e.label + " (" + e.dotNotation + ")";
+			li.appendChild(a);
+			out.appendChild(li);
+		});
+	});
+})();
+</script>
+{{- template "footer"}}
+{{- end}}
+
+{{- define "registration.html"}}{{template "head" (print .Title " - " .SiteTitle)}}
+<nav class="crumbs">
+{{- range $i, $c := .Breadcrumbs}}{{if $i}} / {{end}}
+{{- if eq $c.DotNotation $.DotNotation}}<strong>{{$c.Label}}</strong>{{else}}<a href="{{$c.Href}}">{{$c.Label}}</a>{{end}}
+{{- end}}
+</nav>
+<h1>{{.Title}}</h1>
+<p><code>{{.DotNotation}}</code></p>
+{{- with .Details}}
+<table>
+{{- range .}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- with .Contacts}}
+<h2>Registrants</h2>
+{{- range .}}{{template "contact" .}}{{end}}
+{{- end}}
+{{- with .Children}}
+<h2>Subordinate Registrations</h2>
+<ul>
+{{- range .}}
+<li><a href="{{.Href}}">{{.Label}}</a> <code>{{.DotNotation}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+<nav class="siblings">
+<span>{{with .Left}}&larr; <a href="{{.Href}}">{{.Label}}</a>{{end}}</span>
+<span>{{with .Right}}<a href="{{.Href}}">{{.Label}}</a> &rarr;{{end}}</span>
+</nav>
+{{- template "footer"}}
+{{- end}}
+`
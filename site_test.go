@@ -0,0 +1,50 @@
+package dcxl
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/*
+TestSiteGenerator_malformedOID verifies that registrations bearing a
+malformed dotNotation are not published, and cannot cause pages to be
+written beyond the site directory.
+*/
+func TestSiteGenerator_malformedOID(t *testing.T) {
+	parent := t.TempDir()
+	dir := filepath.Join(parent, `site`)
+
+	root := &RootArc{}
+	root.SetN(`../../root`)
+
+	regs := Registrations{root}
+	for _, dot := range []string{`1.3`, `../escaped`, `1.3/../../x`, `1.3.x`} {
+		sub := &SubArc{}
+		sub.SetDotNotation(dot)
+		regs = append(regs, sub)
+	}
+
+	if err := (SiteGenerator{Privacy: &PrivacyProfile{PublishRestricted: true}}).Generate(dir, regs, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, d := range []string{parent, dir} {
+		files, err := os.ReadDir(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var names []string
+		for _, f := range files {
+			names = append(names, f.Name())
+		}
+		want := `site`
+		if d == dir {
+			want = `1.3.html index.html search.json`
+		}
+		if got := strings.Join(names, ` `); got != want {
+			t.Errorf("%s: expected '%s', got '%s'", d, want, got)
+		}
+	}
+}
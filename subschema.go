@@ -0,0 +1,730 @@
+package dcxl
+
+/*
+subschema.go contains the RFC 4512 definition parser, which produces
+*Schema instances from the contents of a subschema subentry, as well as
+the schema-driven entry validator.
+*/
+
+import "sort"
+
+/*
+ParseSubschema returns a *Schema instance alongside an error following an
+attempt to parse the 'attributeTypes' and 'objectClasses' values within
+the input map, such as the attributes of a subschema subentry (s. 4.2 of
+RFC 4512) retrieved from a live DSA. Attribute type names are matched
+in case-insensitive fashion.
+
+The 'olcAttributeTypes' and 'olcObjectClasses' values of an OpenLDAP
+cn=config schema entry are also honored, with any '{N}' ordering prefixes
+removed.
+*/
+func ParseSubschema(m map[string][]string) (s *Schema, err error) {
+	s = new(Schema)
+	for k, v := range m {
+		switch lc(k) {
+		case `attributetypes`, `olcattributetypes`:
+			for i := 0; i < len(v); i++ {
+				var a AttributeType
+				if a, err = ParseAttributeType(v[i]); err != nil {
+					return
+				}
+				s.AttributeTypes = append(s.AttributeTypes, a)
+			}
+		case `objectclasses`, `olcobjectclasses`:
+			for i := 0; i < len(v); i++ {
+				var o ObjectClass
+				if o, err = ParseObjectClass(v[i]); err != nil {
+					return
+				}
+				s.ObjectClasses = append(s.ObjectClasses, o)
+			}
+		}
+	}
+
+	return
+}
+
+/*
+ParseAttributeType returns an AttributeType alongside an error following
+an attempt to parse the input RFC 4512 AttributeTypeDescription.
+*/
+func ParseAttributeType(def string) (a AttributeType, err error) {
+	var p *defParser
+	if p, err = newDefParser(def); err != nil {
+		return
+	}
+
+	a.OID = p.oid
+	for err == nil && !p.done() {
+		kw := p.next()
+		switch uc(kw.val) {
+		case `NAME`:
+			a.Names, err = p.qdescrs()
+		case `DESC`:
+			a.Desc, err = p.qdstring()
+		case `OBSOLETE`:
+			a.Obsolete = true
+		case `SUP`:
+			a.Sup, err = p.word()
+		case `EQUALITY`:
+			a.Equality, err = p.word()
+		case `ORDERING`:
+			a.Ordering, err = p.word()
+		case `SUBSTR`:
+			a.Substr, err = p.word()
+		case `SYNTAX`:
+			var noidlen string
+			if noidlen, err = p.word(); err == nil {
+				a.Syntax, a.SyntaxLen, err = splitNOIDLen(noidlen)
+			}
+		case `SINGLE-VALUE`:
+			a.SingleValue = true
+		case `COLLECTIVE`:
+			a.Collective = true
+		case `NO-USER-MODIFICATION`:
+			a.NoUserModification = true
+		case `USAGE`:
+			a.Usage, err = p.word()
+		default:
+			a.Extensions, err = p.extension(kw, a.Extensions)
+		}
+	}
+
+	if err != nil {
+		err = errorf("%v: attributeType '%s'", err, a.OID)
+	}
+
+	return
+}
+
+/*
+ParseObjectClass returns an ObjectClass alongside an error following an
+attempt to parse the input RFC 4512 ObjectClassDescription.
+*/
+func ParseObjectClass(def string) (o ObjectClass, err error) {
+	var p *defParser
+	if p, err = newDefParser(def); err != nil {
+		return
+	}
+
+	o.OID = p.oid
+	for err == nil && !p.done() {
+		kw := p.next()
+		switch uc(kw.val) {
+		case `NAME`:
+			o.Names, err = p.qdescrs()
+		case `DESC`:
+			o.Desc, err = p.qdstring()
+		case `OBSOLETE`:
+			o.Obsolete = true
+		case `SUP`:
+			o.Sup, err = p.oids()
+		case `ABSTRACT`, `STRUCTURAL`, `AUXILIARY`:
+			o.Kind = uc(kw.val)
+		case `MUST`:
+			o.Must, err = p.oids()
+		case `MAY`:
+			o.May, err = p.oids()
+		default:
+			o.Extensions, err = p.extension(kw, o.Extensions)
+		}
+	}
+
+	if err != nil {
+		err = errorf("%v: objectClass '%s'", err, o.OID)
+	}
+
+	return
+}
+
+/*
+defToken is a single lexical token of an RFC 4512 definition.
+*/
+type defToken struct {
+	val    string
+	quoted bool
+}
+
+/*
+defParser is a simple recursive descent parser for the RFC 4512
+definition productions shared by attributeTypes and objectClasses.
+*/
+type defParser struct {
+	oid  string
+	toks []defToken
+	pos  int
+}
+
+/*
+newDefParser tokenizes the input definition, verifies its enclosing
+parentheses and returns a *defParser positioned after the numericoid.
+*/
+func newDefParser(def string) (p *defParser, err error) {
+	def = trimS(def)
+
+	// Remove any OpenLDAP cn=config ordering prefix, e.g.: '{12}'
+	if hasPrefix(def, `{`) {
+		if idx := idxRune(def, '}'); idx > 0 && isNumber(def[1:idx]) {
+			def = trimS(def[idx+1:])
+		}
+	}
+
+	p = new(defParser)
+	if p.toks, err = tokenizeDef(def); err != nil {
+		return
+	}
+
+	n := len(p.toks)
+	if n < 3 || p.toks[0].val != `(` || p.toks[0].quoted ||
+		p.toks[n-1].val != `)` || p.toks[n-1].quoted {
+		err = errorf("Definition must be enclosed within parentheses: %s", def)
+		return
+	}
+
+	p.toks = p.toks[1 : n-1]
+	if p.oid = p.toks[0].val; p.toks[0].quoted || !isNumericOID(p.oid) {
+		err = errorf("Invalid numericoid '%s'", p.oid)
+	}
+	p.pos = 1
+
+	return
+}
+
+func (r *defParser) done() bool {
+	return r.pos >= len(r.toks)
+}
+
+func (r *defParser) next() (t defToken) {
+	if !r.done() {
+		t = r.toks[r.pos]
+		r.pos++
+	}
+
+	return
+}
+
+func (r *defParser) peek() (t defToken) {
+	if !r.done() {
+		t = r.toks[r.pos]
+	}
+
+	return
+}
+
+/*
+word returns the next unquoted token, such as an oid or noidlen.
+*/
+func (r *defParser) word() (string, error) {
+	t := r.next()
+	if t.quoted || len(t.val) == 0 || t.val == `(` || t.val == `)` || t.val == `$` {
+		return ``, errorf("Expected oid, found '%s'", t.val)
+	}
+
+	return t.val, nil
+}
+
+/*
+qdstring returns the next quoted token.
+*/
+func (r *defParser) qdstring() (string, error) {
+	t := r.next()
+	if !t.quoted {
+		return ``, errorf("Expected quoted string, found '%s'", t.val)
+	}
+
+	return t.val, nil
+}
+
+/*
+qdescrs returns one or more quoted tokens, per the qdescrs and qdstrings
+productions of RFC 4512.
+*/
+func (r *defParser) qdescrs() (vals []string, err error) {
+	if t := r.peek(); t.quoted {
+		r.pos++
+		vals = []string{t.val}
+		return
+	} else if t.val != `(` {
+		err = errorf("Expected quoted string or '(', found '%s'", t.val)
+		return
+	}
+
+	r.pos++
+	for !r.done() {
+		t := r.next()
+		if !t.quoted && t.val == `)` {
+			return
+		} else if !t.quoted {
+			err = errorf("Expected quoted string, found '%s'", t.val)
+			return
+		}
+		vals = append(vals, t.val)
+	}
+	err = errorf("Unterminated list")
+
+	return
+}
+
+/*
+oids returns one or more '$' delimited oids, per the oids production of
+RFC 4512.
+*/
+func (r *defParser) oids() (vals []string, err error) {
+	if t := r.peek(); t.quoted {
+		err = errorf("Expected oid or '(', found quoted string")
+		return
+	} else if t.val != `(` {
+		var w string
+		if w, err = r.word(); err == nil {
+			vals = []string{w}
+		}
+		return
+	}
+
+	r.pos++
+	for !r.done() {
+		var w string
+		if w, err = r.word(); err != nil {
+			return
+		}
+		vals = append(vals, w)
+
+		switch t := r.next(); {
+		case t.quoted:
+			err = errorf("Unexpected quoted string in oid list")
+			return
+		case t.val == `)`:
+			return
+		case t.val != `$`:
+			err = errorf("Expected '$' or ')', found '%s'", t.val)
+			return
+		}
+	}
+	err = errorf("Unterminated list")
+
+	return
+}
+
+/*
+extension parses the values of the input 'X-' extension keyword, adding
+them to the input map, which is returned. An error is returned for any
+other keyword.
+*/
+func (r *defParser) extension(kw defToken, x map[string][]string) (map[string][]string, error) {
+	if kw.quoted || !hasPrefix(uc(kw.val), `X-`) {
+		return x, errorf("Unexpected token '%s'", kw.val)
+	}
+
+	vals, err := r.qdescrs()
+	if err == nil {
+		if x == nil {
+			x = make(map[string][]string)
+		}
+		x[kw.val] = append(x[kw.val], vals...)
+	}
+
+	return x, err
+}
+
+/*
+tokenizeDef splits the input RFC 4512 definition into tokens. Quoted
+strings are unquoted, with their `\27` and `\5C` escapes resolved.
+*/
+func tokenizeDef(def string) (toks []defToken, err error) {
+	for i := 0; i < len(def); {
+		switch c := def[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == '$':
+			toks = append(toks, defToken{val: string(c)})
+			i++
+		case c == '\'':
+			end := indexOf(def[i+1:], `'`)
+			if end == -1 {
+				err = errorf("Unterminated quoted string")
+				return
+			}
+			toks = append(toks, defToken{val: unescapeQDString(def[i+1 : i+1+end]), quoted: true})
+			i += end + 2
+		default:
+			j := i
+			for j < len(def) && !contains(" \t\n\r()$'", string(def[j])) {
+				j++
+			}
+			toks = append(toks, defToken{val: def[i:j]})
+			i = j
+		}
+	}
+
+	return
+}
+
+/*
+unescapeQDString is the inverse of escapeQDString.
+*/
+func unescapeQDString(s string) string {
+	for _, esc := range [][2]string{{`\27`, `'`}, {`\5C`, `\`}, {`\5c`, `\`}} {
+		s = replaceAll(s, esc[0], esc[1])
+	}
+
+	return s
+}
+
+/*
+splitNOIDLen splits the input noidlen value (e.g.: '1.3.6.1.4.1.1466.115.121.1.15{64}')
+into its numericoid and length components.
+*/
+func splitNOIDLen(noidlen string) (oid string, l uint, err error) {
+	oid = noidlen
+	if idx := idxRune(noidlen, '{'); idx != -1 {
+		if !hasSuffix(noidlen, `}`) || !isNumber(noidlen[idx+1:len(noidlen)-1]) {
+			err = errorf("Invalid noidlen '%s'", noidlen)
+			return
+		}
+		n, _ := atoi(noidlen[idx+1 : len(noidlen)-1])
+		oid, l = noidlen[:idx], uint(n)
+	}
+
+	if !isNumericOID(oid) {
+		err = errorf("Invalid syntax numericoid '%s'", oid)
+	}
+
+	return
+}
+
+/*
+isNumericOID returns a boolean value indicative of whether the input
+string is a numericoid, per RFC 4512.
+*/
+func isNumericOID(s string) bool {
+	arcs := split(s, `.`)
+	if len(arcs) < 2 {
+		return false
+	}
+
+	for i := 0; i < len(arcs); i++ {
+		if !isNumber(arcs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+SchemaViolation describes a single discrepancy between an entry and a
+*Schema instance, as reported by the Validate method.
+*/
+type SchemaViolation struct {
+	Attr    string
+	Problem string
+}
+
+/*
+String returns a human-readable representation of the receiver.
+*/
+func (r SchemaViolation) String() string {
+	return r.Attr + `: ` + r.Problem
+}
+
+/*
+Validate returns slices of SchemaViolation following an attempt to verify
+the input entry against the receiver. The input value may be a map[string][]string
+instance, or any type that extends the Unmarshal method (e.g.: Registration
+and Registrant instances). A zero length return value indicates the entry
+is valid.
+
+The following are verified:
+
+  - each objectClass is defined within the receiver
+  - all attribute types required by each objectClass (and its superiors)
+    are present
+  - single-valued attribute types bear no more than one (1) value
+  - INTEGER, GeneralizedTime, Boolean, DN and OID values are well-formed,
+    per the matching rule of each attribute type (see the MatchFilter
+    function)
+  - each attribute type is defined within the receiver, and permitted by
+    at least one objectClass
+
+The 'top' objectClass need not be defined within the receiver. Attribute
+types permitted by an objectClass, but not defined within the receiver,
+are not flagged (e.g.: 'description' when validating against the output
+of DraftSchema).
+*/
+func (r *Schema) Validate(x any) (v []SchemaViolation) {
+	errs := r.validate(x)
+	for i := 0; i < len(errs); i++ {
+		v = append(v, SchemaViolation{Attr: errs[i].Attr, Problem: errs[i].Msg})
+	}
+
+	return
+}
+
+/*
+Check returns an instance of ValidationErrors describing the problems
+found within the input entry, or nil if none were found. The verification
+performed, and the input values accepted, are as described for Validate.
+
+Each *ValidationError references the section of the ID defining its
+attribute type where known, and wraps RegistrationValidityErr,
+RegistrantValidityErr or DUAConfigValidityErr depending upon the
+objectClass values of the entry. Undefined attribute types are reported
+with SeverityWarning; all other problems bear SeverityError.
+
+For example:
+
+	if err := DraftSchema().Check(reg); err != nil {
+		var verr ValidationErrors
+		if errors.As(err, &verr) {
+			for _, bad := range verr.Severity(SeverityError) {
+				fmt.Println(bad.Attr, bad.Section)
+			}
+		}
+	}
+*/
+func (r *Schema) Check(x any) error {
+	return r.validate(x).Err()
+}
+
+/*
+validate returns an instance of ValidationErrors describing the problems
+found within the input entry.
+*/
+func (r *Schema) validate(x any) (v ValidationErrors) {
+	var m map[string][]string
+	switch tv := x.(type) {
+	case map[string][]string:
+		m = tv
+	case interface{ Unmarshal() map[string][]string }:
+		m = tv.Unmarshal()
+	default:
+		return ValidationErrors{{
+			Attr: `objectClass`,
+			Msg:  sprintf("%v: %T", UnsupportedInputTypeErr, x),
+			Err:  UnsupportedInputTypeErr,
+		}}
+	}
+
+	ocs := mapValues(m, `objectClass`)
+	sentinel := validitySentinel(ocs)
+	violation := func(kind ViolationKind, sev Severity, at, val, p string, x ...any) {
+		v = append(v, &ValidationError{
+			Kind:     kind,
+			Attr:     at,
+			Value:    val,
+			Section:  r.section(at),
+			Severity: sev,
+			Msg:      sprintf(p, x...),
+			Err:      sentinel,
+		})
+	}
+
+	must := make(map[string]bool)
+	may := make(map[string]bool)
+	if len(ocs) == 0 {
+		violation(ObjectClassViolation, SeverityError, `objectClass`, ``, `no objectClass values`)
+	}
+
+	for i := 0; i < len(ocs); i++ {
+		if !r.collectClass(ocs[i], must, may, make(map[string]bool)) {
+			violation(ObjectClassViolation, SeverityError, `objectClass`, ``, "undefined objectClass '%s'", ocs[i])
+		}
+	}
+
+	ats := make([]string, 0, len(m))
+	for at := range m {
+		if !eq(at, `objectClass`) {
+			ats = append(ats, at)
+		}
+	}
+	sort.Strings(ats)
+
+	present := make(map[string]bool)
+	for _, at := range ats {
+		vals := m[at]
+
+		base := at
+		if idx := idxRune(at, ';'); idx != -1 {
+			base = at[:idx] // discard any attribute options
+		}
+
+		def, defined := r.AttributeType(base)
+		key := lc(canonicalAttr(base))
+		if defined {
+			key = lc(def.OID)
+		}
+		present[key] = true
+
+		switch {
+		case len(vals) == 0:
+			violation(NoValuesViolation, SeverityError, at, ``, `no values`)
+		case defined && def.SingleValue && len(vals) > 1:
+			violation(SingleValueViolation, SeverityError, at, join(vals, `, `), "SINGLE-VALUE attribute type bears %d values", len(vals))
+		}
+
+		for i := 0; i < len(vals); i++ {
+			if !validSyntax(base, vals[i]) {
+				violation(ValueSyntaxViolation, SeverityError, at, vals[i], `value is malformed`)
+			}
+		}
+
+		if !must[key] && !may[key] {
+			if defined {
+				violation(NotPermittedViolation, SeverityError, at, ``, `not permitted by any objectClass`)
+			} else {
+				violation(UndefinedAttributeViolation, SeverityWarning, at, ``, `undefined attribute type`)
+			}
+		}
+	}
+
+	var req []string
+	for key := range must {
+		if !present[key] {
+			req = append(req, key)
+		}
+	}
+	sort.Strings(req)
+	for i := 0; i < len(req); i++ {
+		name := req[i]
+		if def, ok := r.AttributeType(name); ok {
+			name = def.Name()
+		}
+		violation(MissingAttributeViolation, SeverityError, name, ``, `required attribute type missing`)
+	}
+
+	return
+}
+
+/*
+validSyntax returns a boolean value indicative of whether the input value
+conforms to the syntax implied by the matching rule of the input attribute
+type. Values of attribute types bearing no such syntax are always valid.
+*/
+func validSyntax(at, val string) bool {
+	switch matchingRuleOf(at) {
+	case integerMatch:
+		if hasPrefix(val, `-`) {
+			val = val[1:]
+		}
+		return isNumber(val)
+	case timeMatch:
+		_, ok := genTimeToTime(val)
+		return ok
+	case booleanMatch:
+		return eq(val, `TRUE`) || eq(val, `FALSE`)
+	case dnMatch:
+		rdns := splitDN(val)
+		for i := 0; i < len(rdns); i++ {
+			if idxRune(rdns[i], '=') < 1 {
+				return false
+			}
+		}
+		return len(rdns) > 0
+	case oidMatch:
+		return isNumericOID(val) || isDescr(val)
+	}
+
+	return true
+}
+
+/*
+isDescr returns a boolean value indicative of whether the input string is
+a descr, per RFC 4512.
+*/
+func isDescr(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && ('0' <= c && c <= '9' || c == '-'):
+		default:
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
+
+/*
+draftArc contains the OID prefix of the attribute type and objectClass
+definitions of the ID, the remainder of which is the number of the
+section defining each (e.g.: 2.1.14 for isLeafNode).
+*/
+const draftArc = `1.3.6.1.4.1.56521.101.`
+
+/*
+section returns the section of the ID defining the named attribute type,
+or a zero string if the receiver does not define it within the ID's arc.
+*/
+func (r *Schema) section(at string) (sect string) {
+	if def, found := r.AttributeType(at); found && hasPrefix(def.OID, draftArc) {
+		sect = def.OID[len(draftArc):]
+	}
+
+	return
+}
+
+/*
+validitySentinel returns the predefined error instance describing the
+invalidity of an entry bearing the input objectClass values. Registration
+classes take precedence over the registrant class, as COMBINED entries
+bear both, while the DUAConfig class is considered last. Entries bearing
+none of these are assumed to be registrations.
+*/
+func validitySentinel(ocs []string) error {
+	switch {
+	case strInSlice(RootArc{}.ObjectClass(), ocs),
+		strInSlice(SubArc{}.ObjectClass(), ocs):
+		return RegistrationValidityErr
+	case strInSlice(Sponsor{}.ObjectClass(), ocs):
+		return RegistrantValidityErr
+	case strInSlice(DUAConfig{}.ObjectClass(), ocs):
+		return DUAConfigValidityErr
+	}
+
+	return RegistrationValidityErr
+}
+
+/*
+collectClass records the MUST and MAY attribute types of the named
+objectClass, and those of its superiors, within the input maps, keyed
+by lowercase OID (or lowercase name for undefined attribute types).
+A boolean value indicative of whether the objectClass was found is
+returned.
+*/
+func (r *Schema) collectClass(name string, must, may, seen map[string]bool) bool {
+	if seen[lc(name)] {
+		return true
+	}
+	seen[lc(name)] = true
+
+	oc, found := r.ObjectClass(name)
+	if !found {
+		if eq(name, `top`) || name == `2.5.6.0` {
+			return true // top requires nothing beyond objectClass
+		}
+		return false
+	}
+
+	key := func(at string) string {
+		if def, ok := r.AttributeType(at); ok {
+			return lc(def.OID)
+		}
+		return lc(canonicalAttr(at))
+	}
+
+	for i := 0; i < len(oc.Must); i++ {
+		if !eq(oc.Must[i], `objectClass`) {
+			must[key(oc.Must[i])] = true
+		}
+	}
+	for i := 0; i < len(oc.May); i++ {
+		may[key(oc.May[i])] = true
+	}
+	for i := 0; i < len(oc.Sup); i++ {
+		r.collectClass(oc.Sup[i], must, may, seen)
+	}
+
+	return true
+}
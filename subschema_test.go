@@ -0,0 +1,71 @@
+package dcxl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+/*
+TestSchema_Check_syntax verifies that malformed values are reported with
+the ValueSyntaxViolation kind, bearing the offending value, in attribute
+type order.
+*/
+func TestSchema_Check_syntax(t *testing.T) {
+	err := DraftSchema().Check(map[string][]string{
+		`objectClass`:         {`top`, `x660SubArc`},
+		`n`:                   {`-x`},
+		`dotNotation`:         {`1.3.x`},
+		`isFrozen`:            {`maybe`},
+		`isLeafNode`:          {`FALSE`},
+		`registrationCreated`: {`notatime`},
+		`registrationRange`:   {`-1`},
+		`supArc`:              {`n=3,n=1,ou=Registrations,o=rA`},
+		`leftArc`:             {`n=2,,o=rA`},
+	})
+
+	var verrs ValidationErrors
+	if !errors.As(err, &verrs) {
+		t.Fatalf("expected ValidationErrors, got %v", err)
+	}
+
+	var got []string
+	for _, verr := range verrs {
+		if verr.Kind != ValueSyntaxViolation {
+			t.Errorf("unexpected violation: %v", verr)
+		} else if !errors.Is(verr, RegistrationValidityErr) {
+			t.Errorf("expected %v to wrap RegistrationValidityErr", verr)
+		}
+		got = append(got, verr.Attr+`=`+verr.Value)
+	}
+
+	want := `dotNotation=1.3.x isFrozen=maybe leftArc=n=2,,o=rA n=-x registrationCreated=notatime`
+	if g := strings.Join(got, ` `); g != want {
+		t.Errorf("expected '%s', got '%s'", want, g)
+	}
+
+	if s := verrs[0].Error(); s != `dotNotation: value is malformed: '1.3.x' (s. 2.1.2)` {
+		t.Errorf("unexpected message '%s'", s)
+	}
+}
+
+/*
+TestValiditySentinel verifies that registration and registrant classes
+take precedence over the DUAConfig class.
+*/
+func TestValiditySentinel(t *testing.T) {
+	for _, tc := range []struct {
+		ocs  []string
+		want error
+	}{
+		{[]string{`x660SubArc`, `x660Registrant`}, RegistrationValidityErr},
+		{[]string{`x660DUAConfig`, `x660RootArc`}, RegistrationValidityErr},
+		{[]string{`x660DUAConfig`, `x660Registrant`}, RegistrantValidityErr},
+		{[]string{`x660DUAConfig`}, DUAConfigValidityErr},
+		{nil, RegistrationValidityErr},
+	} {
+		if got := validitySentinel(tc.ocs); got != tc.want {
+			t.Errorf("%v: expected %v, got %v", tc.ocs, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,459 @@
+package dcxl
+
+/*
+tree.go contains the tree renderer, which draws the hierarchy of a set
+of Registration instances as a Graphviz DOT digraph, a Mermaid flowchart,
+an indented text tree or nested JSON objects.
+*/
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+/*
+TreeFormat describes a diagram format supported by the TreeRenderer type.
+*/
+type TreeFormat uint8
+
+const (
+	TextTreeFormat TreeFormat = iota // indented text tree
+	DOTFormat                        // Graphviz DOT digraph
+	MermaidFormat                    // Mermaid flowchart
+	JSONTreeFormat                   // JSON array of nested objects
+)
+
+/*
+String returns the string name of the receiver.
+*/
+func (r TreeFormat) String() string {
+	switch r {
+	case TextTreeFormat:
+		return `text`
+	case DOTFormat:
+		return `dot`
+	case MermaidFormat:
+		return `mermaid`
+	case JSONTreeFormat:
+		return `json`
+	}
+
+	return `unknown`
+}
+
+/*
+treeColors contains the fill colors of the node states highlighted by
+the TreeRenderer type. Proprietary registrationStatus values are drawn
+using the color of the "status" state.
+*/
+var treeColors = map[string]string{
+	`obsolete`:    `#bdbdbd`,
+	`deallocated`: `#bdbdbd`,
+	`reserved`:    `#fff59d`,
+	`private`:     `#ce93d8`,
+	`status`:      `#e0e0e0`,
+	`leaf`:        `#a5d6a7`,
+	`frozen`:      `#90caf9`,
+	`range`:       `#ffcc80`,
+}
+
+/*
+TreeRenderer draws the hierarchy of Registration instances upon an
+io.Writer in the specified TreeFormat. Each node is labeled using the
+nameAndNumberForm of its Registration, falling back to the identifier
+and numberForm, or the dotNotation, where unset.
+
+The position of each Registration is determined by its dotNotation (or
+the numberForm of a RootArc). If neither is set, the DN is resolved using
+the *DUAConfig of the Registration, if any; otherwise the Registration is
+not drawn. Registrations whose superior is not drawn are attached to the
+nearest drawn ancestor, or else drawn as top-level nodes.
+
+The Root field, if set, limits the diagram to the subtree of the given
+dotNotation. The Depth field, if greater than zero, limits the number of
+levels drawn, counting the Root (or the root arcs, if Root is unset) as
+the first level. The Filter field, if non-nil, limits the diagram to
+those Registrations matched; see the MatchFilter function for details.
+
+JSON trees are drawn as an array of objects, each bearing the oid, label,
+identifier and DN of a node, alongside its subordinates as children.
+
+If the States field is true, nodes are color-coded (DOT and Mermaid) or
+annotated (text and JSON) according to their registrationStatus, isLeafNode,
+isFrozen and registrationRange values. A registrationStatus other than
+"active" or "in-force" takes precedence over a leaf-node, which takes
+precedence over a frozen registration, which in turn takes precedence
+over a range allocation.
+
+If the ASCII field is true, text trees are drawn using ASCII characters
+rather than Unicode box-drawing characters.
+*/
+type TreeRenderer struct {
+	Format TreeFormat
+	Root   string
+	Depth  int
+	Filter Filter
+	States bool
+	ASCII  bool
+}
+
+/*
+treeNode describes a single node drawn by the TreeRenderer type.
+*/
+type treeNode struct {
+	oid  string
+	reg  Registration
+	kids []*treeNode
+}
+
+/*
+Render draws the input Registrations upon the input io.Writer, returning
+an error if the format is unknown, if the Filter cannot be evaluated, or
+if writing fails.
+*/
+func (r TreeRenderer) Render(w io.Writer, regs Registrations) (err error) {
+	var roots []*treeNode
+	if roots, err = r.hierarchy(regs); err != nil {
+		return
+	}
+
+	ew := &errWriter{w: w}
+	switch r.Format {
+	case TextTreeFormat:
+		r.text(ew, roots, ``, true)
+	case DOTFormat:
+		r.dot(ew, roots)
+	case MermaidFormat:
+		r.mermaid(ew, roots)
+	case JSONTreeFormat:
+		r.json(ew, roots)
+	default:
+		return errorf("Unknown %T '%d'", r.Format, r.Format)
+	}
+
+	return ew.err
+}
+
+/*
+hierarchy returns the top-level nodes of the Registrations selected by
+the receiver, each bearing its drawn subordinates in numeric order.
+*/
+func (r TreeRenderer) hierarchy(regs Registrations) (roots []*treeNode, err error) {
+	var base int
+	if len(r.Root) > 0 {
+		base = len(split(r.Root, `.`)) - 1
+	}
+
+	byOID := make(map[string]*treeNode)
+	var nodes []*treeNode
+	for i := 0; i < len(regs); i++ {
+		oid := treeOID(regs[i])
+		switch {
+		case len(oid) == 0, byOID[oid] != nil:
+			continue
+		case len(r.Root) > 0 && oid != r.Root && !hasPrefix(oid, r.Root+`.`):
+			continue
+		case r.Depth > 0 && len(split(oid, `.`))-base > r.Depth:
+			continue
+		}
+
+		if r.Filter != nil {
+			var ok bool
+			if ok, err = MatchFilter(r.Filter, regs[i]); err != nil {
+				return
+			} else if !ok {
+				continue
+			}
+		}
+
+		byOID[oid] = &treeNode{oid: oid, reg: regs[i]}
+		nodes = append(nodes, byOID[oid])
+	}
+	sort.Slice(nodes, func(i, j int) bool { return oidLess(nodes[i].oid, nodes[j].oid) })
+
+	for _, n := range nodes {
+		var sup *treeNode
+		for anc := n.oid; sup == nil; {
+			idx := lastIndex(anc, `.`)
+			if idx < 0 {
+				break
+			}
+			anc = anc[:idx]
+			sup = byOID[anc]
+		}
+
+		if sup == nil {
+			roots = append(roots, n)
+		} else {
+			sup.kids = append(sup.kids, n)
+		}
+	}
+
+	return
+}
+
+/*
+text writes the input nodes, and their subordinates, as an indented tree
+bearing the input prefix.
+*/
+func (r TreeRenderer) text(ew *errWriter, nodes []*treeNode, prefix string, top bool) {
+	branch, last, pipe := `├── `, `└── `, `│   `
+	if r.ASCII {
+		branch, last, pipe = `|-- `, "`-- ", `|   `
+	}
+
+	for i, n := range nodes {
+		line, next := prefix, prefix
+		switch {
+		case top:
+		case i == len(nodes)-1:
+			line += last
+			next += `    `
+		default:
+			line += branch
+			next += pipe
+		}
+
+		line += treeLabel(n)
+		if states := treeStates(n.reg); r.States && len(states) > 0 {
+			line += ` [` + join(states, `, `) + `]`
+		}
+		ew.line(line)
+		r.text(ew, n.kids, next, false)
+	}
+}
+
+/*
+dot writes the input nodes, and their subordinates, as a Graphviz DOT
+digraph.
+*/
+func (r TreeRenderer) dot(ew *errWriter, roots []*treeNode) {
+	ew.line(`digraph registrations {`)
+	ew.line(`	node [shape=box, style=rounded];`)
+
+	var edges []string
+	walkTree(roots, func(sup, n *treeNode) {
+		attrs := `label=` + dotQuote(treeLabel(n))
+		if class := treeClass(n.reg); r.States && len(class) > 0 {
+			attrs += `, style="rounded,filled", fillcolor="` + treeColors[class] + `"`
+		}
+		ew.line(`	` + dotQuote(n.oid) + ` [` + attrs + `];`)
+		if sup != nil {
+			edges = append(edges, `	`+dotQuote(sup.oid)+` -> `+dotQuote(n.oid)+`;`)
+		}
+	})
+
+	for _, e := range edges {
+		ew.line(e)
+	}
+	ew.line(`}`)
+}
+
+/*
+mermaid writes the input nodes, and their subordinates, as a Mermaid
+flowchart.
+*/
+func (r TreeRenderer) mermaid(ew *errWriter, roots []*treeNode) {
+	ew.line(`flowchart TD`)
+
+	var edges []string
+	classes := make(map[string][]string)
+	walkTree(roots, func(sup, n *treeNode) {
+		id := mermaidID(n.oid)
+		label := replaceAll(treeLabel(n), `"`, `#quot;`)
+		ew.line(`	` + id + `["` + label + `"]`)
+		if sup != nil {
+			edges = append(edges, `	`+mermaidID(sup.oid)+` --> `+id)
+		}
+		if class := treeClass(n.reg); r.States && len(class) > 0 {
+			classes[class] = append(classes[class], id)
+		}
+	})
+
+	for _, e := range edges {
+		ew.line(e)
+	}
+
+	names := make([]string, 0, len(classes))
+	for class := range classes {
+		names = append(names, class)
+	}
+	sort.Strings(names)
+	for _, class := range names {
+		ew.line(`	classDef ` + class + ` fill:` + treeColors[class])
+		ew.line(`	class ` + join(classes[class], `,`) + ` ` + class)
+	}
+}
+
+/*
+treeObject describes a single node drawn by the TreeRenderer type in
+JSONTreeFormat.
+*/
+type treeObject struct {
+	OID        string        `json:"oid"`
+	Label      string        `json:"label"`
+	Identifier string        `json:"identifier,omitempty"`
+	DN         string        `json:"dn,omitempty"`
+	States     []string      `json:"states,omitempty"`
+	Children   []*treeObject `json:"children,omitempty"`
+}
+
+/*
+json writes the input nodes, and their subordinates, as a JSON array of
+nested objects.
+*/
+func (r TreeRenderer) json(ew *errWriter, roots []*treeNode) {
+	var objects func([]*treeNode) []*treeObject
+	objects = func(nodes []*treeNode) (objs []*treeObject) {
+		objs = make([]*treeObject, 0, len(nodes))
+		for _, n := range nodes {
+			obj := &treeObject{
+				OID:        n.oid,
+				Label:      treeLabel(n),
+				Identifier: n.reg.Identifier(),
+				DN:         n.reg.DN(),
+			}
+			if r.States {
+				obj.States = treeStates(n.reg)
+			}
+			if len(n.kids) > 0 {
+				obj.Children = objects(n.kids)
+			}
+			objs = append(objs, obj)
+		}
+
+		return
+	}
+
+	b, err := json.MarshalIndent(objects(roots), ``, `  `)
+	if err != nil {
+		ew.err = err
+		return
+	}
+	ew.line(string(b))
+}
+
+/*
+walkTree calls fn for each of the input nodes, and their subordinates,
+in depth-first order, alongside the superior node (nil for the input
+nodes).
+*/
+func walkTree(nodes []*treeNode, fn func(sup, n *treeNode)) {
+	var walk func(*treeNode, []*treeNode)
+	walk = func(sup *treeNode, nodes []*treeNode) {
+		for _, n := range nodes {
+			fn(sup, n)
+			walk(n, n.kids)
+		}
+	}
+	walk(nil, nodes)
+}
+
+/*
+treeOID returns the dotNotation of the input Registration, or a zero
+string if it cannot be determined.
+*/
+func treeOID(reg Registration) (oid string) {
+	switch tv := reg.(type) {
+	case nil:
+		return
+	case *RootArc:
+		if tv == nil {
+			return
+		}
+		oid = tv.N()
+	case *SubArc:
+		if tv == nil {
+			return
+		}
+		oid = tv.DotNotation()
+	}
+
+	if d := reg.DUAConfig(); len(oid) == 0 && d != nil && len(reg.DN()) > 0 {
+		oid, _ = d.RegistrationOID(reg.DN())
+	}
+
+	return
+}
+
+/*
+treeLabel returns the label of the input node.
+*/
+func treeLabel(n *treeNode) string {
+	if nanf := n.reg.NameAndNumberForm(); len(nanf) > 0 {
+		return nanf
+	}
+
+	id, num := n.reg.Identifier(), n.reg.N()
+	switch {
+	case len(id) > 0 && len(num) > 0:
+		return id + `(` + num + `)`
+	case len(num) > 0:
+		return num
+	}
+
+	return n.oid
+}
+
+/*
+treeStates returns the notable states of the input Registration, in order
+of precedence.
+*/
+func treeStates(reg Registration) (states []string) {
+	if status := reg.Status(); len(status) > 0 &&
+		!eq(status, `active`) && !eq(status, `in-force`) {
+		states = append(states, status)
+	}
+	if reg.LeafNode() {
+		states = append(states, `leaf`)
+	}
+	if reg.Frozen() {
+		states = append(states, `frozen`)
+	}
+	if rng := reg.Range(); len(rng) > 0 && rng != `0` {
+		if rng == `-1` {
+			rng = `+`
+		} else {
+			rng = `-` + rng
+		}
+		states = append(states, `range `+reg.N()+rng)
+	}
+
+	return
+}
+
+/*
+treeClass returns the name of the state (a key of treeColors) of highest
+precedence borne by the input Registration, or a zero string if none.
+*/
+func treeClass(reg Registration) string {
+	states := treeStates(reg)
+	if len(states) == 0 {
+		return ``
+	}
+
+	class := lc(states[0])
+	if hasPrefix(class, `range `) {
+		return `range`
+	} else if _, found := treeColors[class]; !found {
+		return `status`
+	}
+
+	return class
+}
+
+/*
+dotQuote returns the input value as a quoted DOT identifier.
+*/
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+/*
+mermaidID returns the Mermaid node identifier of the input dotNotation.
+*/
+func mermaidID(oid string) string {
+	return `n` + replaceAll(oid, `.`, `_`)
+}
@@ -3,8 +3,14 @@ package dcxl
 /*
 type.go encompasses all types, constants and global variables
 defined by this package.
+
+The unmarshal, marshal and related methods of the entry types, as well
+as the Field constants, are generated from the `ldap` struct tags below,
+and must be regenerated whenever the tags change.
 */
 
+//go:generate go run ./internal/genmarshal
+
 const (
 	TwoDimensional   = `1.3.6.1.4.1.56521.101.3.2` // s. 3.2
 	ThreeDimensional = `1.3.6.1.4.1.56521.101.3.3` // s. 3.3
@@ -22,6 +28,12 @@ type instances allow the GetOrSetFunc type to be passed at runtime.
 If no GetOrSetFunc is passed to a Set<*> method, the value is written
 as-is (type assertion permitting) with no special processing.
 
+If several GetOrSetFunc instances are passed to a Set<*> method, they
+are applied in order as a pipeline, such that the value returned by each
+is the input value of the next; see the Compose function. Stock and
+user-registered functions may also be selected by name; see the
+RegisterGetOrSetFunc and ComposeNamed functions.
+
 In the context of Set<*> executions, the first input argument will be
 the value to be written to the appropriate struct field. The second
 input argument will be the (non-nil) POINTER receiver instance that
@@ -96,14 +108,14 @@ entirely optional and at the discretion of the directory owner(s).
 A generic (and optional) map[string][]string instance, via the Settings
 struct field, is available to enhance or augment client behavior.
 
-OID: 1.3.6.1.4.1.56521.101.2.2.3, defined in s. 2.2.3.
+OID: 1.3.6.1.4.1.56521.101.2.2.4, defined in s. 2.2.4.
 */
 type DUAConfig struct {
 	DirectoryModel string   `ldap:"rADirectoryModel"`   // s. 2.1.86, s. 3.2, s. 3.3
 	Registrations  []string `ldap:"rARegistrationBase"` // s. 2.1.84
 	Registrants    []string `ldap:"rARegistrantBase"`   // s. 2.1.85
 	ServiceEmails  []string `ldap:"rAServiceMail"`      // s. 2.1.87
-	ServiceURIs    []string `ldap:"rAServiceURIs"`      // s. 2.1.88
+	ServiceURIs    []string `ldap:"rAServiceURI"`       // s. 2.1.88
 
 	// Not defined in draft-coretta-x660-ldap, but is
 	// sensible to include for client optimization.
@@ -754,6 +766,35 @@ type Registration interface {
 	// map[string][]string, which can be fed to ldap.NewEntry.
 	Unmarshal() map[string][]string
 
+	// Get returns the value(s) of the named attribute type, or nil
+	// if unset or not applicable. The name is matched without regard
+	// for case, and may be an alternative name (e.g.: numberForm).
+	Get(string) []string
+
+	// Set replaces the value(s) of the named attribute type, which
+	// is matched as described for Get. No values clears the attribute
+	// type. An error is returned if the name is not applicable, or if
+	// several values are provided for a single-valued attribute type.
+	Set(string, ...string) error
+
+	// Attributes returns the names of the populated attribute types
+	// of the receiver, in the order in which they are declared.
+	Attributes() []string
+
+	// Clone returns a deep copy of the receiver, sharing no slices,
+	// COMBINED registrants or *DUAConfig instance with it.
+	Clone() Registration
+
+	// Equal returns a boolean value indicative of whether the input
+	// Registration is of the same type and bears equal values. Values are
+	// compared per matching rule, and without regard for order.
+	Equal(Registration) bool
+
+	// Merge merges the values of the input Registration, which must be
+	// of the same type, into the receiver, resolving conflicts of
+	// single-valued attribute types per the MergeStrategy.
+	Merge(Registration, MergeStrategy) error
+
 	// DUAConfig returns an instance of *DUAConfig assigned
 	// to the receiver, else nil if unset.
 	DUAConfig() *DUAConfig
@@ -1042,6 +1083,35 @@ type Registrant interface {
 	// map[string][]string, which can be fed to ldap.NewEntry.
 	Unmarshal() map[string][]string
 
+	// Get returns the value(s) of the named attribute type, or nil
+	// if unset or not applicable. The name is matched without regard
+	// for case.
+	Get(string) []string
+
+	// Set replaces the value(s) of the named attribute type, which
+	// is matched as described for Get. No values clears the attribute
+	// type. An error is returned if the name is not applicable, or if
+	// several values are provided for a single-valued attribute type.
+	Set(string, ...string) error
+
+	// Attributes returns the names of the populated attribute types
+	// of the receiver, in the order in which they are declared.
+	Attributes() []string
+
+	// Clone returns a deep copy of the receiver, sharing no slices,
+	// COMBINED registrants or *DUAConfig instance with it.
+	Clone() Registrant
+
+	// Equal returns a boolean value indicative of whether the input
+	// Registrant is of the same type and bears equal values. Values are
+	// compared per matching rule, and without regard for order.
+	Equal(Registrant) bool
+
+	// Merge merges the values of the input Registrant, which must be
+	// of the same type, into the receiver, resolving conflicts of
+	// single-valued attribute types per the MergeStrategy.
+	Merge(Registrant, MergeStrategy) error
+
 	// DUAConfig returns an instance of *DUAConfig assigned
 	// to the receiver, else nil if unset.
 	DUAConfig() *DUAConfig
@@ -1,6 +1,7 @@
 package dcxl
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -25,6 +26,8 @@ var (
 	hasPrefix  func(string, string) bool           = strings.HasPrefix
 	hasSuffix  func(string, string) bool           = strings.HasSuffix
 	idxRune    func(string, rune) int              = strings.IndexRune
+	indexOf    func(string, string) int            = strings.Index
+	lastIndex  func(string, string) int            = strings.LastIndex
 	join       func([]string, string) string       = strings.Join
 	lc         func(string) string                 = strings.ToLower
 	uc         func(string) string                 = strings.ToUpper
@@ -45,6 +48,8 @@ var (
 	until func(time.Time) time.Duration = time.Until
 	now   func() time.Time              = time.Now
 
+	isErr func(error, error) bool = errors.Is
+
 	typeOf func(any) reflect.Type  = reflect.TypeOf
 	valOf  func(any) reflect.Value = reflect.ValueOf
 
@@ -182,6 +187,11 @@ returned. Ultimately, this function exists in order
 to provide an effective means of turning one of the
 structs defined in this package into something that
 can be fed to ldap.NewEntry.
+
+The Unmarshal methods of this package no longer call
+this function, but rather the reflection-free unmarshal
+methods generated within marshal_gen.go. It is kept as
+the reference against which those are verified.
 */
 func toMap(a any) (m map[string][]string) {
 	ot, ov, ok := getReflectInstances(a)
@@ -358,6 +368,164 @@ func strInSlice(str string, sl []string) bool {
 	return false
 }
 
+/*
+fromMap transports values from the provided map[string][]string instance
+into the struct referenced by the provided pointer (x). Ultimately, this
+function is the inverse of toMap, and exists in order to provide a means
+of turning an LDAP entry (e.g.: one returned by a Directory) into one of
+the structs defined in this package.
+
+Attribute names are matched in case-insensitive fashion, and alternative
+names (e.g.: numberForm) are honored. A `dn` key, if present, is written
+to the R_DN field. Pointer fields, such as those used for COMBINED entries,
+are not processed here.
+
+As with toMap, the Marshal methods of this package use the generated
+marshal methods instead; this function is kept as their reference.
+*/
+func fromMap(m map[string][]string, x any) (err error) {
+	if !isPtr(x) || valOf(x).IsNil() {
+		err = errorf("%v: %T (non-nil pointer required)", UnsupportedInputTypeErr, x)
+		return
+	}
+
+	ot, ov, ok := getReflectInstances(x)
+	if !ok {
+		err = errorf("%v: %T", UnsupportedInputTypeErr, x)
+		return
+	}
+
+	elem := ov.Interface()
+	for i := 0; i < ot.NumField(); i++ {
+		tag, found := extractLTag(ot.Field(i).Name, elem)
+		if !found {
+			continue
+		}
+
+		vals := mapValues(m, tag[0])
+		if len(vals) == 0 {
+			continue
+		}
+
+		// As with toMap, only string and []string
+		// kinds are expected to bear ldap tags.
+		fv := ov.Field(i)
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(vals[0])
+		case reflect.Slice:
+			fv.Set(valOf(append([]string{}, vals...)))
+		}
+	}
+
+	return
+}
+
+/*
+mapValues returns the values assigned to the named attribute type (at)
+within the provided map, or nil if not found. Matching is conducted in
+case-insensitive fashion, and honors alternative names.
+*/
+func mapValues(m map[string][]string, at string) []string {
+	for k, v := range m {
+		if eq(k, at) || eq(canonicalAttr(k), at) {
+			return v
+		}
+	}
+
+	return nil
+}
+
+/*
+hasPrefixedAttr returns a boolean value indicative of whether the
+provided map contains any attribute type bearing the specified prefix
+(pfx), excluding the prefix itself. This is used to detect the presence
+of registrant attribute types (e.g.: sponsorOrg) within an entry.
+*/
+func hasPrefixedAttr(m map[string][]string, pfx string) bool {
+	for k, v := range m {
+		if len(k) > len(pfx) && eq(k[:len(pfx)], pfx) && len(v) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+MarshalRegistration returns an instance of Registration alongside an error
+following an attempt to marshal the provided map[string][]string instance.
+The map is expected to describe an LDAP entry, such as those returned by
+a Directory, and must bear an objectClass of x660RootArc or x660SubArc.
+
+The DN, which is not normally present within such maps, may be provided
+using the `dn` key.
+
+COMBINED registrant values, if present, are marshaled into the appropriate
+embedded fields.
+*/
+func MarshalRegistration(m map[string][]string) (r Registration, err error) {
+	oc := mapValues(m, `objectClass`)
+	switch {
+	case strInSlice(RootArc{}.ObjectClass(), oc):
+		r = new(RootArc)
+	case strInSlice(SubArc{}.ObjectClass(), oc):
+		r = new(SubArc)
+	default:
+		err = errorw(RegistrationValidityErr, "no registration objectClass in %v", oc)
+		return
+	}
+
+	switch tv := r.(type) {
+	case *RootArc:
+		err = tv.Marshal(m)
+	case *SubArc:
+		err = tv.Marshal(m)
+	}
+
+	return
+}
+
+/*
+MarshalRegistrants returns an instance of Registrants alongside an error
+following an attempt to marshal the provided map[string][]string instance.
+
+As a single entry may describe any combination of first authority, current
+authority and sponsor, up to three (3) Registrant instances are returned,
+one per registrant type detected. An error is returned if none are found.
+*/
+func MarshalRegistrants(m map[string][]string) (r Registrants, err error) {
+	if hasPrefixedAttr(m, `firstAuthority`) {
+		f := new(FirstAuthority)
+		if err = f.Marshal(m); err != nil {
+			return
+		}
+		r = append(r, f)
+	}
+
+	if hasPrefixedAttr(m, `currentAuthority`) {
+		c := new(CurrentAuthority)
+		if err = c.Marshal(m); err != nil {
+			return
+		}
+		r = append(r, c)
+	}
+
+	if hasPrefixedAttr(m, `sponsor`) {
+		s := new(Sponsor)
+		if err = s.Marshal(m); err != nil {
+			return
+		}
+		r = append(r, s)
+	}
+
+	if len(r) == 0 {
+		err = errorw(RegistrantValidityErr, `no registrant attribute types found`)
+	}
+
+	return
+}
+
 /*
 Relegate transports all values from input instance of *CurrentAuthority (c) -- which
 MUST be an actual pointer -- into a new instance of *FirstAuthority, which is then
@@ -449,3 +617,66 @@ func Relegate(X any, c *CurrentAuthority) (F *FirstAuthority, err error) {
 
 	return
 }
+
+/*
+splitDN returns the individual RDN components of the input string DN,
+honoring any escaped comma (,) characters. Leading and trailing white
+space surrounding each RDN is removed.
+*/
+func splitDN(dn string) (rdns []string) {
+	var last int
+	for i := 0; i < len(dn); i++ {
+		if dn[i] == '\\' {
+			i++
+			continue
+		} else if dn[i] == ',' {
+			rdns = append(rdns, trimS(dn[last:i]))
+			last = i + 1
+		}
+	}
+
+	if rest := trimS(dn[last:]); len(rest) > 0 || len(rdns) > 0 {
+		rdns = append(rdns, rest)
+	}
+
+	return
+}
+
+/*
+normalizeDN returns a normalized form of the input string DN suitable
+for comparison purposes. Attribute types and values are folded to lower
+case, escape sequences are resolved and extraneous white space around
+the RDN and AVA delimiters is removed.
+
+Note this is a simplified approximation of distinguishedNameMatch, as
+described in section 4.2.15 of RFC 4517, which does not consider the
+matching rules of the individual attribute types.
+*/
+func normalizeDN(dn string) string {
+	rdns := splitDN(dn)
+	for i := 0; i < len(rdns); i++ {
+		if idx := idxRune(rdns[i], '='); idx != -1 {
+			at := lc(canonicalAttr(trimS(rdns[i][:idx])))
+			val := lc(unescapeDNValue(trimS(rdns[i][idx+1:])))
+			rdns[i] = at + `=` + replaceAll(replaceAll(val, `\`, `\\`), `,`, `\,`)
+		}
+	}
+
+	return join(rdns, `,`)
+}
+
+/*
+canonicalAttr returns the preferred attribute type descriptor for the
+input descriptor, resolving the alternative names (e.g.: 'numberForm')
+defined within the altnames map in case-insensitive fashion. If the
+input is not an alternative name, it is returned as-is.
+*/
+func canonicalAttr(at string) string {
+	for alt, pref := range altnames {
+		if eq(alt, at) {
+			return pref
+		}
+	}
+
+	return at
+}
@@ -103,7 +103,7 @@ type DUAConfig struct {
 	Registrations  []string `ldap:"rARegistrationBase"` // s. 2.1.84
 	Registrants    []string `ldap:"rARegistrantBase"`   // s. 2.1.85
 	ServiceEmails  []string `ldap:"rAServiceMail"`      // s. 2.1.87
-	ServiceURIs    []string `ldap:"rAServiceURIs"`      // s. 2.1.88
+	ServiceURIs    []string `ldap:"rAServiceURI"`       // s. 2.1.88
 
 	// Not defined in draft-coretta-x660-ldap, but is
 	// sensible to include for client optimization.